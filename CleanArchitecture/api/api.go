@@ -0,0 +1,17 @@
+// Package api - /api/v1/users OpenAPI 계약 (openapi.yaml)
+//
+// 문서는 바이너리에 내장되어 HTTP 서버가 제공하고 요청 검증에 쓰며,
+// client 패키지의 타입 있는 Go 클라이언트는 이 문서로 생성한다 (go generate ./api/...).
+package api
+
+import _ "embed"
+
+//go:generate go tool oapi-codegen -config oapi-codegen.yaml openapi.yaml
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Spec - OpenAPI 문서 원문 (YAML)
+func Spec() []byte {
+	return spec
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/api"
+	"github.com/milman2/go-api/clean-architecture/api/client"
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+// 생성된 클라이언트가 api/openapi.yaml과 맞아야 함 (다르면 go generate ./api/... 실행)
+func TestGeneratedClientUpToDate(t *testing.T) {
+	raw, err := os.ReadFile("../oapi-codegen.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg struct {
+		codegen.Configuration `yaml:",inline"`
+		Output                string `yaml:"output"`
+	}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := util.LoadSwagger("../openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := codegen.Generate(spec, cfg.Configuration.UpdateDefaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("client.gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 머리 주석의 생성기 버전은 빌드 방식에 따라 달라지므로 package 절부터 비교
+	if withoutHeader(string(got)) != withoutHeader(want) {
+		t.Fatal("client.gen.go is stale; run go generate ./api/...")
+	}
+}
+
+func withoutHeader(src string) string {
+	_, body, _ := strings.Cut(src, "\npackage ")
+	return body
+}
+
+// 생성된 클라이언트로 실제 라우터를 호출 (검증 미들웨어 포함)
+func TestClientRoundTrip(t *testing.T) {
+	oa, err := deliveryhttp.NewOpenAPI(api.Spec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := usecase.NewUserUseCase(memory.NewUserRepository())
+	srv := httptest.NewServer(deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{TrustHeader: true})),
+		deliveryhttp.WithOpenAPI(oa),
+	))
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	tenant := "acme"
+
+	created, err := c.CreateUserWithResponse(ctx, &client.CreateUserParams{XTenantID: &tenant},
+		client.CreateUserRequest{Email: "kim@example.com", Name: "Kim"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.JSON201 == nil {
+		t.Fatalf("create: got %d: %s", created.StatusCode(), created.Body)
+	}
+	user := created.JSON201
+	if user.TenantId != tenant || user.Status != client.UserStatusActive || user.CreatedAt.IsZero() {
+		t.Fatalf("created = %+v", user)
+	}
+
+	got, err := c.GetUserWithResponse(ctx, user.Id, &client.GetUserParams{XTenantID: &tenant})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.JSON200 == nil || got.JSON200.Email != "kim@example.com" {
+		t.Fatalf("get: got %d: %s", got.StatusCode(), got.Body)
+	}
+
+	missing, err := c.GetUserWithResponse(ctx, "missing", &client.GetUserParams{XTenantID: &tenant})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.JSON404 == nil || missing.JSON404.Code != "user_not_found" {
+		t.Fatalf("get missing: got %d: %s", missing.StatusCode(), missing.Body)
+	}
+
+	// 검증 실패는 details와 함께 400
+	limit := 0
+	bad, err := c.SearchUsersWithResponse(ctx, &client.SearchUsersParams{XTenantID: &tenant, Q: "kim", Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bad.JSON400 == nil || bad.JSON400.Code != "invalid_request" || bad.JSON400.Details == nil {
+		t.Fatalf("search: got %d: %s", bad.StatusCode(), bad.Body)
+	}
+	if d := (*bad.JSON400.Details)[0]; d.In != client.ValidationErrorDetailInQuery || d.Name == nil || *d.Name != "limit" {
+		t.Fatalf("details = %+v", *bad.JSON400.Details)
+	}
+
+	if resp, err := c.DeleteUser(ctx, user.Id, &client.DeleteUserParams{XTenantID: &tenant}); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("delete: %v, %v", resp, err)
+	}
+}
@@ -1,40 +1,138 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
-	httpDelivery "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
-	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
-	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/milman2/go-api/clean-architecture/internal/app"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	// run의 defer(저장소 닫기 등)가 끝난 뒤 종료 코드로 나감
+	os.Exit(run())
+}
+
+func run() int {
 	// 의존성 주입 (Dependency Injection)
-	// 외부 레이어에서 내부 레이어로 의존성 주입
+	// 저장소 → 유스케이스 → 핸들러 조립은 컴포지션 루트(internal/app)가 담당
+	cfg := app.LoadConfig()
+
+	// SIGINT/SIGTERM을 받으면 서버를 정리하고 종료
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 백그라운드 작업과 저장소는 서버가 요청을 다 처리한 뒤에 멈춤
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	api, cleanup, err := app.NewAPI(ctx, cfg, app.DefaultProviders())
+	if err != nil {
+		log.Printf("의존성 조립 실패: %v", err)
+		return 1
+	}
+	defer cleanup()
 
-	// 1. Repository 생성 (가장 바깥 레이어)
-	userRepo := memory.NewUserRepository()
+	var workers sync.WaitGroup
+	startWorker := func(run func(context.Context) error) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
+	// 정리(cleanup) 전에 작업이 끝나기를 기다림 (defer는 역순이므로 cleanup보다 먼저 실행)
+	defer workers.Wait()
+	defer cancel()
 
-	// 2. Use Case 생성 (중간 레이어)
-	userUseCase := usecase.NewUserUseCase(userRepo)
+	// 사용자 이벤트를 구독 중인 웹훅으로 전송 (실패 시 백오프로 재시도)
+	startWorker(api.Webhooks.Run)
 
-	// 3. Handler 생성 (프레젠테이션 레이어)
-	userHandler := httpDelivery.NewUserHandler(userUseCase)
+	// 가입 환영/계정 변경 알림 메일 전송 (실패 시 백오프로 재시도)
+	if api.Notifications != nil {
+		startWorker(api.Notifications.Run)
+	}
 
-	// 4. Router 설정
-	router := httpDelivery.NewRouter(userHandler)
+	// 개인정보 내보내기/삭제 요청 처리
+	startWorker(api.Privacy.Run)
 
-	// 5. 서버 시작
-	addr := ":8080"
-	log.Printf("🚀 Clean Architecture 서버가 %s 포트에서 시작되었습니다\n", addr)
+	// 주기 유지보수 작업 (여러 인스턴스면 SCHEDULER_LEASE_STORE=spanner로 회차마다 한 곳만 실행)
+	startWorker(api.Scheduler.Run)
+
+	// 서버 시작 (HTTP + gRPC 동시 실행)
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		log.Printf("gRPC 리스너 생성 실패: %v", err)
+		return 1
+	}
+	httpServer := &http.Server{Addr: cfg.HTTPAddr, Handler: api.HTTP}
+	// 변경 피드(SSE) 연결은 스스로 끝나지 않으므로 종료를 시작하면 피드를 닫아 스트림을 끝냄
+	httpServer.RegisterOnShutdown(api.Events.Close)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		log.Printf("🚀 gRPC 서버가 %s 포트에서 시작되었습니다\n", cfg.GRPCAddr)
+		if err := api.GRPC.Serve(lis); err != nil {
+			serveErr <- fmt.Errorf("gRPC 서버 실행 실패: %w", err)
+		}
+	}()
+	go func() {
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("서버 실행 실패: %w", err)
+		}
+	}()
+
+	log.Printf("🚀 Clean Architecture 서버가 %s 포트에서 시작되었습니다\n", cfg.HTTPAddr)
 	log.Printf("📖 Clean Architecture 레이어:\n")
 	log.Printf("   - Domain (Entities): internal/domain\n")
 	log.Printf("   - Use Cases: internal/usecase\n")
 	log.Printf("   - Interface Adapters: internal/repository\n")
-	log.Printf("   - Frameworks & Drivers: internal/delivery/http\n")
+	log.Printf("   - Frameworks & Drivers: internal/delivery/http, internal/delivery/grpc, internal/delivery/graphql\n")
+	log.Printf("   - Composition Root: internal/app\n")
+
+	code := 0
+	select {
+	case <-sigCtx.Done():
+		log.Printf("종료 신호를 받아 서버를 정리합니다 (최대 %s)", cfg.ShutdownTimeout)
+	case err := <-serveErr:
+		log.Print(err)
+		code = 1
+	}
+	// 두 번째 신호는 기본 동작(즉시 종료)
+	stop()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// 새 연결은 받지 않고 처리 중인 요청이 끝나기를 기다림 (제한 시간이 지나면 강제로 닫음)
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP 서버 정리 시간 초과, 남은 연결을 닫습니다: %v", err)
+		httpServer.Close()
+	}
+	gracefulStop(shutdownCtx, api.GRPC)
+	return code
+}
+
+// gracefulStop - 처리 중인 RPC가 끝나기를 기다리고, ctx가 끝나면 강제로 멈춤
+func gracefulStop(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
 
-	if err := http.ListenAndServe(addr, router); err != nil {
-		log.Fatalf("서버 시작 실패: %v", err)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("gRPC 서버 정리 시간 초과, 남은 RPC를 끊습니다")
+		s.Stop()
 	}
 }
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/bulk"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// cli - 명령 실행기 (프레젠테이션 레이어)
+type cli struct {
+	userUseCase *usecase.UserUseCase
+	tenantID    string
+	keyringFile string
+	out         printer
+	stdout      io.Writer
+	stdin       io.Reader
+	stderr      io.Writer
+}
+
+// dispatch - 하위 명령 실행
+func (c *cli) dispatch(cmd string, args []string) error {
+	ctx := tenant.WithID(context.Background(), c.tenantID)
+
+	switch cmd {
+	case "create":
+		return c.create(ctx, args)
+	case "get":
+		return c.get(ctx, args)
+	case "list":
+		return c.list(ctx, args)
+	case "update":
+		return c.update(ctx, args)
+	case "delete":
+		return c.delete(ctx, args)
+	case "import":
+		return c.importUsers(ctx, args)
+	case "rotate-key":
+		return c.rotateKey(args)
+	default:
+		fmt.Fprintf(c.stderr, "알 수 없는 명령: %s\n\n%s", cmd, usage)
+		return errUsage
+	}
+}
+
+// newFlagSet - 하위 명령용 FlagSet
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// parse - 플래그 파싱 (위치 인자가 플래그 앞에 와도 허용)
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// requireID - 위치 인자로 받은 ID 하나 확인
+func (c *cli) requireID(cmd string, positional []string) (string, error) {
+	if len(positional) != 1 {
+		fmt.Fprintf(c.stderr, "사용법: userctl %s <id>\n", cmd)
+		return "", errUsage
+	}
+	return positional[0], nil
+}
+
+// create - 사용자 생성
+func (c *cli) create(ctx context.Context, args []string) error {
+	fs := c.newFlagSet("create")
+	email := fs.String("email", "", "email")
+	name := fs.String("name", "", "name")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	user, err := c.userUseCase.CreateUser(ctx, *email, *name)
+	if err != nil {
+		return err
+	}
+
+	return c.out.PrintUser(user)
+}
+
+// get - 사용자 조회
+func (c *cli) get(ctx context.Context, args []string) error {
+	positional, err := parse(c.newFlagSet("get"), args)
+	if err != nil {
+		return err
+	}
+	id, err := c.requireID("get", positional)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.userUseCase.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.out.PrintUser(user)
+}
+
+// list - 모든 사용자 조회
+func (c *cli) list(ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlagSet("list"), args); err != nil {
+		return err
+	}
+
+	users, err := c.userUseCase.GetAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.out.PrintUsers(users)
+}
+
+// update - 사용자 이름 수정
+func (c *cli) update(ctx context.Context, args []string) error {
+	fs := c.newFlagSet("update")
+	name := fs.String("name", "", "name")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := c.requireID("update", positional)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.userUseCase.UpdateUser(ctx, id, *name)
+	if err != nil {
+		return err
+	}
+
+	return c.out.PrintUser(user)
+}
+
+// delete - 사용자 삭제
+func (c *cli) delete(ctx context.Context, args []string) error {
+	positional, err := parse(c.newFlagSet("delete"), args)
+	if err != nil {
+		return err
+	}
+	id, err := c.requireID("delete", positional)
+	if err != nil {
+		return err
+	}
+
+	if err := c.userUseCase.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "삭제됨: %s\n", id)
+	return nil
+}
+
+// importUsers - CSV/NDJSON 파일에서 사용자 일괄 생성
+// 실패한 행은 건너뛰고 계속 진행, 하나라도 실패하면 에러 반환
+func (c *cli) importUsers(ctx context.Context, args []string) error {
+	fs := c.newFlagSet("import")
+	format := fs.String("format", "", "csv | ndjson (기본값: 확장자로 판단)")
+	dryRun := fs.Bool("dry-run", false, "저장하지 않고 검증만 수행")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(c.stderr, "사용법: userctl import [-format csv|ndjson] [-dry-run] <file>")
+		return errUsage
+	}
+
+	path := positional[0]
+	var r io.Reader = c.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = bulk.FormatCSV
+		case ".ndjson", ".jsonl":
+			*format = bulk.FormatNDJSON
+		default:
+			fmt.Fprintln(c.stderr, "형식을 알 수 없습니다: -format csv|ndjson 을 지정하세요")
+			return errUsage
+		}
+	}
+
+	reader, err := bulk.NewReader(*format, r)
+	if errors.Is(err, bulk.ErrUnsupportedFormat) {
+		fmt.Fprintf(c.stderr, "지원하지 않는 형식: %s\n", *format)
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	summary, err := c.userUseCase.ImportUsers(ctx, reader, *dryRun, func(res usecase.ImportResult) error {
+		switch {
+		case res.Err != nil:
+			fmt.Fprintf(c.stderr, "행 %d (%s): %v\n", res.Line, res.Email, res.Err)
+		case *dryRun:
+			fmt.Fprintf(c.stderr, "행 %d: 유효함 %s\n", res.Line, res.Email)
+		default:
+			fmt.Fprintf(c.stderr, "행 %d: 생성됨 %s\n", res.Line, res.User.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "가져오기 완료: 전체 %d, 성공 %d, 실패 %d\n", summary.Total, summary.Succeeded, summary.Failed)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d개 행 가져오기 실패", summary.Failed)
+	}
+	return nil
+}
+
+// rotateKey - 필드 암호화 키 교체 (새 키 ID는 stdout)
+// 이전 키는 키링에 남으며, 기존 데이터는 저장소가 다음에 쓸 때 새 키로 다시 암호화됨
+// 실행 중인 서버는 재시작해야 새 키로 암호화함
+func (c *cli) rotateKey(args []string) error {
+	if _, err := parse(c.newFlagSet("rotate-key"), args); err != nil {
+		return err
+	}
+	if c.keyringFile == "" {
+		fmt.Fprintln(c.stderr, "키링 파일이 없습니다: KEYRING_FILE을 지정하세요")
+		return errUsage
+	}
+
+	keys, err := fieldcrypt.OpenFileKeyring(c.keyringFile)
+	if err != nil {
+		return err
+	}
+	info, err := keys.Rotate()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, info.ID)
+	fmt.Fprintf(c.stderr, "키 교체됨: 이전 키 %d개 유지, 기존 데이터는 다음 변경 때 다시 암호화됩니다\n", len(keys.Keys())-1)
+	return nil
+}
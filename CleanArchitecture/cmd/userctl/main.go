@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/milman2/go-api/clean-architecture/internal/app"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// 종료 코드 (도메인 에러별로 구분)
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitConflict = 4
+	exitInvalid  = 5
+	exitDenied   = 6
+)
+
+// errUsage - 잘못된 명령행 사용
+var errUsage = errors.New("usage error")
+
+const usage = `userctl - 사용자 관리 CLI
+
+사용법:
+  userctl [전역 옵션] <명령> [옵션]
+
+명령:
+  create -email <email> -name <name>   사용자 생성
+  get <id>                             사용자 조회
+  list                                 모든 사용자 조회
+  update <id> -name <name>             사용자 이름 수정
+  delete <id>                          사용자 삭제
+  import [-format csv|ndjson] [-dry-run] <file>
+                                       CSV/NDJSON 파일에서 일괄 생성 (- 는 stdin)
+  rotate-key                           필드 암호화 키 교체 ($KEYRING_FILE, 기존 데이터는 다음 변경 때 다시 암호화)
+
+전역 옵션:
+  -repo   file | memory   (기본값: $USERCTL_REPO 또는 file)
+  -data   파일 경로        (기본값: $USERCTL_DATA 또는 users.json)
+  -tenant 테넌트 ID        (기본값: $USERCTL_TENANT 또는 default)
+  -o      table | json | yaml (기본값: table)
+
+환경 변수:
+  KEYRING_FILE  이메일/이름 필드 암호화 키링 파일 (지정하면 데이터 파일에 암호문만 저장)
+
+종료 코드:
+  0 성공, 1 일반 오류, 2 사용법 오류, 3 사용자 없음, 4 중복, 5 잘못된 입력,
+  6 다른 테넌트의 사용자
+`
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run - 명령 실행 후 종료 코드 반환
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("userctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+
+	repoKind := fs.String("repo", getEnv("USERCTL_REPO", "file"), "repository: file | memory")
+	dataPath := fs.String("data", getEnv("USERCTL_DATA", "users.json"), "data file path")
+	tenantID := fs.String("tenant", getEnv("USERCTL_TENANT", tenant.Default), "tenant id")
+	outputFormat := fs.String("o", "table", "output: table | json | yaml")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	out, err := newPrinter(*outputFormat, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitUsage
+	}
+	if !tenant.Valid(*tenantID) {
+		fmt.Fprintf(stderr, "error: %v: %s\n", domain.ErrInvalidTenant, *tenantID)
+		return exitUsage
+	}
+
+	// 의존성 주입: 컴포지션 루트가 Repository → Use Case 조립
+	if *repoKind != "file" && *repoKind != "memory" {
+		fmt.Fprintf(stderr, "error: 알 수 없는 저장소: %s\n", *repoKind)
+		return exitUsage
+	}
+	userUseCase, cleanup, err := app.NewCLI(context.Background(), app.Config{
+		UserStore:    *repoKind,
+		UserDataFile: *dataPath,
+		KeyringFile:  getEnv("KEYRING_FILE", ""),
+		AvatarDir:    getEnv("AVATAR_DIR", ""),
+	}, app.DefaultProviders())
+	if err != nil {
+		fmt.Fprintf(stderr, "error: 저장소 열기 실패: %v\n", err)
+		return exitError
+	}
+	defer cleanup()
+
+	cli := &cli{
+		userUseCase: userUseCase,
+		tenantID:    *tenantID,
+		keyringFile: getEnv("KEYRING_FILE", ""),
+		out:         out,
+		stdout:      stdout,
+		stdin:       stdin,
+		stderr:      stderr,
+	}
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	if err := cli.dispatch(cmd, cmdArgs); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+		}
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+// exitCode - 에러를 종료 코드로 변환
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, domain.ErrUserNotFound):
+		return exitNotFound
+	case errors.Is(err, domain.ErrUserExists):
+		return exitConflict
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidUserID):
+		return exitInvalid
+	case errors.Is(err, domain.ErrCrossTenant):
+		return exitDenied
+	default:
+		return exitError
+	}
+}
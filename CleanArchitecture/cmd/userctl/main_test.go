@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+// isolateEnv - 테스트가 실행 환경의 USERCTL_* / KEYRING_FILE 설정을 읽지 않게 함
+func isolateEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"USERCTL_REPO", "USERCTL_DATA", "USERCTL_TENANT", "KEYRING_FILE", "AVATAR_DIR"} {
+		t.Setenv(key, "")
+	}
+}
+
+// TestRun - 명령행 파싱과 종료 코드 (매 실행마다 새 메모리 저장소)
+func TestRun(t *testing.T) {
+	isolateEnv(t)
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		code       int
+		stdout     []string // stdout에 있어야 하는 문자열
+		stderr     []string // stderr에 있어야 하는 문자열
+		wantStdout bool     // false면 stdout이 비어 있어야 함
+	}{
+		{name: "no command", args: []string{"-repo", "memory"}, code: exitUsage, stderr: []string{"사용법:"}},
+		{name: "help", args: []string{"-h"}, code: exitOK, stderr: []string{"종료 코드:"}},
+		{name: "unknown global flag", args: []string{"-nope", "list"}, code: exitUsage},
+		{name: "unknown command", args: []string{"-repo", "memory", "frobnicate"}, code: exitUsage, stderr: []string{"알 수 없는 명령: frobnicate"}},
+		{name: "unknown repository", args: []string{"-repo", "spanner", "list"}, code: exitUsage, stderr: []string{"알 수 없는 저장소: spanner"}},
+		{name: "unknown output", args: []string{"-repo", "memory", "-o", "xml", "list"}, code: exitUsage, stderr: []string{"지원하지 않는 출력 형식: xml"}},
+		{name: "invalid tenant", args: []string{"-repo", "memory", "-tenant", "Bad Tenant!", "list"}, code: exitUsage, stderr: []string{"invalid tenant"}},
+		{
+			name: "create", args: []string{"-repo", "memory", "-o", "json", "create", "-email", "kim@example.com", "-name", "Kim"},
+			code: exitOK, stdout: []string{`"email": "kim@example.com"`, `"tenant_id": "default"`}, wantStdout: true,
+		},
+		{
+			name: "create in tenant", args: []string{"-repo", "memory", "-tenant", "acme", "-o", "yaml", "create", "-email", "kim@example.com", "-name", "Kim"},
+			code: exitOK, stdout: []string{"tenant_id: acme", "name: Kim"}, wantStdout: true,
+		},
+		{name: "create without email", args: []string{"-repo", "memory", "create", "-name", "Kim"}, code: exitInvalid, stderr: []string{"error: invalid email"}},
+		{name: "create unknown flag", args: []string{"-repo", "memory", "create", "-mail", "kim@example.com"}, code: exitUsage},
+		{name: "get missing", args: []string{"-repo", "memory", "get", "missing"}, code: exitNotFound, stderr: []string{"error: user not found"}},
+		{name: "get without id", args: []string{"-repo", "memory", "get"}, code: exitUsage, stderr: []string{"사용법: userctl get <id>"}},
+		{name: "get two ids", args: []string{"-repo", "memory", "get", "a", "b"}, code: exitUsage},
+		{name: "update missing", args: []string{"-repo", "memory", "update", "missing", "-name", "Lee"}, code: exitNotFound},
+		{name: "delete missing", args: []string{"-repo", "memory", "delete", "missing"}, code: exitNotFound},
+		{name: "list empty", args: []string{"-repo", "memory", "list"}, code: exitOK, stdout: []string{"ID  EMAIL  NAME  CREATED AT  UPDATED AT"}, wantStdout: true},
+		{
+			name: "import stdin", args: []string{"-repo", "memory", "import", "-format", "csv", "-"},
+			stdin: "email,name\nkim@example.com,Kim\nlee@example.com,Lee\n",
+			code:  exitOK, stderr: []string{"가져오기 완료: 전체 2, 성공 2, 실패 0"},
+		},
+		{
+			name: "import partial failure", args: []string{"-repo", "memory", "import", "-format", "ndjson", "-"},
+			stdin: `{"email":"kim@example.com","name":"Kim"}` + "\n" + `{"email":"kim@example.com","name":"Kim"}` + "\n",
+			code:  exitError, stderr: []string{"행 2 (kim@example.com): user already exists", "성공 1, 실패 1"},
+		},
+		{
+			name: "import dry run", args: []string{"-repo", "memory", "import", "-dry-run", "-format", "csv", "-"},
+			stdin: "email,name\nkim@example.com,Kim\n",
+			code:  exitOK, stderr: []string{"행 2: 유효함 kim@example.com"},
+		},
+		{name: "import without format", args: []string{"-repo", "memory", "import", "-"}, code: exitUsage, stderr: []string{"-format csv|ndjson"}},
+		{name: "import unsupported format", args: []string{"-repo", "memory", "import", "-format", "xml", "-"}, code: exitUsage, stderr: []string{"지원하지 않는 형식: xml"}},
+		{name: "rotate-key without keyring", args: []string{"-repo", "memory", "rotate-key"}, code: exitUsage, stderr: []string{"KEYRING_FILE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Fatalf("exit code = %d, want %d\nstdout: %s\nstderr: %s", code, tt.code, &stdout, &stderr)
+			}
+			for _, want := range tt.stdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout missing %q:\n%s", want, &stdout)
+				}
+			}
+			for _, want := range tt.stderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("stderr missing %q:\n%s", want, &stderr)
+				}
+			}
+			if !tt.wantStdout && stdout.Len() > 0 {
+				t.Errorf("unexpected stdout:\n%s", &stdout)
+			}
+		})
+	}
+}
+
+// exec - 같은 유스케이스(메모리 저장소)로 하위 명령 실행
+func exec(t *testing.T, users *usecase.UserUseCase, tenantID, format string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	out, err := newPrinter(format, &stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &cli{userUseCase: users, tenantID: tenantID, out: out, stdout: &stdout, stdin: strings.NewReader(""), stderr: &stderr}
+	code := exitCode(c.dispatch(args[0], args[1:]))
+	return code, stdout.String(), stderr.String()
+}
+
+// TestCommands - 여러 명령을 이어서 실행하며 출력 형식 확인
+func TestCommands(t *testing.T) {
+	users := usecase.NewUserUseCase(memory.NewUserRepository())
+
+	code, out, _ := exec(t, users, "acme", "json", "create", "-email", "kim@example.com", "-name", "Kim")
+	if code != exitOK {
+		t.Fatalf("create: exit %d", code)
+	}
+	var kim userView
+	if err := json.Unmarshal([]byte(out), &kim); err != nil {
+		t.Fatalf("create output: %v\n%s", err, out)
+	}
+	if kim.ID == "" || kim.TenantID != "acme" || kim.Email != "kim@example.com" || kim.Name != "Kim" || kim.CreatedAt == "" {
+		t.Fatalf("create = %+v", kim)
+	}
+
+	// 위치 인자가 플래그 앞에 와도 됨
+	if code, _, _ := exec(t, users, "acme", "table", "update", kim.ID, "-name", "Kim Updated"); code != exitOK {
+		t.Fatalf("update: exit %d", code)
+	}
+	if code, _, _ := exec(t, users, "acme", "table", "create", "-name", "Lee", "-email", "lee@example.com"); code != exitOK {
+		t.Fatalf("create lee: exit %d", code)
+	}
+
+	tests := []struct {
+		name   string
+		tenant string
+		format string
+		args   []string
+		code   int
+		check  func(t *testing.T, stdout, stderr string)
+	}{
+		{"get table", "acme", "table", []string{"get", kim.ID}, exitOK, func(t *testing.T, stdout, _ string) {
+			lines := strings.Split(strings.TrimSpace(stdout), "\n")
+			if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Kim Updated") {
+				t.Fatalf("table output:\n%s", stdout)
+			}
+		}},
+		{"list json in creation order", "acme", "json", []string{"list"}, exitOK, func(t *testing.T, stdout, _ string) {
+			var views []userView
+			if err := json.Unmarshal([]byte(stdout), &views); err != nil {
+				t.Fatalf("decode: %v\n%s", err, stdout)
+			}
+			if len(views) != 2 || views[0].Email != "kim@example.com" || views[1].Email != "lee@example.com" {
+				t.Fatalf("list = %+v", views)
+			}
+		}},
+		{"list yaml", "acme", "yaml", []string{"list"}, exitOK, func(t *testing.T, stdout, _ string) {
+			var views []userView
+			if err := yaml.Unmarshal([]byte(stdout), &views); err != nil {
+				t.Fatalf("decode: %v\n%s", err, stdout)
+			}
+			if len(views) != 2 || views[0].Name != "Kim Updated" || views[1].TenantID != "acme" {
+				t.Fatalf("list = %+v", views)
+			}
+		}},
+		{"list other tenant", "other", "json", []string{"list"}, exitOK, func(t *testing.T, stdout, _ string) {
+			if strings.TrimSpace(stdout) != "[]" {
+				t.Fatalf("list = %s", stdout)
+			}
+		}},
+		{"get other tenant", "other", "table", []string{"get", kim.ID}, exitDenied, nil},
+		{"duplicate email", "acme", "table", []string{"create", "-email", "kim@example.com", "-name", "Kim"}, exitConflict, nil},
+		{"empty name", "acme", "table", []string{"update", kim.ID, "-name", ""}, exitInvalid, nil},
+		{"delete", "acme", "table", []string{"delete", kim.ID}, exitOK, func(t *testing.T, stdout, stderr string) {
+			if stdout != "" || !strings.Contains(stderr, "삭제됨: "+kim.ID) {
+				t.Fatalf("delete output: stdout %q, stderr %q", stdout, stderr)
+			}
+		}},
+		{"get deleted", "acme", "table", []string{"get", kim.ID}, exitNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := exec(t, users, tt.tenant, tt.format, tt.args...)
+			if code != tt.code {
+				t.Fatalf("exit code = %d, want %d\nstdout: %s\nstderr: %s", code, tt.code, stdout, stderr)
+			}
+			if tt.check != nil {
+				tt.check(t, stdout, stderr)
+			}
+		})
+	}
+}
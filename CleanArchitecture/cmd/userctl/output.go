@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// userView - 출력용 DTO (HTTP 응답과 같은 필드 이름)
+type userView struct {
+	ID        string `json:"id" yaml:"id"`
+	TenantID  string `json:"tenant_id" yaml:"tenant_id"`
+	Email     string `json:"email" yaml:"email"`
+	Name      string `json:"name" yaml:"name"`
+	CreatedAt string `json:"created_at" yaml:"created_at"`
+	UpdatedAt string `json:"updated_at" yaml:"updated_at"`
+}
+
+func toUserView(user *domain.User) userView {
+	return userView{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// printer - 출력 형식별 구현
+type printer interface {
+	PrintUser(user *domain.User) error
+	PrintUsers(users []*domain.User) error
+}
+
+// newPrinter - 형식 이름으로 printer 생성
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case "table":
+		return &tablePrinter{w: w}, nil
+	case "json":
+		return &jsonPrinter{w: w}, nil
+	case "yaml":
+		return &yamlPrinter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 출력 형식: %s", format)
+	}
+}
+
+// sortedViews - 생성 시각 순으로 정렬된 출력 DTO
+func sortedViews(users []*domain.User) []userView {
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+	views := make([]userView, len(users))
+	for i, user := range users {
+		views[i] = toUserView(user)
+	}
+	return views
+}
+
+// tablePrinter - 사람이 읽기 좋은 표 형식
+type tablePrinter struct {
+	w io.Writer
+}
+
+func (p *tablePrinter) PrintUser(user *domain.User) error {
+	return p.PrintUsers([]*domain.User{user})
+}
+
+func (p *tablePrinter) PrintUsers(users []*domain.User) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEMAIL\tNAME\tCREATED AT\tUPDATED AT")
+	for _, v := range sortedViews(users) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.ID, v.Email, v.Name, v.CreatedAt, v.UpdatedAt)
+	}
+	return tw.Flush()
+}
+
+// jsonPrinter - JSON 형식
+type jsonPrinter struct {
+	w io.Writer
+}
+
+func (p *jsonPrinter) PrintUser(user *domain.User) error {
+	return p.encode(toUserView(user))
+}
+
+func (p *jsonPrinter) PrintUsers(users []*domain.User) error {
+	return p.encode(sortedViews(users))
+}
+
+func (p *jsonPrinter) encode(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// yamlPrinter - YAML 형식
+type yamlPrinter struct {
+	w io.Writer
+}
+
+func (p *yamlPrinter) PrintUser(user *domain.User) error {
+	return p.encode(toUserView(user))
+}
+
+func (p *yamlPrinter) PrintUsers(users []*domain.User) error {
+	return p.encode(sortedViews(users))
+}
+
+func (p *yamlPrinter) encode(v interface{}) error {
+	enc := yaml.NewEncoder(p.w)
+	defer enc.Close()
+	return enc.Encode(v)
+}
@@ -0,0 +1,305 @@
+// Package app - 컴포지션 루트 (저장소 → 유스케이스 → 핸들러 조립)
+//
+// 바이너리(cmd/api, cmd/userctl 등)는 직접 의존성을 만들지 않고 이 패키지의 주입기를 호출한다.
+// 주입기는 Wire가 생성하는 코드와 같은 모양으로 손으로 작성했다:
+// 프로바이더를 의존 순서대로 부르고, 실패하면 그때까지 만든 자원을 정리하며,
+// 성공하면 모든 정리 함수를 만든 순서의 역순으로 부르는 cleanup 하나를 돌려준다.
+// 조립이 잘못되면 런타임이 아니라 컴파일 시점에 드러난다.
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/api"
+	"github.com/milman2/go-api/clean-architecture/internal/changefeed"
+	graphqlDelivery "github.com/milman2/go-api/clean-architecture/internal/delivery/graphql"
+	grpcDelivery "github.com/milman2/go-api/clean-architecture/internal/delivery/grpc"
+	httpDelivery "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/notify"
+	"github.com/milman2/go-api/clean-architecture/internal/privacy"
+	"github.com/milman2/go-api/clean-architecture/internal/ratelimit"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/cache"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/search"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/milman2/go-api/clean-architecture/internal/webhook"
+	"google.golang.org/grpc"
+)
+
+// Core - 모든 바이너리가 공유하는 유스케이스 그래프
+type Core struct {
+	Users         *usecase.UserUseCase
+	Schemas       *usecase.AttributeSchemaUseCase
+	Webhooks      *webhook.Service       // 이벤트 전송은 Webhooks.Run을 실행한 프로세스가 담당
+	Notifications *notify.Service        // 알림 메일 (Notifications.Run이 전송, 끄면 nil)
+	Index         *search.UserRepository // 검색 색인 (주기 작업이 재생성)
+	Privacy       *privacy.Service       // 개인정보 내보내기/삭제 (Privacy.Run이 요청을 처리)
+	Events        *changefeed.Feed       // 사용자 변경 피드 (이 프로세스가 처리한 변경만)
+}
+
+// API - HTTP(REST + GraphQL)와 gRPC 서버, 주기 작업 스케줄러
+type API struct {
+	*Core
+	HTTP      http.Handler
+	GRPC      *grpc.Server
+	Scheduler *scheduler.Scheduler // Scheduler.Run을 실행해야 작업이 돎
+}
+
+// cleanups - 정리 함수 스택 (역순 실행)
+type cleanups []func()
+
+func (c *cleanups) add(f func()) {
+	if f != nil {
+		*c = append(*c, f)
+	}
+}
+
+func (c cleanups) run() {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i]()
+	}
+}
+
+// provide - 프로바이더를 호출하고 정리 함수를 스택에 쌓음
+func provide[T any](ctx context.Context, cfg Config, p Provider[T], c *cleanups) (T, error) {
+	v, cleanup, err := p(ctx, cfg)
+	if err != nil {
+		return v, err
+	}
+	c.add(cleanup)
+	return v, nil
+}
+
+// NewCore - 저장소와 유스케이스 조립
+// 사용자 저장소는 읽기 캐시와 검색 색인 데코레이터로 감쌈
+func NewCore(ctx context.Context, cfg Config, p Providers) (*Core, func(), error) {
+	var c cleanups
+	core, err := newCore(ctx, cfg, p, &c)
+	if err != nil {
+		c.run()
+		return nil, nil, err
+	}
+	return core, c.run, nil
+}
+
+func newCore(ctx context.Context, cfg Config, p Providers, c *cleanups) (*Core, error) {
+	baseRepo, err := provide(ctx, cfg, p.UserRepository, c)
+	if err != nil {
+		return nil, err
+	}
+	// 읽기 캐시 데코레이터로 감싸서 GetByID 부하를 줄임
+	cachedRepo := cache.NewUserRepository(baseRepo, cache.Config{
+		Size:        10000,
+		TTL:         time.Minute,
+		NegativeTTL: 10 * time.Second,
+	})
+	// 검색 색인 데코레이터 (가장 바깥에 두어야 쓰기를 모두 색인)
+	userRepo := search.NewUserRepository(cachedRepo)
+
+	schemaRepo, err := provide(ctx, cfg, p.SchemaRepository, c)
+	if err != nil {
+		return nil, err
+	}
+	webhookStore, err := provide(ctx, cfg, p.WebhookStore, c)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := provide(ctx, cfg, p.BlobStore, c)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := provide(ctx, cfg, p.Notifier, c)
+	if err != nil {
+		return nil, err
+	}
+	privacyStore, err := provide(ctx, cfg, p.PrivacyStore, c)
+	if err != nil {
+		return nil, err
+	}
+	erasureMode := domain.ErasureMode(cfg.PrivacyErasureMode)
+	if erasureMode != "" && !erasureMode.Valid() {
+		return nil, fmt.Errorf("알 수 없는 PRIVACY_ERASURE_MODE: %s", cfg.PrivacyErasureMode)
+	}
+
+	webhooks := webhook.NewService(webhookStore, webhook.Config{AllowPrivateNetworks: cfg.WebhookAllowPrivateNetworks})
+	events := changefeed.New(changefeed.Config{})
+	c.add(events.Close)
+	schemas := usecase.NewAttributeSchemaUseCase(schemaRepo)
+	opts := []usecase.Option{
+		usecase.WithEventPublisher(webhooks),
+		usecase.WithEventPublisher(events),
+		usecase.WithAttributeSchemas(schemas),
+	}
+	if blobs != nil {
+		opts = append(opts, usecase.WithBlobStore(blobs))
+	}
+	var notifications *notify.Service
+	if notifier != nil {
+		if notifications, err = notify.NewService(notifier, notify.Config{}); err != nil {
+			return nil, err
+		}
+		opts = append(opts, usecase.WithEventPublisher(notifications))
+	}
+
+	users := usecase.NewUserUseCase(userRepo, opts...)
+	privacyService := privacy.NewService(privacyStore, users, blobs, privacy.Config{
+		ArchiveTTL: cfg.PrivacyArchiveTTL,
+		ReceiptKey: []byte(cfg.PrivacyReceiptKey),
+		Retention: privacy.Retention{
+			DefaultMode:      erasureMode,
+			ModerationPeriod: cfg.PrivacyModeration,
+			LegalHold:        cfg.PrivacyLegalHoldKey,
+		},
+	}, privacy.WebhookSource(webhooks))
+
+	return &Core{
+		Users:         users,
+		Schemas:       schemas,
+		Webhooks:      webhooks,
+		Notifications: notifications,
+		Index:         userRepo,
+		Privacy:       privacyService,
+		Events:        events,
+	}, nil
+}
+
+// NewAPI - Core 위에 HTTP 라우터와 gRPC 서버 조립 (두 서버가 같은 유스케이스를 공유)
+func NewAPI(ctx context.Context, cfg Config, p Providers) (*API, func(), error) {
+	var c cleanups
+	api, err := newAPI(ctx, cfg, p, &c)
+	if err != nil {
+		c.run()
+		return nil, nil, err
+	}
+	return api, c.run, nil
+}
+
+func newAPI(ctx context.Context, cfg Config, p Providers, c *cleanups) (*API, error) {
+	core, err := newCore(ctx, cfg, p, c)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyStore, err := provide(ctx, cfg, p.IdempotencyStore, c)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitStore, err := provide(ctx, cfg, p.RateLimitStore, c)
+	if err != nil {
+		return nil, err
+	}
+	leases, err := provide(ctx, cfg, p.LeaseStore, c)
+	if err != nil {
+		return nil, err
+	}
+	history, err := provide(ctx, cfg, p.RunHistory, c)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := newScheduler(cfg, core, idempotencyStore, leases, history)
+	if err != nil {
+		return nil, err
+	}
+
+	routerOpts := []httpDelivery.RouterOption{
+		// 토큰 클레임 → 서브도메인 → X-Tenant-ID 헤더 (TRUST_TENANT_HEADER일 때만) 순으로 테넌트 확인
+		httpDelivery.WithTenantResolver(httpDelivery.NewTenantResolver(httpDelivery.TenantConfig{
+			BaseDomain:  cfg.TenantBaseDomain,
+			Default:     cfg.DefaultTenant,
+			TrustHeader: cfg.TrustTenantHeader,
+		})),
+		// 재시도된 사용자 생성 요청은 첫 응답을 재생
+		httpDelivery.WithIdempotency(httpDelivery.NewIdempotency(idempotencyStore, cfg.IdempotencyTTL)),
+		httpDelivery.WithWebhooks(httpDelivery.NewWebhookHandler(core.Webhooks, cfg.AdminToken)),
+		httpDelivery.WithAttributeSchemas(httpDelivery.NewAttributeSchemaHandler(core.Schemas, cfg.AdminToken)),
+		httpDelivery.WithJobs(httpDelivery.NewJobHandler(jobs, cfg.AdminToken)),
+		httpDelivery.WithUserAdmin(httpDelivery.NewUserAdminHandler(core.Users, cfg.AdminToken)),
+		httpDelivery.WithPrivacy(httpDelivery.NewPrivacyHandler(core.Privacy, cfg.AdminToken)),
+		httpDelivery.WithUserEvents(httpDelivery.NewUserEventsHandler(core.Events, httpDelivery.EventStreamConfig{})),
+	}
+	proxies, err := httpDelivery.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	routerOpts = append(routerOpts, httpDelivery.WithTrustedProxies(proxies))
+	if rateLimitStore != nil {
+		rl, err := newRateLimiter(rateLimitStore)
+		if err != nil {
+			return nil, err
+		}
+		routerOpts = append(routerOpts, httpDelivery.WithRateLimiter(rl))
+	}
+	// api/openapi.yaml 계약으로 사용자 API 요청 검증
+	openAPI, err := httpDelivery.NewOpenAPI(api.Spec())
+	if err != nil {
+		return nil, err
+	}
+	routerOpts = append(routerOpts, httpDelivery.WithOpenAPI(openAPI))
+	router := httpDelivery.NewRouter(httpDelivery.NewUserHandler(core.Users), routerOpts...)
+	router.Post("/graphql", graphqlDelivery.NewHandler(core.Users).ServeHTTP)
+
+	grpcServer := grpcDelivery.NewServer(
+		grpcDelivery.NewUserHandler(core.Users),
+		grpc.UnaryInterceptor(grpcDelivery.TenantUnaryInterceptor(cfg.DefaultTenant, cfg.TrustTenantHeader)),
+		grpc.StreamInterceptor(grpcDelivery.TenantStreamInterceptor(cfg.DefaultTenant, cfg.TrustTenantHeader)),
+	)
+	c.add(grpcServer.Stop)
+
+	return &API{
+		Core:      core,
+		HTTP:      router,
+		GRPC:      grpcServer,
+		Scheduler: jobs,
+	}, nil
+}
+
+// NewCLI - 관리자 CLI용 유스케이스 (데코레이터와 웹훅 전송 없이 저장소를 직접 사용)
+func NewCLI(ctx context.Context, cfg Config, p Providers) (*usecase.UserUseCase, func(), error) {
+	var c cleanups
+	users, err := newCLI(ctx, cfg, p, &c)
+	if err != nil {
+		c.run()
+		return nil, nil, err
+	}
+	return users, c.run, nil
+}
+
+func newCLI(ctx context.Context, cfg Config, p Providers, c *cleanups) (*usecase.UserUseCase, error) {
+	userRepo, err := provide(ctx, cfg, p.UserRepository, c)
+	if err != nil {
+		return nil, err
+	}
+	schemaRepo, err := provide(ctx, cfg, p.SchemaRepository, c)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := provide(ctx, cfg, p.BlobStore, c)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []usecase.Option{usecase.WithAttributeSchemas(usecase.NewAttributeSchemaUseCase(schemaRepo))}
+	if blobs != nil {
+		opts = append(opts, usecase.WithBlobStore(blobs))
+	}
+	return usecase.NewUserUseCase(userRepo, opts...), nil
+}
+
+// newRateLimiter - 라우트별 속도 제한 규칙
+func newRateLimiter(store ratelimit.Store) (*httpDelivery.RateLimiter, error) {
+	defaultLimit := ratelimit.PerMinute(600)
+	return httpDelivery.NewRateLimiter(store, httpDelivery.RateLimitConfig{
+		Rules: []httpDelivery.RateLimitRule{
+			// 사용자 생성은 클라이언트당 분당 10회, 최대 5회 연속
+			{Method: "POST", Pattern: "/api/v1/users", Limit: ratelimit.Limit{Rate: 10, Period: time.Minute, Burst: 5}},
+			{Method: "POST", Pattern: "/api/v1/users:import", Limit: ratelimit.PerMinute(1)},
+			// 일괄 생성/삭제는 한 번에 최대 100명을 바꾸므로 따로 제한 (일괄 조회는 기본값)
+			{Method: "POST", Pattern: "/api/v1/users:batchCreate", Limit: ratelimit.PerMinute(5)},
+			{Method: "POST", Pattern: "/api/v1/users:batchDelete", Limit: ratelimit.PerMinute(5)},
+		},
+		Default: &defaultLimit,
+	})
+}
@@ -0,0 +1,245 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/app"
+	httpDelivery "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/notify"
+	"github.com/milman2/go-api/clean-architecture/internal/privacy"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"golang.org/x/text/language"
+)
+
+func testConfig() app.Config {
+	return app.Config{
+		UserStore:      "memory",
+		RateLimitStore: "off",
+		DefaultTenant:  tenant.Default,
+	}
+}
+
+// tracked - 호출 순서를 기록하는 프로바이더로 감쌈
+func tracked[T any](name string, log *[]string, p app.Provider[T]) app.Provider[T] {
+	return func(ctx context.Context, cfg app.Config) (T, func(), error) {
+		v, cleanup, err := p(ctx, cfg)
+		if err != nil {
+			return v, nil, err
+		}
+		*log = append(*log, "open "+name)
+		return v, func() {
+			if cleanup != nil {
+				cleanup()
+			}
+			*log = append(*log, "close "+name)
+		}, nil
+	}
+}
+
+func trackedProviders(log *[]string) app.Providers {
+	p := app.DefaultProviders()
+	p.UserRepository = tracked("users", log, p.UserRepository)
+	p.SchemaRepository = tracked("schemas", log, p.SchemaRepository)
+	p.WebhookStore = tracked("webhooks", log, p.WebhookStore)
+	p.BlobStore = tracked("blobs", log, p.BlobStore)
+	p.Notifier = tracked("notifier", log, p.Notifier)
+	p.PrivacyStore = tracked("privacy", log, p.PrivacyStore)
+	p.IdempotencyStore = tracked("idempotency", log, p.IdempotencyStore)
+	p.RateLimitStore = tracked("ratelimit", log, p.RateLimitStore)
+	p.LeaseStore = tracked("leases", log, p.LeaseStore)
+	p.RunHistory = tracked("history", log, p.RunHistory)
+	return p
+}
+
+func TestCleanupRunsInReverseOrder(t *testing.T) {
+	var log []string
+	_, cleanup, err := app.NewAPI(context.Background(), testConfig(), trackedProviders(&log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup()
+
+	want := []string{
+		"open users", "open schemas", "open webhooks", "open blobs", "open notifier", "open privacy", "open idempotency", "open ratelimit", "open leases", "open history",
+		"close history", "close leases", "close ratelimit", "close idempotency", "close privacy", "close notifier", "close blobs", "close webhooks", "close schemas", "close users",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("got %v\nwant %v", log, want)
+	}
+}
+
+func TestFailedProviderCleansUpEarlierOnes(t *testing.T) {
+	var log []string
+	p := trackedProviders(&log)
+	boom := errors.New("boom")
+	p.BlobStore = func(context.Context, app.Config) (usecase.BlobStore, func(), error) {
+		return nil, nil, boom
+	}
+
+	_, cleanup, err := app.NewAPI(context.Background(), testConfig(), p)
+	if !errors.Is(err, boom) {
+		t.Fatalf("got %v, want %v", err, boom)
+	}
+	if cleanup != nil {
+		t.Fatal("cleanup must be nil on error")
+	}
+	want := []string{"open users", "open schemas", "open webhooks", "close webhooks", "close schemas", "close users"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("got %v\nwant %v", log, want)
+	}
+}
+
+func TestUnknownStoreFails(t *testing.T) {
+	cfg := testConfig()
+	cfg.UserStore = "postgres"
+	if _, _, err := app.NewCore(context.Background(), cfg, app.DefaultProviders()); err == nil {
+		t.Fatal("expected error for unknown user store")
+	}
+
+	cfg = testConfig()
+	cfg.RateLimitStore = "redis"
+	if _, _, err := app.NewAPI(context.Background(), cfg, app.DefaultProviders()); err == nil {
+		t.Fatal("expected error for unknown rate limit store")
+	}
+}
+
+// 프로바이더 하나만 바꿔 끼워도 HTTP/gRPC/CLI가 모두 같은 저장소를 씀
+func TestSwapUserRepository(t *testing.T) {
+	repo := memory.NewUserRepository()
+	p := app.DefaultProviders()
+	p.UserRepository = func(context.Context, app.Config) (usecase.UserRepository, func(), error) {
+		return repo, nil, nil
+	}
+
+	api, cleanup, err := app.NewAPI(context.Background(), testConfig(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{"email":"kim@example.com","name":"Kim"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	api.HTTP.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("got %d: %s", rec.Code, rec.Body)
+	}
+	var created httpDelivery.UserResponse
+	json.NewDecoder(rec.Body).Decode(&created)
+
+	users, cleanupCLI, err := app.NewCLI(context.Background(), testConfig(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanupCLI()
+
+	got, err := users.GetUser(tenant.WithID(context.Background(), tenant.Default), created.ID)
+	if err != nil {
+		t.Fatalf("CLI graph does not see HTTP write: %v", err)
+	}
+	if got.Email != "kim@example.com" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+// 사용자를 만들면 요청 언어로 환영 메일이 대기열에 들어감
+func TestWelcomeMail(t *testing.T) {
+	capture, err := notify.NewCaptureNotifier("no-reply@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := app.DefaultProviders()
+	p.Notifier = func(context.Context, app.Config) (notify.Notifier, func(), error) {
+		return capture, nil, nil
+	}
+
+	api, cleanup, err := app.NewAPI(context.Background(), testConfig(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{"email":"kim@example.com","name":"Kim"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "ko-KR")
+	rec := httptest.NewRecorder()
+	api.HTTP.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 응답 전에는 보내지 않음 (Run 워커가 전송)
+	if len(capture.Messages()) != 0 || api.Notifications.Pending() != 1 {
+		t.Fatalf("mail sent synchronously")
+	}
+	api.Notifications.ProcessDue(context.Background())
+
+	msgs := capture.Messages()
+	if len(msgs) != 1 || msgs[0].Kind != notify.KindWelcome || msgs[0].To != "kim@example.com" || msgs[0].Language != language.Korean {
+		t.Fatalf("got %+v", msgs)
+	}
+}
+
+// heldLeases - 모든 작업의 리스를 다른 인스턴스가 가지고 있는 리스 저장소
+type heldLeases struct{}
+
+func (heldLeases) Acquire(context.Context, string, string, time.Time, time.Duration) (bool, error) {
+	return false, nil
+}
+
+func (heldLeases) Release(context.Context, string, string) error { return nil }
+
+// 내보내기 파일은 만든 인스턴스에만 있으므로, 공유 리스를 다른 인스턴스가 가지고 있어도 각자 자기 파일을 지움
+func TestPurgePrivacyExportsOnEveryInstance(t *testing.T) {
+	cfg := testConfig()
+	cfg.AvatarDir = t.TempDir()
+	cfg.PrivacyArchiveTTL = time.Millisecond
+	p := app.DefaultProviders()
+	p.LeaseStore = func(context.Context, app.Config) (scheduler.LeaseStore, func(), error) {
+		return heldLeases{}, nil, nil
+	}
+
+	api, cleanup, err := app.NewAPI(context.Background(), cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	user, err := api.Users.CreateUser(ctx, "kim@example.com", "Kim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := api.Privacy.RequestExport(ctx, user.ID, privacy.FormatJSON, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.Privacy.ProcessPending(ctx)
+	time.Sleep(5 * time.Millisecond)
+
+	run, err := api.Scheduler.RunNow(context.Background(), "purge-privacy-exports")
+	if err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	if run.Status != scheduler.RunSucceeded {
+		t.Fatalf("run: %+v", run)
+	}
+	got, err := api.Privacy.Request(ctx, req.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != privacy.StatusExpired {
+		t.Fatalf("status = %s, want %s", got.Status, privacy.StatusExpired)
+	}
+}
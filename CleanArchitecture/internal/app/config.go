@@ -0,0 +1,138 @@
+package app
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// Config - 조립에 필요한 설정 (환경 변수에서 읽거나 테스트에서 직접 채움)
+type Config struct {
+	HTTPAddr string // HTTP 서버 주소
+	GRPCAddr string // gRPC 서버 주소
+
+	ShutdownTimeout time.Duration // 종료 신호를 받은 뒤 처리 중인 요청을 기다리는 시간
+
+	UserStore     string // 사용자 저장소: memory | file | spanner (spanner면 속성 스키마도 Spanner에 둠)
+	UserDataFile  string // file 저장소 JSON 파일 경로
+	MemoryDataDir string // memory 저장소 WAL/스냅샷 디렉터리 (빈 문자열이면 영속하지 않음)
+	MemoryFsync   string // memory 저장소 fsync 정책: always | interval | never
+	KeyringFile   string // 사용자 PII 필드 암호화 키링 파일 (빈 문자열이면 평문 저장, 없으면 새로 만듦)
+
+	TrustedProxies string // X-Forwarded-For / X-Real-IP를 믿을 프록시 (쉼표로 구분한 CIDR/IP, 빈 문자열이면 헤더 무시)
+
+	AvatarDir       string // 아바타 저장 디렉터리 (빈 문자열이면 아바타 API는 501)
+	RateLimitStore  string // 속도 제한 저장소: memory | spanner | off
+	SpannerDatabase string // projects/<p>/instances/<i>/databases/<d>
+
+	DefaultTenant     string        // 테넌트를 지정하지 않은 요청의 테넌트 (빈 문자열이면 모든 요청에 테넌트 요구)
+	TenantBaseDomain  string        // 서브도메인 테넌트 확인용 도메인
+	TrustTenantHeader bool          // X-Tenant-ID 헤더 / x-tenant-id 메타데이터로 테넌트 선택 허용 (앞단 게이트웨이가 덮어쓰는 신뢰 네트워크에서만)
+	AdminToken        string        // 관리자 API 토큰: 웹훅/속성 스키마/작업/계정 상태/개인정보 요청 (빈 문자열이면 모두 401)
+	IdempotencyTTL    time.Duration // Idempotency-Key 응답 보관 기간
+
+	WebhookAllowPrivateNetworks bool // 루프백/사설 주소로도 웹훅 전송 허용 (로컬 개발용)
+
+	SchedulerLeaseStore string // 주기 작업 리스 저장소: memory | spanner (여러 인스턴스면 spanner)
+	InstanceID          string // 리스 소유자로 기록할 인스턴스 식별자 (빈 문자열이면 호스트 이름 + PID)
+
+	Notifier        string // 알림 메일: capture | smtp | off
+	MailFrom        string // 보내는 주소 (예: "Clean Architecture <no-reply@example.com>")
+	MailCaptureDir  string // capture일 때 .eml 파일을 쓸 디렉터리 (빈 문자열이면 메모리에만 보관)
+	SMTPAddr        string // smtp일 때 서버 주소 (host:port)
+	SMTPUsername    string // 비어 있으면 인증 안 함
+	SMTPPassword    string
+	SMTPImplicitTLS bool // 465 포트처럼 연결부터 TLS (기본은 STARTTLS)
+
+	PrivacyArchiveTTL   time.Duration // 개인정보 내보내기 파일 보관 기간 (파일은 아바타 저장소에 씀, AvatarDir가 없으면 내보내기 501)
+	PrivacyReceiptKey   string        // 삭제 완료 기록 서명 키 (빈 문자열이면 다이제스트만 남김)
+	PrivacyErasureMode  string        // 방식을 지정하지 않은 삭제 요청의 방식: delete | anonymize
+	PrivacyModeration   time.Duration // 이 기간 안에 정지/비활성화된 사용자는 delete 요청도 익명화 (0이면 규칙 없음)
+	PrivacyLegalHoldKey string        // 이 속성("네임스페이스.필드")이 true인 사용자는 익명화 (빈 문자열이면 규칙 없음)
+}
+
+// LoadConfig - 환경 변수로 Config 구성
+func LoadConfig() Config {
+	defaultTenant := tenant.Default
+	if getEnv("TENANT_REQUIRED", "false") == "true" {
+		defaultTenant = ""
+	}
+
+	return Config{
+		HTTPAddr: getEnv("HTTP_ADDR", ":8080"),
+		GRPCAddr: getEnv("GRPC_ADDR", ":9090"),
+
+		ShutdownTimeout: getDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		UserStore:     getEnv("USER_STORE", "memory"),
+		UserDataFile:  getEnv("USER_DATA_FILE", "users.json"),
+		MemoryDataDir: getEnv("MEMORY_DATA_DIR", ""),
+		MemoryFsync:   getEnv("MEMORY_FSYNC", "always"),
+		KeyringFile:   getEnv("KEYRING_FILE", ""),
+
+		TrustedProxies: getEnv("TRUSTED_PROXIES", ""),
+
+		AvatarDir:       getEnv("AVATAR_DIR", ""),
+		RateLimitStore:  getEnv("RATE_LIMIT_STORE", "memory"),
+		SpannerDatabase: spannerDatabase(),
+
+		DefaultTenant:     defaultTenant,
+		TenantBaseDomain:  getEnv("TENANT_BASE_DOMAIN", ""),
+		TrustTenantHeader: getEnv("TRUST_TENANT_HEADER", "false") == "true",
+		AdminToken:        getEnv("ADMIN_TOKEN", ""),
+		IdempotencyTTL:    24 * time.Hour,
+
+		WebhookAllowPrivateNetworks: getEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+
+		SchedulerLeaseStore: getEnv("SCHEDULER_LEASE_STORE", "memory"),
+		InstanceID:          getEnv("INSTANCE_ID", ""),
+
+		Notifier:        getEnv("NOTIFIER", "capture"),
+		MailFrom:        getEnv("MAIL_FROM", defaultMailFrom),
+		MailCaptureDir:  getEnv("MAIL_CAPTURE_DIR", ""),
+		SMTPAddr:        getEnv("SMTP_ADDR", "localhost:587"),
+		SMTPUsername:    getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+		SMTPImplicitTLS: getEnv("SMTP_IMPLICIT_TLS", "false") == "true",
+
+		PrivacyArchiveTTL:   getDuration("PRIVACY_ARCHIVE_TTL", 7*24*time.Hour),
+		PrivacyReceiptKey:   getEnv("PRIVACY_RECEIPT_KEY", ""),
+		PrivacyErasureMode:  getEnv("PRIVACY_ERASURE_MODE", "delete"),
+		PrivacyModeration:   getDuration("PRIVACY_MODERATION_RETENTION", 365*24*time.Hour),
+		PrivacyLegalHoldKey: getEnv("PRIVACY_LEGAL_HOLD", "privacy.legal_hold"),
+	}
+}
+
+// defaultMailFrom - MAIL_FROM을 지정하지 않았을 때 보내는 주소
+const defaultMailFrom = "Clean Architecture <no-reply@localhost>"
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getDuration - time.ParseDuration 형식의 환경 변수 (형식이 틀리면 경고하고 기본값)
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("%s 값 %q를 해석할 수 없어 기본값 %s 사용: %v", key, value, defaultValue, err)
+		return defaultValue
+	}
+	return d
+}
+
+// spannerDatabase - 환경 변수로 Spanner 데이터베이스 경로 구성
+func spannerDatabase() string {
+	projectID := getEnv("SPANNER_PROJECT_ID", "test-project")
+	instanceID := getEnv("SPANNER_INSTANCE_ID", "test-instance")
+	databaseID := getEnv("SPANNER_DATABASE_ID", "test-db")
+	return "projects/" + projectID + "/instances/" + instanceID + "/databases/" + databaseID
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/idempotency"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+)
+
+// newScheduler - 주기 유지보수 작업 등록
+func newScheduler(cfg Config, core *Core, idempotencyStore idempotency.Store, leases scheduler.LeaseStore, history scheduler.HistoryStore) (*scheduler.Scheduler, error) {
+	s := scheduler.New(leases, history, scheduler.Config{Holder: cfg.InstanceID})
+
+	jobs := []scheduler.Job{
+		{
+			// 쓰기 경로 밖에서 원본이 바뀌었을 때를 대비해 매일 새벽 검색 색인을 다시 만듦
+			// 색인은 인스턴스 메모리에 있으므로 인스턴스마다 실행
+			Name:        "rebuild-search-index",
+			Schedule:    "30 3 * * *",
+			Timeout:     10 * time.Minute,
+			PerInstance: true,
+			Run: func(ctx context.Context) error {
+				n, err := core.Index.Rebuild(ctx)
+				if err == nil {
+					log.Printf("검색 색인 재생성: 테넌트 %d개", n)
+				}
+				return err
+			},
+		},
+		{
+			// 보관 기간이 지난 개인정보 내보내기 파일 삭제
+			// 요청 기록과 파일이 인스턴스마다 따로 있으므로 (메모리 요청 저장소, 로컬 아바타 디렉터리) 인스턴스마다 실행
+			Name:        "purge-privacy-exports",
+			Schedule:    "15 * * * *",
+			Timeout:     5 * time.Minute,
+			PerInstance: true,
+			Run: func(ctx context.Context) error {
+				n, err := core.Privacy.PurgeExpired(ctx)
+				if n > 0 {
+					log.Printf("만료된 개인정보 내보내기 파일 삭제: %d개", n)
+				}
+				return err
+			},
+		},
+	}
+	if purger, ok := idempotencyStore.(idempotency.Purger); ok {
+		jobs = append(jobs, scheduler.Job{
+			// 요청이 없어도 만료된 Idempotency-Key가 메모리에 남지 않게 함 (인스턴스마다 따로인 저장소)
+			Name:        "purge-idempotency-keys",
+			Schedule:    "*/10 * * * *",
+			Timeout:     time.Minute,
+			PerInstance: true,
+			Run: func(ctx context.Context) error {
+				_, err := purger.PurgeExpired(ctx)
+				return err
+			},
+		})
+	}
+
+	for _, job := range jobs {
+		if err := s.Register(job); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/idempotency"
+	"github.com/milman2/go-api/clean-architecture/internal/notify"
+	"github.com/milman2/go-api/clean-architecture/internal/privacy"
+	"github.com/milman2/go-api/clean-architecture/internal/ratelimit"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	spannerRepo "github.com/milman2/go-api/clean-architecture/internal/repository/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/milman2/go-api/clean-architecture/internal/webhook"
+)
+
+// Provider - 설정으로 의존성 하나를 만드는 함수
+// cleanup은 nil이어도 되며, 주입기가 만든 순서의 역순으로 호출
+type Provider[T any] func(ctx context.Context, cfg Config) (T, func(), error)
+
+// Providers - 외부 자원(저장소)을 만드는 프로바이더 모음
+// 유스케이스/핸들러 조립은 주입기(NewCore, NewAPI, NewCLI)가 맡고, 여기에는 교체할 수 있는 말단만 둠.
+// 테스트는 DefaultProviders()에서 필요한 필드 하나만 바꿔 끼우면 됨
+type Providers struct {
+	UserRepository   Provider[usecase.UserRepository]
+	SchemaRepository Provider[usecase.AttributeSchemaRepository]
+	BlobStore        Provider[usecase.BlobStore] // nil 값을 돌려주면 아바타 기능 없음
+	WebhookStore     Provider[webhook.Store]
+	IdempotencyStore Provider[idempotency.Store]
+	RateLimitStore   Provider[ratelimit.Store] // nil 값을 돌려주면 속도 제한 없음
+	LeaseStore       Provider[scheduler.LeaseStore]
+	RunHistory       Provider[scheduler.HistoryStore]
+	Notifier         Provider[notify.Notifier] // nil 값을 돌려주면 알림 메일 없음
+	PrivacyStore     Provider[privacy.Store]
+}
+
+// DefaultProviders - 운영용 프로바이더
+func DefaultProviders() Providers {
+	return Providers{
+		UserRepository:   ProvideUserRepository,
+		SchemaRepository: ProvideSchemaRepository,
+		BlobStore:        ProvideBlobStore,
+		WebhookStore:     ProvideWebhookStore,
+		IdempotencyStore: ProvideIdempotencyStore,
+		RateLimitStore:   ProvideRateLimitStore,
+		LeaseStore:       ProvideLeaseStore,
+		RunHistory:       ProvideRunHistory,
+		Notifier:         ProvideNotifier,
+		PrivacyStore:     ProvidePrivacyStore,
+	}
+}
+
+// ProvideUserRepository - UserStore(memory | file | spanner)에 따라 사용자 저장소 생성
+// memory는 MemoryDataDir가 있으면 WAL + 스냅샷 영속 모드 (MemoryFsync로 내구성과 처리량 조절)
+// spanner는 SpannerDatabase의 users 테이블 사용 (schema.sql)
+// KeyringFile이 있으면 디스크에 쓰는 이메일과 이름을 암호화 (메모리 안의 값은 평문)
+func ProvideUserRepository(ctx context.Context, cfg Config) (usecase.UserRepository, func(), error) {
+	cipher, err := openCipher(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch cfg.UserStore {
+	case "", "memory":
+	case "file":
+		repo, err := file.NewUserRepository(cfg.UserDataFile, file.WithCipher(cipher))
+		if err != nil {
+			return nil, nil, fmt.Errorf("파일 저장소 열기 실패: %w", err)
+		}
+		return repo, nil, nil
+	case "spanner":
+		client, err := spanner.NewClient(ctx, cfg.SpannerDatabase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Spanner 클라이언트 생성 실패: %w", err)
+		}
+		return spannerRepo.NewUserRepository(client, spannerRepo.WithCipher(cipher)), client.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 USER_STORE: %s", cfg.UserStore)
+	}
+
+	if cfg.MemoryDataDir == "" {
+		return memory.NewUserRepository(), nil, nil
+	}
+
+	var policy memory.SyncPolicy
+	switch cfg.MemoryFsync {
+	case "", "always":
+		policy = memory.SyncAlways
+	case "interval":
+		policy = memory.SyncInterval
+	case "never":
+		policy = memory.SyncNever
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 MEMORY_FSYNC: %s", cfg.MemoryFsync)
+	}
+
+	repo, err := memory.OpenUserRepository(memory.PersistConfig{
+		Dir:       cfg.MemoryDataDir,
+		Sync:      policy,
+		SyncEvery: time.Second,
+		Cipher:    cipher,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("메모리 저장소 복구 실패: %w", err)
+	}
+	return repo, func() { repo.Close() }, nil
+}
+
+// openCipher - KeyringFile의 키링으로 필드 암호화기 생성 (KeyringFile이 없으면 nil)
+func openCipher(cfg Config) (*fieldcrypt.Cipher, error) {
+	if cfg.KeyringFile == "" {
+		return nil, nil
+	}
+	keys, err := fieldcrypt.OpenFileKeyring(cfg.KeyringFile)
+	if err != nil {
+		return nil, fmt.Errorf("키링 열기 실패: %w", err)
+	}
+	return fieldcrypt.New(keys), nil
+}
+
+// ProvideSchemaRepository - 속성 스키마 저장소 (UserStore가 spanner면 여러 인스턴스가 공유하도록 Spanner, 아니면 메모리)
+func ProvideSchemaRepository(ctx context.Context, cfg Config) (usecase.AttributeSchemaRepository, func(), error) {
+	if cfg.UserStore != "spanner" {
+		return memory.NewAttributeSchemaRepository(), nil, nil
+	}
+	client, err := spanner.NewClient(ctx, cfg.SpannerDatabase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Spanner 클라이언트 생성 실패: %w", err)
+	}
+	return spannerRepo.NewAttributeSchemaRepository(client), client.Close, nil
+}
+
+// ProvideBlobStore - AvatarDir가 있으면 아바타를 그 디렉터리에 저장 (없으면 nil)
+func ProvideBlobStore(_ context.Context, cfg Config) (usecase.BlobStore, func(), error) {
+	if cfg.AvatarDir == "" {
+		return nil, nil, nil
+	}
+	store, err := file.NewBlobStore(cfg.AvatarDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("아바타 저장소 생성 실패: %w", err)
+	}
+	return store, nil, nil
+}
+
+// ProvideWebhookStore - 웹훅 구독/전송 기록 저장소
+func ProvideWebhookStore(context.Context, Config) (webhook.Store, func(), error) {
+	return webhook.NewMemoryStore(), nil, nil
+}
+
+// ProvideIdempotencyStore - Idempotency-Key 응답 저장소
+func ProvideIdempotencyStore(context.Context, Config) (idempotency.Store, func(), error) {
+	return idempotency.NewMemoryStore(), nil, nil
+}
+
+// ProvideRateLimitStore - RateLimitStore(memory | spanner | off)에 따라 속도 제한 저장소 생성 (off면 nil)
+func ProvideRateLimitStore(ctx context.Context, cfg Config) (ratelimit.Store, func(), error) {
+	switch cfg.RateLimitStore {
+	case "off":
+		return nil, nil, nil
+	case "", "memory":
+		return ratelimit.NewMemoryStore(), nil, nil
+	case "spanner":
+		client, err := spanner.NewClient(ctx, cfg.SpannerDatabase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Spanner 클라이언트 생성 실패: %w", err)
+		}
+		return spannerRepo.NewRateLimitStore(client), client.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 RATE_LIMIT_STORE: %s", cfg.RateLimitStore)
+	}
+}
+
+// ProvideLeaseStore - SchedulerLeaseStore(memory | spanner)에 따라 주기 작업 리스 저장소 생성
+func ProvideLeaseStore(ctx context.Context, cfg Config) (scheduler.LeaseStore, func(), error) {
+	switch cfg.SchedulerLeaseStore {
+	case "", "memory":
+		return scheduler.NewMemoryLeaseStore(), nil, nil
+	case "spanner":
+		client, err := spanner.NewClient(ctx, cfg.SpannerDatabase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Spanner 클라이언트 생성 실패: %w", err)
+		}
+		return spannerRepo.NewLeaseStore(client), client.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 SCHEDULER_LEASE_STORE: %s", cfg.SchedulerLeaseStore)
+	}
+}
+
+// ProvideRunHistory - 주기 작업 실행 기록 (인스턴스별 메모리, 작업마다 최근 100건)
+func ProvideRunHistory(context.Context, Config) (scheduler.HistoryStore, func(), error) {
+	return scheduler.NewMemoryHistory(100), nil, nil
+}
+
+// ProvideNotifier - Notifier(capture | smtp | off)에 따라 알림 메일 전송기 생성
+// capture는 보내지 않고 보관만 함 (MailCaptureDir가 있으면 .eml 파일로도 저장)
+func ProvideNotifier(_ context.Context, cfg Config) (notify.Notifier, func(), error) {
+	from := cfg.MailFrom
+	if from == "" {
+		from = defaultMailFrom
+	}
+
+	switch cfg.Notifier {
+	case "", "capture":
+		n, err := notify.NewCaptureNotifier(from, cfg.MailCaptureDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		return n, nil, nil
+	case "smtp":
+		n, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+			Addr:        cfg.SMTPAddr,
+			Username:    cfg.SMTPUsername,
+			Password:    cfg.SMTPPassword,
+			From:        from,
+			ImplicitTLS: cfg.SMTPImplicitTLS,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return n, nil, nil
+	case "off":
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 NOTIFIER: %s", cfg.Notifier)
+	}
+}
+
+// ProvidePrivacyStore - 개인정보 내보내기/삭제 요청 기록 저장소
+func ProvidePrivacyStore(context.Context, Config) (privacy.Store, func(), error) {
+	return privacy.NewMemoryStore(), nil, nil
+}
@@ -0,0 +1,235 @@
+// Package changefeed - 사용자 변경 이벤트 실시간 피드 (SSE 등 스트리밍 전달용)
+//
+// Feed는 발행된 이벤트에 증가하는 순번을 붙여 크기가 제한된 메모리 로그에 남기고,
+// 구독자마다 버퍼가 있는 채널로 나눠 준다. 구독자가 버퍼를 다 채울 만큼 느리면
+// 발행자를 막지 않고 그 구독을 끊는다 (ErrSlowConsumer). 끊긴 클라이언트는 마지막으로 받은
+// 이벤트 ID로 다시 구독해 로그에 남은 이벤트부터 이어 받는다.
+//
+// 로그는 프로세스 메모리에만 있으므로, 재시작하거나 로그에서 밀려난 ID로 이어 받으려 하면
+// Subscription.Reset으로 알려 클라이언트가 목록을 다시 읽게 한다. 여러 인스턴스를 띄우면
+// 각 인스턴스는 자기가 처리한 변경만 보낸다.
+package changefeed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+var (
+	// ErrSlowConsumer - 구독자가 이벤트를 제때 가져가지 않아 버퍼가 넘침
+	ErrSlowConsumer = errors.New("changefeed: subscriber too slow")
+	// ErrClosed - 피드가 닫힘
+	ErrClosed = errors.New("changefeed: closed")
+)
+
+// Config - 피드 설정 (0 값은 기본값 사용)
+type Config struct {
+	LogSize int // 이어 받기용으로 보관하는 최근 이벤트 수 (기본 1024, 모든 테넌트 합계)
+	Buffer  int // 구독자별 채널 버퍼 (기본 64, 넘치면 구독을 끊음)
+}
+
+// Event - 순번이 붙은 사용자 변경 이벤트
+type Event struct {
+	ID string // "<피드 세대>-<순번>" (SSE id, Last-Event-ID로 이어 받을 때 사용)
+	domain.UserEvent
+
+	seq uint64
+}
+
+// Feed - 사용자 변경 이벤트 피드 (usecase.EventPublisher 구현, 여러 고루틴에서 사용 가능)
+type Feed struct {
+	cfg   Config
+	epoch string // 프로세스마다 다른 세대 (이전 프로세스의 ID로 이어 받으려 하면 Reset)
+
+	mu     sync.Mutex
+	seq    uint64
+	log    []Event // 순환 버퍼
+	next   int     // 다음에 쓸 log 위치
+	size   int     // log에 든 이벤트 수
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// New - Feed 생성자
+func New(cfg Config) *Feed {
+	if cfg.LogSize <= 0 {
+		cfg.LogSize = 1024
+	}
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 64
+	}
+
+	b := make([]byte, 4)
+	rand.Read(b)
+	return &Feed{
+		cfg:   cfg,
+		epoch: hex.EncodeToString(b),
+		log:   make([]Event, cfg.LogSize),
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish - 이벤트를 로그에 남기고 같은 테넌트 구독자에게 전달 (usecase.EventPublisher 구현)
+// 버퍼가 가득 찬 구독은 기다리지 않고 끊음
+// 개인정보 삭제 이벤트면 로그에 남은 그 사용자의 이전 스냅샷도 ID만 남기고 지움
+func (f *Feed) Publish(_ context.Context, event domain.UserEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return
+	}
+	if event.Type == domain.UserErased {
+		f.redact(event.TenantID, event.User.ID)
+	}
+	f.seq++
+	e := Event{ID: f.epoch + "-" + strconv.FormatUint(f.seq, 10), UserEvent: event, seq: f.seq}
+	f.log[f.next] = e
+	f.next = (f.next + 1) % len(f.log)
+	if f.size < len(f.log) {
+		f.size++
+	}
+
+	for sub := range f.subs {
+		if sub.tenantID != event.TenantID {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			f.drop(sub, ErrSlowConsumer)
+		}
+	}
+}
+
+// Subscribe - 테넌트의 이벤트 구독
+// lastEventID가 있으면 로그에서 그 뒤 이벤트를 Backlog로 먼저 돌려줌
+// 로그에서 이미 밀려났거나 이 프로세스가 발급하지 않은 ID면 Reset을 켬 (그 사이 이벤트를 놓쳤을 수 있음)
+// 다 쓴 구독은 Close해야 함
+func (f *Feed) Subscribe(tenantID, lastEventID string) (*Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil, ErrClosed
+	}
+	sub := &Subscription{
+		feed:     f,
+		tenantID: tenantID,
+		ch:       make(chan Event, f.cfg.Buffer),
+	}
+	if lastEventID != "" {
+		sub.Backlog, sub.Reset = f.since(tenantID, lastEventID)
+	}
+	f.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// since - lastEventID 뒤의 테넌트 이벤트 (호출자가 잠금 보유)
+// reset이면 그 사이 이벤트가 로그에 다 남아 있지 않아 이어 받을 수 없음
+func (f *Feed) since(tenantID, lastEventID string) (events []Event, reset bool) {
+	seq, ok := f.parseID(lastEventID)
+	if !ok || seq > f.seq {
+		return nil, true
+	}
+	oldest := f.seq - uint64(f.size) // 로그에 남은 가장 오래된 이벤트 바로 앞 순번
+	if seq < oldest {
+		return nil, true
+	}
+
+	start := (f.next - f.size + len(f.log)) % len(f.log)
+	for i := 0; i < f.size; i++ {
+		e := f.log[(start+i)%len(f.log)]
+		if e.seq > seq && e.TenantID == tenantID {
+			events = append(events, e)
+		}
+	}
+	return events, false
+}
+
+// redact - 로그에서 사용자의 스냅샷을 ID만 남기고 지움 (호출자가 잠금 보유)
+func (f *Feed) redact(tenantID, userID string) {
+	for i := range f.log {
+		if e := &f.log[i]; e.TenantID == tenantID && e.User.ID == userID {
+			e.User = domain.User{ID: userID, TenantID: tenantID}
+		}
+	}
+}
+
+// parseID - "<세대>-<순번>" 해석 (다른 세대면 ok=false)
+func (f *Feed) parseID(id string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != f.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Close - 모든 구독을 ErrClosed로 끝냄 (서버 종료 전에 호출하면 스트림이 바로 끝남)
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for sub := range f.subs {
+		f.drop(sub, ErrClosed)
+	}
+}
+
+// Subscribers - 현재 구독 수
+func (f *Feed) Subscribers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs)
+}
+
+// drop - 구독을 끊고 채널을 닫음 (호출자가 잠금 보유)
+func (f *Feed) drop(sub *Subscription, err error) {
+	if _, ok := f.subs[sub]; !ok {
+		return
+	}
+	delete(f.subs, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+// Subscription - 한 클라이언트의 구독
+type Subscription struct {
+	Backlog []Event // 이어 받기: 구독 전에 발행된 이벤트 (Events보다 먼저 보내야 함)
+	Reset   bool    // 이어 받을 수 없음: 클라이언트가 상태를 다시 읽어야 함
+
+	feed     *Feed
+	tenantID string
+	ch       chan Event
+	err      error // ch를 닫은 이유 (feed.mu로 보호, ch가 닫힌 뒤에만 읽음)
+}
+
+// Events - 새 이벤트 채널 (구독이 끊기면 닫히고 Err로 이유 확인)
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Err - 채널이 닫힌 이유 (ErrSlowConsumer, ErrClosed, Close했으면 nil)
+func (s *Subscription) Err() error {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.err
+}
+
+// Close - 구독 해제
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.drop(s, nil)
+}
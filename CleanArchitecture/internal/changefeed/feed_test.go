@@ -0,0 +1,136 @@
+package changefeed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/changefeed"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+func publish(f *changefeed.Feed, tenantID, userID string, eventType domain.UserEventType) {
+	f.Publish(context.Background(), domain.UserEvent{
+		Type:     eventType,
+		TenantID: tenantID,
+		User:     domain.User{ID: userID, TenantID: tenantID, Email: userID + "@example.com"},
+	})
+}
+
+func receive(t *testing.T, sub *changefeed.Subscription) changefeed.Event {
+	t.Helper()
+	select {
+	case e, ok := <-sub.Events():
+		if !ok {
+			t.Fatalf("subscription closed: %v", sub.Err())
+		}
+		return e
+	default:
+		t.Fatal("no event delivered")
+		return changefeed.Event{}
+	}
+}
+
+func TestFeedDeliversTenantEvents(t *testing.T) {
+	f := changefeed.New(changefeed.Config{})
+	acme, err := f.Subscribe("acme", "")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer acme.Close()
+
+	publish(f, "globex", "g1", domain.UserCreated)
+	publish(f, "acme", "a1", domain.UserCreated)
+
+	if e := receive(t, acme); e.User.ID != "a1" || e.Type != domain.UserCreated || e.ID == "" {
+		t.Fatalf("event = %+v", e)
+	}
+	if len(acme.Events()) != 0 {
+		t.Fatal("event from another tenant delivered")
+	}
+
+	acme.Close()
+	if _, ok := <-acme.Events(); ok || acme.Err() != nil || f.Subscribers() != 0 {
+		t.Fatalf("after Close: err %v, subscribers %d", acme.Err(), f.Subscribers())
+	}
+}
+
+func TestFeedResume(t *testing.T) {
+	f := changefeed.New(changefeed.Config{LogSize: 3})
+	first, _ := f.Subscribe("acme", "")
+	publish(f, "acme", "a1", domain.UserCreated)
+	last := receive(t, first)
+	first.Close()
+
+	publish(f, "acme", "a1", domain.UserUpdated)
+	publish(f, "globex", "g1", domain.UserCreated)
+
+	resumed, _ := f.Subscribe("acme", last.ID)
+	defer resumed.Close()
+	if resumed.Reset || len(resumed.Backlog) != 1 || resumed.Backlog[0].Type != domain.UserUpdated {
+		t.Fatalf("resume: reset %v, backlog %+v", resumed.Reset, resumed.Backlog)
+	}
+
+	// 로그(3개)에서 밀려난 ID나 다른 프로세스의 ID로는 이어 받을 수 없음
+	publish(f, "acme", "a2", domain.UserCreated)
+	publish(f, "acme", "a3", domain.UserCreated)
+	for _, id := range []string{last.ID, "deadbeef-1", "garbage"} {
+		sub, _ := f.Subscribe("acme", id)
+		if !sub.Reset || len(sub.Backlog) != 0 {
+			t.Errorf("Subscribe(%q): reset %v, backlog %d", id, sub.Reset, len(sub.Backlog))
+		}
+		sub.Close()
+	}
+}
+
+func TestFeedDropsSlowConsumer(t *testing.T) {
+	f := changefeed.New(changefeed.Config{Buffer: 2})
+	slow, _ := f.Subscribe("acme", "")
+	fast, _ := f.Subscribe("acme", "")
+	defer fast.Close()
+
+	for i, id := range []string{"a1", "a2", "a3"} {
+		publish(f, "acme", id, domain.UserCreated)
+		if e := receive(t, fast); e.User.ID != id {
+			t.Fatalf("fast event %d = %+v", i, e)
+		}
+	}
+
+	// 버퍼에 남은 이벤트를 다 읽으면 채널이 닫혀 있음
+	for range slow.Events() {
+	}
+	if !errors.Is(slow.Err(), changefeed.ErrSlowConsumer) || f.Subscribers() != 1 {
+		t.Fatalf("slow subscriber: err %v, subscribers %d", slow.Err(), f.Subscribers())
+	}
+
+	f.Close()
+	if _, ok := <-fast.Events(); ok || !errors.Is(fast.Err(), changefeed.ErrClosed) {
+		t.Fatalf("after feed Close: err %v", fast.Err())
+	}
+	if _, err := f.Subscribe("acme", ""); !errors.Is(err, changefeed.ErrClosed) {
+		t.Fatalf("Subscribe after Close: got %v, want ErrClosed", err)
+	}
+}
+
+// 개인정보 삭제 이벤트 뒤에는 로그에 남은 이전 스냅샷도 ID만 남음
+func TestFeedRedactsErasedUser(t *testing.T) {
+	f := changefeed.New(changefeed.Config{})
+	sub, _ := f.Subscribe("acme", "")
+	publish(f, "acme", "a1", domain.UserCreated)
+	start := receive(t, sub)
+	sub.Close()
+
+	publish(f, "acme", "a1", domain.UserUpdated)
+	f.Publish(context.Background(), domain.UserEvent{Type: domain.UserErased, TenantID: "acme", User: domain.User{ID: "a1"}})
+
+	resumed, _ := f.Subscribe("acme", start.ID)
+	defer resumed.Close()
+	if len(resumed.Backlog) != 2 {
+		t.Fatalf("backlog = %+v", resumed.Backlog)
+	}
+	for _, e := range resumed.Backlog {
+		if e.User.ID != "a1" || e.User.Email != "" {
+			t.Errorf("%s snapshot not redacted: %+v", e.Type, e.User)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package bulk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/bulk"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+var ctx = tenant.WithID(context.Background(), "acme")
+
+// readAll - io.EOF까지 모든 행 읽기
+func readAll(t *testing.T, r usecase.ImportRowReader) []usecase.ImportRow {
+	t.Helper()
+
+	var rows []usecase.ImportRow
+	for {
+		row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// row - 기대하는 행 (malformed면 ErrMalformedRow)
+type row struct {
+	line        int
+	email, name string
+	malformed   bool
+}
+
+func assertRows(t *testing.T, got []usecase.ImportRow, want []row) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Line != w.line {
+			t.Errorf("row %d: line %d, want %d", i, g.Line, w.line)
+		}
+		if w.malformed {
+			if !errors.Is(g.Err, bulk.ErrMalformedRow) {
+				t.Errorf("row %d: err %v, want ErrMalformedRow", i, g.Err)
+			}
+			continue
+		}
+		if g.Err != nil || g.Email != w.email || g.Name != w.name {
+			t.Errorf("row %d: got %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   []row
+	}{
+		{
+			name:   "csv columns in any order with extras",
+			format: bulk.FormatCSV,
+			input:  "Name, extra ,EMAIL\nKim,x, kim@example.com \n\"Lee, Jr.\",y,lee@example.com\n",
+			want:   []row{{line: 2, email: "kim@example.com", name: "Kim"}, {line: 3, email: "lee@example.com", name: "Lee, Jr."}},
+		},
+		{
+			name:   "csv short and malformed rows",
+			format: bulk.FormatCSV,
+			input:  "email,name\nkim@example.com\nlee@example.com,\"Lee\nx\"y\npark@example.com,Park\n",
+			want: []row{
+				{line: 2, malformed: true},
+				{line: 3, malformed: true},
+				{line: 5, email: "park@example.com", name: "Park"},
+			},
+		},
+		{
+			name:   "csv header only",
+			format: bulk.FormatCSV,
+			input:  "email,name\n",
+		},
+		{
+			name:   "ndjson skips blank lines",
+			format: bulk.FormatNDJSON,
+			input:  "{\"email\":\"kim@example.com\",\"name\":\"Kim\"}\n\n   \n{\"name\":\"Lee\",\"email\":\"lee@example.com\",\"extra\":1}\n",
+			want:   []row{{line: 1, email: "kim@example.com", name: "Kim"}, {line: 4, email: "lee@example.com", name: "Lee"}},
+		},
+		{
+			name:   "ndjson invalid line continues",
+			format: bulk.FormatNDJSON,
+			input:  "{\"email\":\"kim@example.com\",\"name\":\"Kim\"}\n{not json}\n[1,2]\n{\"email\":\"lee@example.com\",\"name\":\"Lee\"}",
+			want: []row{
+				{line: 1, email: "kim@example.com", name: "Kim"},
+				{line: 2, malformed: true},
+				{line: 3, malformed: true},
+				{line: 4, email: "lee@example.com", name: "Lee"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := bulk.NewReader(tt.format, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			assertRows(t, readAll(t, r), tt.want)
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   error
+	}{
+		{"unsupported format", "xml", "", bulk.ErrUnsupportedFormat},
+		{"csv empty input", bulk.FormatCSV, "", bulk.ErrMissingColumns},
+		{"csv missing name column", bulk.FormatCSV, "email,nickname\nkim@example.com,Kim\n", bulk.ErrMissingColumns},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := bulk.NewReader(tt.format, strings.NewReader(tt.input)); !errors.Is(err, tt.want) {
+				t.Fatalf("NewReader: got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 한 줄이 너무 길면 행 에러가 아니라 전체 중단
+	long := strings.Repeat("x", 2<<20)
+	r := bulk.NewNDJSONReader(strings.NewReader(long + "\n"))
+	if _, err := r.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Fatalf("Next on oversized line: got %v, want scanner error", err)
+	}
+}
+
+// TestImportPartialFailure - 잘못된 행은 그 행만 실패하고 나머지는 생성됨
+func TestImportPartialFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{
+			name:   "csv",
+			format: bulk.FormatCSV,
+			input:  "email,name\nkim@example.com,Kim\n,NoEmail\nlee@example.com\nkim@example.com,Kim again\npark@example.com,Park\n",
+		},
+		{
+			name:   "ndjson",
+			format: bulk.FormatNDJSON,
+			input: `{"email":"kim@example.com","name":"Kim"}
+{"email":"","name":"NoEmail"}
+{broken
+{"email":"kim@example.com","name":"Kim again"}
+{"email":"park@example.com","name":"Park"}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := usecase.NewUserUseCase(memory.NewUserRepository())
+			r, err := bulk.NewReader(tt.format, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+
+			var results []usecase.ImportResult
+			summary, err := users.ImportUsers(ctx, r, false, func(res usecase.ImportResult) error {
+				results = append(results, res)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ImportUsers: %v", err)
+			}
+			if summary.Total != 5 || summary.Succeeded != 2 || summary.Failed != 3 {
+				t.Fatalf("summary = %+v", summary)
+			}
+
+			wantErrs := []error{nil, domain.ErrInvalidEmail, bulk.ErrMalformedRow, domain.ErrUserExists, nil}
+			for i, want := range wantErrs {
+				if want == nil && results[i].Err != nil || want != nil && !errors.Is(results[i].Err, want) {
+					t.Errorf("result %d (line %d): err %v, want %v", i, results[i].Line, results[i].Err, want)
+				}
+			}
+
+			all, err := users.GetAllUsers(ctx)
+			if err != nil {
+				t.Fatalf("GetAllUsers: %v", err)
+			}
+			if len(all) != 2 {
+				t.Fatalf("stored %d users, want 2", len(all))
+			}
+		})
+	}
+}
+
+// TestRoundTrip - 내보낸 파일을 다시 가져오면 같은 이메일/이름의 사용자가 생김
+func TestRoundTrip(t *testing.T) {
+	source := usecase.NewUserUseCase(memory.NewUserRepository())
+	names := []string{"Kim", "Lee, Jr.", `Park "PJ"`, "홍길동"}
+	for i, name := range names {
+		if _, err := source.CreateUser(ctx, fmt.Sprintf("user%d@example.com", i), name); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+
+	for _, format := range []string{bulk.FormatCSV, bulk.FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := bulk.NewWriter(format, &buf)
+			if err != nil {
+				t.Fatalf("NewWriter: %v", err)
+			}
+			if err := source.ExportUsers(ctx, w.Write); err != nil {
+				t.Fatalf("ExportUsers: %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			r, err := bulk.NewReader(format, &buf)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			target := usecase.NewUserUseCase(memory.NewUserRepository())
+			summary, err := target.ImportUsers(ctx, r, false, func(res usecase.ImportResult) error { return res.Err })
+			if err != nil {
+				t.Fatalf("ImportUsers: %v", err)
+			}
+			if summary.Succeeded != len(names) {
+				t.Fatalf("summary = %+v", summary)
+			}
+
+			want := make(map[string]string)
+			exported, _ := source.GetAllUsers(ctx)
+			for _, user := range exported {
+				want[user.Email] = user.Name
+			}
+			imported, err := target.GetAllUsers(ctx)
+			if err != nil {
+				t.Fatalf("GetAllUsers: %v", err)
+			}
+			got := make(map[string]string)
+			for _, user := range imported {
+				got[user.Email] = user.Name
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("imported %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{bulk.FormatCSV, "id,email,name,created_at,updated_at\n"},
+		{bulk.FormatNDJSON, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := bulk.NewWriter(tt.format, &buf)
+			if err != nil {
+				t.Fatalf("NewWriter: %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Fatalf("output %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	if _, err := bulk.NewWriter("xml", io.Discard); !errors.Is(err, bulk.ErrUnsupportedFormat) {
+		t.Fatalf("NewWriter(xml): got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// 지원 형식
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// maxLineSize - NDJSON 한 줄 최대 크기
+const maxLineSize = 1 << 20
+
+var (
+	ErrUnsupportedFormat = domain.NewError("unsupported_format", "unsupported format")
+	ErrMissingColumns    = domain.NewError("missing_columns", "csv header must contain email and name columns")
+	ErrMalformedRow      = domain.NewError("malformed_row", "malformed row")
+)
+
+// NewReader - 형식 이름으로 가져오기 리더 생성
+func NewReader(format string, r io.Reader) (usecase.ImportRowReader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(r)
+	case FormatNDJSON:
+		return NewNDJSONReader(r), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// CSVReader - email,name 헤더가 있는 CSV 스트림 리더
+type CSVReader struct {
+	r        *csv.Reader
+	emailCol int
+	nameCol  int
+}
+
+// NewCSVReader - 헤더를 읽고 CSVReader 생성
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrMissingColumns
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	emailCol, nameCol := -1, -1
+	for i, col := range header {
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case "email":
+			emailCol = i
+		case "name":
+			nameCol = i
+		}
+	}
+	if emailCol < 0 || nameCol < 0 {
+		return nil, ErrMissingColumns
+	}
+
+	return &CSVReader{r: cr, emailCol: emailCol, nameCol: nameCol}, nil
+}
+
+// Next - 다음 행 읽기 (usecase.ImportRowReader 구현)
+func (c *CSVReader) Next() (usecase.ImportRow, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return usecase.ImportRow{Line: parseErr.StartLine, Err: fmt.Errorf("%w: %v", ErrMalformedRow, parseErr.Err)}, nil
+		}
+		return usecase.ImportRow{}, err
+	}
+	line, _ := c.r.FieldPos(0)
+
+	if c.emailCol >= len(record) || c.nameCol >= len(record) {
+		return usecase.ImportRow{Line: line, Err: fmt.Errorf("%w: expected at least %d fields", ErrMalformedRow, max(c.emailCol, c.nameCol)+1)}, nil
+	}
+
+	return usecase.ImportRow{
+		Line:  line,
+		Email: strings.TrimSpace(record[c.emailCol]),
+		Name:  strings.TrimSpace(record[c.nameCol]),
+	}, nil
+}
+
+// NDJSONReader - 한 줄에 JSON 객체 하나씩 담긴 스트림 리더
+type NDJSONReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewNDJSONReader - NDJSONReader 생성자
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &NDJSONReader{scanner: scanner}
+}
+
+// Next - 다음 행 읽기 (빈 줄은 건너뜀)
+func (n *NDJSONReader) Next() (usecase.ImportRow, error) {
+	for n.scanner.Scan() {
+		n.line++
+		text := bytes.TrimSpace(n.scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var v struct {
+			Email string `json:"email"`
+			Name  string `json:"name"`
+		}
+		if err := json.Unmarshal(text, &v); err != nil {
+			return usecase.ImportRow{Line: n.line, Err: fmt.Errorf("%w: %v", ErrMalformedRow, err)}, nil
+		}
+
+		return usecase.ImportRow{Line: n.line, Email: v.Email, Name: v.Name}, nil
+	}
+
+	if err := n.scanner.Err(); err != nil {
+		return usecase.ImportRow{}, err
+	}
+	return usecase.ImportRow{}, io.EOF
+}
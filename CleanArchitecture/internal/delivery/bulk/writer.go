@@ -0,0 +1,114 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// UserWriter - 내보내기 스트림 라이터
+type UserWriter interface {
+	Write(user *domain.User) error
+	Flush() error
+}
+
+// record - 내보내기 레코드 (HTTP 응답과 같은 필드 이름)
+type record struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toRecord(user *domain.User) record {
+	return record{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// NewWriter - 형식 이름으로 내보내기 라이터 생성
+func NewWriter(format string, w io.Writer) (UserWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatNDJSON:
+		return NewNDJSONWriter(w), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ContentType - 형식별 Content-Type
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// CSVWriter - CSV 내보내기 (첫 Write 때 헤더 기록)
+type CSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// NewCSVWriter - CSVWriter 생성자
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (c *CSVWriter) writeHeader() error {
+	c.wroteHeader = true
+	return c.w.Write([]string{"id", "email", "name", "created_at", "updated_at"})
+}
+
+// Write - 사용자 한 명 기록
+func (c *CSVWriter) Write(user *domain.User) error {
+	if !c.wroteHeader {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	rec := toRecord(user)
+	return c.w.Write([]string{rec.ID, rec.Email, rec.Name, rec.CreatedAt, rec.UpdatedAt})
+}
+
+// Flush - 버퍼 비우기 (사용자가 없어도 헤더는 기록)
+func (c *CSVWriter) Flush() error {
+	if !c.wroteHeader {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// NDJSONWriter - 한 줄에 JSON 객체 하나씩 기록
+type NDJSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONWriter - NDJSONWriter 생성자
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{enc: json.NewEncoder(w)}
+}
+
+// Write - 사용자 한 명 기록
+func (n *NDJSONWriter) Write(user *domain.User) error {
+	return n.enc.Encode(toRecord(user))
+}
+
+// Flush - json.Encoder는 버퍼가 없으므로 할 일 없음
+func (n *NDJSONWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+)
+
+// 에러 코드 (extensions.code)
+const (
+	codeNotFound      = "NOT_FOUND"
+	codeAlreadyExists = "ALREADY_EXISTS"
+	codeBadUserInput  = "BAD_USER_INPUT"
+	codeForbidden     = "FORBIDDEN"
+	codeConflict      = "CONFLICT"
+	codeInternal      = "INTERNAL"
+)
+
+// Error - 타입이 있는 GraphQL 에러
+// graphql-go가 Extensions()를 응답의 "extensions" 필드로 내보냄
+// message는 요청 언어(Accept-Language)로 지역화한 메시지
+type Error struct {
+	Code      string
+	localized i18n.Localized
+	err       error
+}
+
+func (e *Error) Error() string {
+	return e.localized.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Extensions - GraphQL 에러 확장 필드
+// reason은 언어와 무관한 에러 코드, detail은 감싼 에러의 원문 설명
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{
+		"code": e.Code,
+	}
+	if e.localized.Code != "" {
+		ext["reason"] = e.localized.Code
+	}
+	if e.localized.Detail != "" {
+		ext["detail"] = e.localized.Detail
+	}
+	return ext
+}
+
+// toGraphQLError - 도메인 에러를 요청 언어의 GraphQL 에러로 변환
+func toGraphQLError(ctx context.Context, err error) error {
+	e := newError(err)
+	e.localized = i18n.Localize(i18n.FromContext(ctx), err)
+	return e
+}
+
+// newError - 도메인 에러를 GraphQL 에러 분류로 매핑
+func newError(err error) *Error {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return &Error{Code: codeNotFound, err: err}
+	case errors.Is(err, domain.ErrUserExists):
+		return &Error{Code: codeAlreadyExists, err: err}
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrTenantRequired),
+		errors.Is(err, domain.ErrInvalidTenant),
+		errors.Is(err, errInvalidCursor),
+		errors.Is(err, errInvalidFirst):
+		return &Error{Code: codeBadUserInput, err: err}
+	case errors.Is(err, domain.ErrCrossTenant):
+		return &Error{Code: codeForbidden, err: err}
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated),
+		errors.Is(err, domain.ErrUserModified):
+		return &Error{Code: codeConflict, err: err}
+	default:
+		return &Error{Code: codeInternal, err: err}
+	}
+}
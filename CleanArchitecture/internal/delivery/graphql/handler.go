@@ -0,0 +1,27 @@
+package graphql
+
+import (
+	_ "embed"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// NewHandler - GraphQL HTTP 핸들러 생성 (POST /graphql)
+// 요청마다 새 userLoader를 컨텍스트에 넣어 ID 조회를 배치 처리
+func NewHandler(userUseCase *usecase.UserUseCase) http.Handler {
+	resolver := NewResolver(userUseCase)
+	schema := graphql.MustParseSchema(schemaSDL, resolver)
+	h := &relay.Handler{Schema: schema}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		loader := newUserLoader(ctx, resolver.loadUsers)
+		h.ServeHTTP(w, r.WithContext(withUserLoader(ctx, loader)))
+	})
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// 배치 설정 (배치 하나가 BatchGetUsers 한 번이므로 최대 크기는 usecase.MaxBatchSize)
+const (
+	defaultBatchWait = 2 * time.Millisecond
+	defaultMaxBatch  = usecase.MaxBatchSize
+)
+
+// userBatchFunc - ID 목록을 한 번에 조회하는 함수
+type userBatchFunc func(ctx context.Context, ids []string) map[string]userResult
+
+type userResult struct {
+	user *domain.User
+	err  error
+}
+
+// userBatch - 같은 시간 창에 모인 ID 요청 묶음
+type userBatch struct {
+	keys    []string
+	once    sync.Once
+	done    chan struct{}
+	results map[string]userResult
+}
+
+// userLoader - dataloader 스타일 ID 조회 병합기 (요청 단위)
+// 짧은 시간 창 안에 들어온 Load 호출을 하나의 배치로 모으고,
+// 같은 ID는 한 번만 조회하며 결과를 요청이 끝날 때까지 캐시
+type userLoader struct {
+	ctx      context.Context
+	fetch    userBatchFunc
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending *userBatch
+	cache   map[string]*userBatch
+}
+
+// newUserLoader - userLoader 생성자
+func newUserLoader(ctx context.Context, fetch userBatchFunc) *userLoader {
+	return &userLoader{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     defaultBatchWait,
+		maxBatch: defaultMaxBatch,
+		cache:    make(map[string]*userBatch),
+	}
+}
+
+// Load - ID로 사용자 조회 (배치가 실행될 때까지 대기)
+func (l *userLoader) Load(ctx context.Context, id string) (*domain.User, error) {
+	l.mu.Lock()
+	b, ok := l.cache[id]
+	if !ok {
+		if l.pending == nil {
+			l.pending = &userBatch{done: make(chan struct{})}
+			pending := l.pending
+			time.AfterFunc(l.wait, func() { l.dispatch(pending) })
+		}
+		b = l.pending
+		b.keys = append(b.keys, id)
+		l.cache[id] = b
+		if len(b.keys) >= l.maxBatch {
+			l.pending = nil
+			go l.dispatch(b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		r := b.results[id]
+		return r.user, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Clear - 캐시에서 ID 제거 (변경 뮤테이션 후 호출)
+func (l *userLoader) Clear(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.cache[id]; ok && b != l.pending {
+		delete(l.cache, id)
+	}
+}
+
+// dispatch - 배치 실행 (배치당 한 번만)
+func (l *userLoader) dispatch(b *userBatch) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.pending == b {
+			l.pending = nil
+		}
+		l.mu.Unlock()
+
+		b.results = l.fetch(l.ctx, b.keys)
+		close(b.done)
+	})
+}
+
+type loaderKey struct{}
+
+// withUserLoader - 컨텍스트에 요청 단위 loader 저장
+func withUserLoader(ctx context.Context, l *userLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// userLoaderFrom - 컨텍스트에서 loader 조회
+func userLoaderFrom(ctx context.Context) (*userLoader, bool) {
+	l, ok := ctx.Value(loaderKey{}).(*userLoader)
+	return l, ok
+}
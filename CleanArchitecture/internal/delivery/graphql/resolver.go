@@ -0,0 +1,265 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// 페이지네이션 설정
+const maxPageSize = 100
+
+var (
+	errInvalidCursor = domain.NewError("invalid_cursor", "invalid cursor")
+	errInvalidFirst  = domain.NewError("invalid_first", "first must be between 0 and 100")
+)
+
+// Resolver - GraphQL 루트 리졸버 (프레젠테이션 레이어)
+type Resolver struct {
+	userUseCase *usecase.UserUseCase
+}
+
+// NewResolver - Resolver 생성자
+func NewResolver(userUseCase *usecase.UserUseCase) *Resolver {
+	return &Resolver{
+		userUseCase: userUseCase,
+	}
+}
+
+// loadUsers - loader 배치 함수 (배치 하나를 BatchGetUsers 한 번으로 조회)
+// 배치 전체가 실패하면(테넌트 없음 등) 모든 ID에 같은 에러
+func (r *Resolver) loadUsers(ctx context.Context, ids []string) map[string]userResult {
+	results := make(map[string]userResult, len(ids))
+	batch, err := r.userUseCase.BatchGetUsers(ctx, ids)
+	if err != nil {
+		for _, id := range ids {
+			results[id] = userResult{err: err}
+		}
+		return results
+	}
+	for _, res := range batch {
+		results[ids[res.Index]] = userResult{user: res.User, err: res.Err}
+	}
+	return results
+}
+
+// User - user(id) 쿼리
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	var (
+		user *domain.User
+		err  error
+	)
+	if l, ok := userLoaderFrom(ctx); ok {
+		user, err = l.Load(ctx, string(args.ID))
+	} else {
+		user, err = r.userUseCase.GetUser(ctx, string(args.ID))
+	}
+	if err != nil {
+		return nil, toGraphQLError(ctx, err)
+	}
+	if user == nil {
+		return nil, toGraphQLError(ctx, domain.ErrUserNotFound)
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+// Users - users(first, after) 쿼리
+func (r *Resolver) Users(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*userConnectionResolver, error) {
+	first := int(args.First)
+	if first < 0 || first > maxPageSize {
+		return nil, toGraphQLError(ctx, errInvalidFirst)
+	}
+
+	users, err := r.userUseCase.GetAllUsers(ctx)
+	if err != nil {
+		return nil, toGraphQLError(ctx, err)
+	}
+
+	// 안정적인 커서를 위해 생성 시각, ID 순으로 정렬
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID < users[j].ID
+	})
+
+	start := 0
+	if args.After != nil {
+		afterID, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, toGraphQLError(ctx, err)
+		}
+		start = -1
+		for i, user := range users {
+			if user.ID == afterID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, toGraphQLError(ctx, errInvalidCursor)
+		}
+	}
+
+	end := start + first
+	if end > len(users) {
+		end = len(users)
+	}
+
+	return &userConnectionResolver{
+		users:       users[start:end],
+		totalCount:  len(users),
+		hasNextPage: end < len(users),
+	}, nil
+}
+
+// CreateUser - createUser 뮤테이션
+func (r *Resolver) CreateUser(ctx context.Context, args struct {
+	Input struct {
+		Email string
+		Name  string
+	}
+}) (*userResolver, error) {
+	user, err := r.userUseCase.CreateUser(ctx, args.Input.Email, args.Input.Name)
+	if err != nil {
+		return nil, toGraphQLError(ctx, err)
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+// UpdateUser - updateUser 뮤테이션
+func (r *Resolver) UpdateUser(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input struct {
+		Name string
+	}
+}) (*userResolver, error) {
+	user, err := r.userUseCase.UpdateUser(ctx, string(args.ID), args.Input.Name)
+	if err != nil {
+		return nil, toGraphQLError(ctx, err)
+	}
+	if l, ok := userLoaderFrom(ctx); ok {
+		l.Clear(user.ID)
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+// DeleteUser - deleteUser 뮤테이션 (삭제된 ID 반환)
+func (r *Resolver) DeleteUser(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	if err := r.userUseCase.DeleteUser(ctx, string(args.ID)); err != nil {
+		return "", toGraphQLError(ctx, err)
+	}
+	if l, ok := userLoaderFrom(ctx); ok {
+		l.Clear(string(args.ID))
+	}
+
+	return args.ID, nil
+}
+
+// userResolver - User 타입 리졸버
+type userResolver struct {
+	user *domain.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.ID)
+}
+
+func (r *userResolver) Email() string {
+	return r.user.Email
+}
+
+func (r *userResolver) Name() string {
+	return r.user.Name
+}
+
+func (r *userResolver) Status() string {
+	return string(r.user.CurrentStatus())
+}
+
+func (r *userResolver) CreatedAt() string {
+	return r.user.CreatedAt.Format("2006-01-02T15:04:05Z07:00")
+}
+
+func (r *userResolver) UpdatedAt() string {
+	return r.user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+}
+
+// userConnectionResolver - UserConnection 타입 리졸버
+type userConnectionResolver struct {
+	users       []*domain.User
+	totalCount  int
+	hasNextPage bool
+}
+
+func (r *userConnectionResolver) Edges() []*userEdgeResolver {
+	edges := make([]*userEdgeResolver, len(r.users))
+	for i, user := range r.users {
+		edges[i] = &userEdgeResolver{user: user}
+	}
+	return edges
+}
+
+func (r *userConnectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{hasNextPage: r.hasNextPage}
+	if len(r.users) > 0 {
+		cursor := encodeCursor(r.users[len(r.users)-1].ID)
+		info.endCursor = &cursor
+	}
+	return info
+}
+
+func (r *userConnectionResolver) TotalCount() int32 {
+	return int32(r.totalCount)
+}
+
+// userEdgeResolver - UserEdge 타입 리졸버
+type userEdgeResolver struct {
+	user *domain.User
+}
+
+func (r *userEdgeResolver) Cursor() string {
+	return encodeCursor(r.user.ID)
+}
+
+func (r *userEdgeResolver) Node() *userResolver {
+	return &userResolver{user: r.user}
+}
+
+// pageInfoResolver - PageInfo 타입 리졸버
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+// encodeCursor - 사용자 ID를 불투명한 커서로 인코딩
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("user:" + id))
+}
+
+// decodeCursor - 커서에서 사용자 ID 추출
+func decodeCursor(cursor string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) <= len("user:") || string(b[:len("user:")]) != "user:" {
+		return "", errInvalidCursor
+	}
+	return string(b[len("user:"):]), nil
+}
@@ -0,0 +1,160 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/graphql"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// countingRepository - ID 조회 호출 수를 세는 저장소
+type countingRepository struct {
+	*memory.UserRepository
+	getByID  atomic.Int32
+	getByIDs atomic.Int32
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	r.getByID.Add(1)
+	return r.UserRepository.GetByID(ctx, id)
+}
+
+func (r *countingRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.User, error) {
+	r.getByIDs.Add(1)
+	return r.UserRepository.GetByIDs(ctx, ids)
+}
+
+type gqlResponse struct {
+	Data   map[string]*struct{ ID, Email string }
+	Errors []struct {
+		Path       []any
+		Extensions struct{ Code string }
+	}
+}
+
+// query - 테넌트 "acme"로 GraphQL 요청 실행
+func query(t *testing.T, h http.Handler, q string) gqlResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"query": q})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req = req.WithContext(tenant.WithID(req.Context(), "acme"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v (%s)", err, rec.Body)
+	}
+	return resp
+}
+
+func TestUserQueriesBatchedPerRequest(t *testing.T) {
+	repo := &countingRepository{UserRepository: memory.NewUserRepository()}
+	users := usecase.NewUserUseCase(repo)
+	ctx := tenant.WithID(context.Background(), "acme")
+	alice, err := users.CreateUser(ctx, "alice@example.com", "Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := users.CreateUser(ctx, "bob@example.com", "Bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := users.CreateUser(tenant.WithID(context.Background(), "other"), "carol@example.com", "Carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := graphql.NewHandler(users)
+
+	tests := []struct {
+		name     string
+		query    string
+		want     map[string]string // 별칭 → 이메일 ("" 이면 null)
+		notFound []string          // NOT_FOUND 에러가 나야 하는 별칭
+	}{
+		{
+			name:  "aliases share one fetch",
+			query: `{ a: user(id: "` + alice.ID + `") { id email } b: user(id: "` + bob.ID + `") { id email } }`,
+			want:  map[string]string{"a": alice.Email, "b": bob.Email},
+		},
+		{
+			name:     "missing and other tenant",
+			query:    `{ a: user(id: "` + alice.ID + `") { id email } b: user(id: "nope") { id } c: user(id: "` + other.ID + `") { id } }`,
+			want:     map[string]string{"a": alice.Email, "b": "", "c": ""},
+			notFound: []string{"b", "c"},
+		},
+		{
+			name:  "duplicate ids",
+			query: `{ a: user(id: "` + bob.ID + `") { id email } b: user(id: "` + bob.ID + `") { id email } }`,
+			want:  map[string]string{"a": bob.Email, "b": bob.Email},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.getByID.Store(0)
+			repo.getByIDs.Store(0)
+
+			resp := query(t, h, tt.query)
+
+			if n := repo.getByIDs.Load(); n != 1 {
+				t.Errorf("GetByIDs called %d times, want 1", n)
+			}
+			if n := repo.getByID.Load(); n != 0 {
+				t.Errorf("GetByID called %d times, want 0", n)
+			}
+			for alias, email := range tt.want {
+				got := resp.Data[alias]
+				switch {
+				case email == "" && got != nil:
+					t.Errorf("%s: got %+v, want null", alias, got)
+				case email != "" && (got == nil || got.Email != email):
+					t.Errorf("%s: got %+v, want %s", alias, got, email)
+				}
+			}
+			codes := make(map[string]string)
+			for _, e := range resp.Errors {
+				if len(e.Path) > 0 {
+					codes[e.Path[0].(string)] = e.Extensions.Code
+				}
+			}
+			if len(codes) != len(tt.notFound) {
+				t.Errorf("errors = %+v, want NOT_FOUND for %v", resp.Errors, tt.notFound)
+			}
+			for _, alias := range tt.notFound {
+				if codes[alias] != "NOT_FOUND" {
+					t.Errorf("%s: error code %q, want NOT_FOUND", alias, codes[alias])
+				}
+			}
+		})
+	}
+}
+
+func TestUserQueryWithoutTenant(t *testing.T) {
+	repo := &countingRepository{UserRepository: memory.NewUserRepository()}
+	h := graphql.NewHandler(usecase.NewUserUseCase(repo))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ a: user(id: \"x\") { id } b: user(id: \"y\") { id } }"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Errors) != 2 {
+		t.Fatalf("errors = %+v, want one per field", resp.Errors)
+	}
+	if n := repo.getByIDs.Load() + repo.getByID.Load(); n != 0 {
+		t.Fatalf("repository called %d times without tenant, want 0", n)
+	}
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain - ErrorInfo.Domain (에러 코드의 이름공간)
+const errorDomain = "users.clean-architecture"
+
+// languageMetadataKey - 응답 언어 메타데이터 키 (HTTP Accept-Language와 같은 형식)
+const languageMetadataKey = "accept-language"
+
+// toStatusError - 도메인 에러를 gRPC 상태 코드로 변환
+// 상태 메시지는 개발자용 원문(영어)을 유지하고, 코드가 있는 에러는
+// ErrorInfo(reason = 에러 코드)와 LocalizedMessage(요청 언어)를 details에 붙임
+func toStatusError(ctx context.Context, err error) error {
+	st := status.New(statusCode(err), err.Error())
+
+	e, ok := domain.AsError(err)
+	if !ok {
+		return st.Err()
+	}
+	tag := requestLanguage(ctx)
+	localized := i18n.Localize(tag, err)
+	withDetails, detailErr := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: e.Code(), Domain: errorDomain},
+		&errdetails.LocalizedMessage{Locale: tag.String(), Message: localized.Message},
+	)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return codes.NotFound
+	case errors.Is(err, domain.ErrUserExists):
+		return codes.AlreadyExists
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrTenantRequired),
+		errors.Is(err, domain.ErrInvalidTenant):
+		return codes.InvalidArgument
+	case errors.Is(err, domain.ErrCrossTenant):
+		return codes.PermissionDenied
+	case errors.Is(err, domain.ErrUserModified):
+		return codes.Aborted
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated):
+		return codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// requestLanguage - 메타데이터 accept-language로 응답 언어 선택
+func requestLanguage(ctx context.Context) language.Tag {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(languageMetadataKey); len(values) > 0 {
+			return i18n.Negotiate(values[0])
+		}
+	}
+	return i18n.Default
+}
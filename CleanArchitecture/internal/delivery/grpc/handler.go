@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/grpc/userpb"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative userpb/user.proto
+
+// UserHandler - gRPC 핸들러 (프레젠테이션 레이어)
+// HTTP 핸들러와 같은 UserUseCase를 사용
+type UserHandler struct {
+	userpb.UnimplementedUserServiceServer
+	userUseCase *usecase.UserUseCase
+}
+
+// NewUserHandler - UserHandler 생성자
+func NewUserHandler(userUseCase *usecase.UserUseCase) *UserHandler {
+	return &UserHandler{
+		userUseCase: userUseCase,
+	}
+}
+
+// toUserMessage - 도메인 엔티티를 protobuf 메시지로 변환
+func toUserMessage(user *domain.User) *userpb.User {
+	return &userpb.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// CreateUser - 사용자 생성
+func (h *UserHandler) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.User, error) {
+	user, err := h.userUseCase.CreateUser(ctx, req.GetEmail(), req.GetName())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return toUserMessage(user), nil
+}
+
+// GetUser - 사용자 조회
+func (h *UserHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.User, error) {
+	user, err := h.userUseCase.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return toUserMessage(user), nil
+}
+
+// ListUsers - 모든 사용자 조회
+func (h *UserHandler) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	users, err := h.userUseCase.GetAllUsers(ctx)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	resp := &userpb.ListUsersResponse{
+		Users: make([]*userpb.User, len(users)),
+	}
+	for i, user := range users {
+		resp.Users[i] = toUserMessage(user)
+	}
+
+	return resp, nil
+}
+
+// StreamUsers - 사용자 목록을 한 건씩 스트리밍
+// 저장소가 usecase.UserStreamer를 구현하면 전체 목록을 메모리에 올리지 않고 읽는 대로 보냄
+func (h *UserHandler) StreamUsers(req *userpb.ListUsersRequest, stream userpb.UserService_StreamUsersServer) error {
+	var sendErr error
+	err := h.userUseCase.ExportUsers(stream.Context(), func(user *domain.User) error {
+		sendErr = stream.Send(toUserMessage(user))
+		return sendErr
+	})
+	if sendErr != nil {
+		// 전송 실패(클라이언트 연결 끊김 등)는 이미 gRPC 상태이므로 그대로 반환
+		return sendErr
+	}
+	if err != nil {
+		return toStatusError(stream.Context(), err)
+	}
+
+	return nil
+}
+
+// UpdateUser - 사용자 수정
+func (h *UserHandler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.User, error) {
+	user, err := h.userUseCase.UpdateUser(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return toUserMessage(user), nil
+}
+
+// DeleteUser - 사용자 삭제
+func (h *UserHandler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := h.userUseCase.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,310 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	deliverygrpc "github.com/milman2/go-api/clean-architecture/internal/delivery/grpc"
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/grpc/userpb"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// countingRepository - 목록 조회 방식(GetAll / Stream) 호출 수를 세는 저장소
+type countingRepository struct {
+	*memory.UserRepository
+	getAll atomic.Int32
+	stream atomic.Int32
+}
+
+func (r *countingRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	r.getAll.Add(1)
+	return r.UserRepository.GetAll(ctx)
+}
+
+func (r *countingRepository) Stream(ctx context.Context, fn func(*domain.User) error) error {
+	r.stream.Add(1)
+	return r.UserRepository.Stream(ctx, fn)
+}
+
+// plainRepository - UserStreamer를 구현하지 않는 저장소 (UserRepository 메서드만 노출)
+type plainRepository struct {
+	usecase.UserRepository
+}
+
+// newClient - bufconn 위에 app과 같은 인터셉터로 서버를 띄우고 클라이언트 반환 (기본 테넌트 없음, 메타데이터 신뢰)
+func newClient(t *testing.T, repo usecase.UserRepository) userpb.UserServiceClient {
+	t.Helper()
+	return newTenantClient(t, repo, "", true)
+}
+
+// newTenantClient - 테넌트 인터셉터 설정을 지정한 newClient
+func newTenantClient(t *testing.T, repo usecase.UserRepository, defaultTenant string, trustMetadata bool) userpb.UserServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := deliverygrpc.NewServer(
+		deliverygrpc.NewUserHandler(usecase.NewUserUseCase(repo)),
+		grpc.UnaryInterceptor(deliverygrpc.TenantUnaryInterceptor(defaultTenant, trustMetadata)),
+		grpc.StreamInterceptor(deliverygrpc.TenantStreamInterceptor(defaultTenant, trustMetadata)),
+	)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return userpb.NewUserServiceClient(conn)
+}
+
+// inTenant - 테넌트 메타데이터를 붙인 컨텍스트
+func inTenant(tenantID string, kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), append([]string{"x-tenant-id", tenantID}, kv...)...)
+}
+
+// assertStatus - gRPC 상태 코드와 ErrorInfo.Reason 확인 (reason이 ""이면 details 없음)
+func assertStatus(t *testing.T, op string, err error, code codes.Code, reason string) *status.Status {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("%s: got non-status error %v", op, err)
+	}
+	if st.Code() != code {
+		t.Fatalf("%s: got code %s (%s), want %s", op, st.Code(), st.Message(), code)
+	}
+	var got string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			got = info.GetReason()
+		}
+	}
+	if got != reason {
+		t.Fatalf("%s: got reason %q, want %q", op, got, reason)
+	}
+	return st
+}
+
+func TestUserServiceCRUD(t *testing.T) {
+	client := newClient(t, memory.NewUserRepository())
+	ctx := inTenant("acme")
+
+	created, err := client.CreateUser(ctx, &userpb.CreateUserRequest{Email: "kim@example.com", Name: "Kim"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if created.GetId() == "" || created.GetEmail() != "kim@example.com" || created.GetName() != "Kim" {
+		t.Fatalf("CreateUser = %+v", created)
+	}
+	if created.GetCreatedAt().AsTime().IsZero() || !created.GetUpdatedAt().AsTime().Equal(created.GetCreatedAt().AsTime()) {
+		t.Fatalf("CreateUser timestamps = %v, %v", created.GetCreatedAt(), created.GetUpdatedAt())
+	}
+
+	got, err := client.GetUser(ctx, &userpb.GetUserRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.GetId() != created.GetId() || got.GetEmail() != created.GetEmail() || !got.GetCreatedAt().AsTime().Equal(created.GetCreatedAt().AsTime()) {
+		t.Fatalf("GetUser = %+v, want %+v", got, created)
+	}
+
+	updated, err := client.UpdateUser(ctx, &userpb.UpdateUserRequest{Id: created.GetId(), Name: "Kim Updated"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.GetName() != "Kim Updated" || updated.GetUpdatedAt().AsTime().Before(created.GetUpdatedAt().AsTime()) {
+		t.Fatalf("UpdateUser = %+v", updated)
+	}
+
+	list, err := client.ListUsers(ctx, &userpb.ListUsersRequest{})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(list.GetUsers()) != 1 || list.GetUsers()[0].GetName() != "Kim Updated" {
+		t.Fatalf("ListUsers = %+v", list.GetUsers())
+	}
+
+	// 다른 테넌트에서는 보이지 않음
+	other, err := client.ListUsers(inTenant("other"), &userpb.ListUsersRequest{})
+	if err != nil || len(other.GetUsers()) != 0 {
+		t.Fatalf("ListUsers in other tenant = %+v, %v", other.GetUsers(), err)
+	}
+
+	if _, err := client.DeleteUser(ctx, &userpb.DeleteUserRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	_, err = client.GetUser(ctx, &userpb.GetUserRequest{Id: created.GetId()})
+	assertStatus(t, "GetUser after delete", err, codes.NotFound, "user_not_found")
+}
+
+func TestUserServiceErrors(t *testing.T) {
+	client := newClient(t, memory.NewUserRepository())
+	kim, err := client.CreateUser(inTenant("acme"), &userpb.CreateUserRequest{Email: "kim@example.com", Name: "Kim"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{"not found", func() error {
+			_, err := client.GetUser(inTenant("acme"), &userpb.GetUserRequest{Id: "missing"})
+			return err
+		}, codes.NotFound, "user_not_found"},
+		{"empty id", func() error {
+			_, err := client.GetUser(inTenant("acme"), &userpb.GetUserRequest{})
+			return err
+		}, codes.InvalidArgument, "invalid_user_id"},
+		{"empty email", func() error {
+			_, err := client.CreateUser(inTenant("acme"), &userpb.CreateUserRequest{Name: "Kim"})
+			return err
+		}, codes.InvalidArgument, "invalid_email"},
+		{"duplicate email", func() error {
+			_, err := client.CreateUser(inTenant("acme"), &userpb.CreateUserRequest{Email: "kim@example.com", Name: "Kim"})
+			return err
+		}, codes.AlreadyExists, "user_exists"},
+		{"other tenant", func() error {
+			_, err := client.GetUser(inTenant("other"), &userpb.GetUserRequest{Id: kim.GetId()})
+			return err
+		}, codes.PermissionDenied, "cross_tenant"},
+		{"no tenant", func() error {
+			_, err := client.GetUser(context.Background(), &userpb.GetUserRequest{Id: kim.GetId()})
+			return err
+		}, codes.InvalidArgument, "tenant_required"},
+		{"invalid tenant", func() error {
+			_, err := client.GetUser(inTenant("Not A Tenant!"), &userpb.GetUserRequest{Id: kim.GetId()})
+			return err
+		}, codes.InvalidArgument, "invalid_tenant"},
+		{"conflicting tenants", func() error {
+			_, err := client.GetUser(inTenant("acme", "x-tenant-id", "other"), &userpb.GetUserRequest{Id: kim.GetId()})
+			return err
+		}, codes.PermissionDenied, "cross_tenant"},
+		{"stream without tenant", func() error {
+			stream, err := client.StreamUsers(context.Background(), &userpb.ListUsersRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.InvalidArgument, "tenant_required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStatus(t, tt.name, tt.call(), tt.code, tt.reason)
+		})
+	}
+}
+
+// 메타데이터를 믿지 않으면 기본 테넌트와 다른 테넌트를 고를 수 없음
+func TestUserServiceUntrustedTenantMetadata(t *testing.T) {
+	client := newTenantClient(t, memory.NewUserRepository(), "default", false)
+
+	if _, err := client.ListUsers(context.Background(), &userpb.ListUsersRequest{}); err != nil {
+		t.Fatalf("ListUsers without metadata: %v", err)
+	}
+	if _, err := client.ListUsers(inTenant("default"), &userpb.ListUsersRequest{}); err != nil {
+		t.Fatalf("ListUsers with matching metadata: %v", err)
+	}
+	_, err := client.ListUsers(inTenant("acme"), &userpb.ListUsersRequest{})
+	assertStatus(t, "ListUsers in other tenant", err, codes.PermissionDenied, "cross_tenant")
+
+	stream, err := client.StreamUsers(inTenant("acme"), &userpb.ListUsersRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	assertStatus(t, "StreamUsers in other tenant", err, codes.PermissionDenied, "cross_tenant")
+}
+
+func TestUserServiceLocalizedError(t *testing.T) {
+	client := newClient(t, memory.NewUserRepository())
+
+	_, err := client.GetUser(inTenant("acme", "accept-language", "ko-KR"), &userpb.GetUserRequest{Id: "missing"})
+	st := assertStatus(t, "GetUser", err, codes.NotFound, "user_not_found")
+	if st.Message() != "user not found" {
+		t.Fatalf("status message = %q, want the English original", st.Message())
+	}
+	var localized *errdetails.LocalizedMessage
+	for _, d := range st.Details() {
+		if m, ok := d.(*errdetails.LocalizedMessage); ok {
+			localized = m
+		}
+	}
+	if localized == nil || localized.GetLocale() != "ko" || localized.GetMessage() != "사용자를 찾을 수 없습니다" {
+		t.Fatalf("localized message = %+v", localized)
+	}
+}
+
+func TestStreamUsers(t *testing.T) {
+	streaming := &countingRepository{UserRepository: memory.NewUserRepository()}
+	tests := []struct {
+		name string
+		repo usecase.UserRepository
+	}{
+		{"streamer", streaming},
+		{"get all fallback", plainRepository{memory.NewUserRepository()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, tt.repo)
+			ctx := inTenant("acme")
+			want := make(map[string]bool)
+			for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+				user, err := client.CreateUser(ctx, &userpb.CreateUserRequest{Email: email, Name: "User"})
+				if err != nil {
+					t.Fatalf("CreateUser: %v", err)
+				}
+				want[user.GetId()] = true
+			}
+			if _, err := client.CreateUser(inTenant("other"), &userpb.CreateUserRequest{Email: "d@example.com", Name: "User"}); err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+
+			stream, err := client.StreamUsers(ctx, &userpb.ListUsersRequest{})
+			if err != nil {
+				t.Fatalf("StreamUsers: %v", err)
+			}
+			seen := make(map[string]bool)
+			for {
+				user, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Recv: %v", err)
+				}
+				if !want[user.GetId()] || seen[user.GetId()] {
+					t.Fatalf("unexpected user %+v", user)
+				}
+				seen[user.GetId()] = true
+			}
+			if len(seen) != len(want) {
+				t.Fatalf("streamed %d users, want %d", len(seen), len(want))
+			}
+		})
+	}
+
+	// 저장소가 UserStreamer면 전체 목록(GetAll)을 읽지 않음
+	if streaming.stream.Load() != 1 || streaming.getAll.Load() != 0 {
+		t.Fatalf("Stream called %d times, GetAll %d times; want 1 and 0", streaming.stream.Load(), streaming.getAll.Load())
+	}
+}
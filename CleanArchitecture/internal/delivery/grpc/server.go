@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/grpc/userpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer - gRPC 서버 설정
+// 헬스 체크와 서버 리플렉션(grpcurl 등 도구 지원)을 함께 등록
+func NewServer(userHandler *UserHandler, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+
+	// 서비스 등록
+	userpb.RegisterUserServiceServer(s, userHandler)
+
+	// 헬스 체크
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(userpb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	// 서버 리플렉션
+	reflection.Register(s)
+
+	return s
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tenantMetadataKey - 테넌트 메타데이터 키 (gRPC 메타데이터 키는 소문자)
+const tenantMetadataKey = "x-tenant-id"
+
+// TenantUnaryInterceptor - 메타데이터의 테넌트를 컨텍스트에 저장
+// 메타데이터가 없으면 defaultTenant 사용 (빈 문자열이면 테넌트 없이 진행 → InvalidArgument)
+// trustMetadata가 false면 메타데이터로 테넌트를 고를 수 없고 defaultTenant와 같을 때만 허용 (다르면 PermissionDenied)
+// 클라이언트가 메타데이터를 마음대로 보낼 수 있으므로, 앞단 게이트웨이가 인증 후 덮어쓰는 신뢰 네트워크에서만 true
+// 헬스 체크/리플렉션처럼 테넌트가 필요 없는 서비스는 영향 없음
+func TenantUnaryInterceptor(defaultTenant string, trustMetadata bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := withTenant(ctx, defaultTenant, trustMetadata)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantStreamInterceptor - 스트리밍 RPC용 TenantUnaryInterceptor
+func TenantStreamInterceptor(defaultTenant string, trustMetadata bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withTenant(ss.Context(), defaultTenant, trustMetadata)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &tenantStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withTenant - 메타데이터에서 테넌트를 확인해 컨텍스트에 저장
+func withTenant(ctx context.Context, defaultTenant string, trustMetadata bool) (context.Context, error) {
+	tenantID := defaultTenant
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// 값이 여러 개인데 서로 다르면 다른 테넌트 접근 시도로 보고 거부
+		values := md.Get(tenantMetadataKey)
+		for _, v := range values {
+			if v != values[0] {
+				return nil, toStatusError(ctx, domain.ErrCrossTenant)
+			}
+		}
+		if len(values) > 0 {
+			if !trustMetadata && values[0] != defaultTenant {
+				return nil, toStatusError(ctx, domain.ErrCrossTenant)
+			}
+			tenantID = values[0]
+		}
+	}
+
+	if tenantID == "" {
+		return ctx, nil
+	}
+	if !tenant.Valid(tenantID) {
+		return nil, toStatusError(ctx, domain.ErrInvalidTenant)
+	}
+	return tenant.WithID(ctx, tenantID), nil
+}
+
+// tenantStream - 컨텍스트만 바꾼 ServerStream
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// requireAdmin - Authorization: Bearer <관리자 토큰> 확인 (토큰이 비어 있으면 모두 거부)
+func requireAdmin(adminToken string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || adminToken == "" ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			respondError(w, r, http.StatusUnauthorized, errAdminTokenRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// maxSchemaBytes - 스키마 문서 최대 크기
+const maxSchemaBytes = 64 << 10
+
+// attributeFilterPrefix - 목록 조회 속성 조건 쿼리 파라미터 접두어 (attr.<네임스페이스>.<필드>=값)
+const attributeFilterPrefix = "attr."
+
+// AttributeErrorResponse - 속성 검증 실패 응답 DTO
+type AttributeErrorResponse struct {
+	Error     string                 `json:"error"`
+	Code      string                 `json:"code"`
+	Namespace string                 `json:"namespace"`
+	Details   []AttributeErrorDetail `json:"details"`
+}
+
+// AttributeErrorDetail - 필드별 검증 실패 이유
+type AttributeErrorDetail struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// AttributeSchemaResponse - 속성 스키마 응답 DTO
+type AttributeSchemaResponse struct {
+	Namespace string          `json:"namespace"`
+	Schema    json.RawMessage `json:"schema"`
+	Version   int             `json:"version"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+}
+
+func toAttributeSchemaResponse(s *domain.AttributeSchema) AttributeSchemaResponse {
+	return AttributeSchemaResponse{
+		Namespace: s.Namespace,
+		Schema:    json.RawMessage(s.Schema),
+		Version:   s.Version,
+		CreatedAt: s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// attributeFilters - attr.<네임스페이스>.<필드>=값 쿼리 파라미터를 속성 조건으로 변환
+// 같은 키를 여러 번 주면 모두 만족해야 함 (AND)
+func attributeFilters(query url.Values) ([]domain.AttributeFilter, error) {
+	var filters []domain.AttributeFilter
+	for key, values := range query {
+		if !strings.HasPrefix(key, attributeFilterPrefix) {
+			continue
+		}
+		namespace, field, ok := strings.Cut(strings.TrimPrefix(key, attributeFilterPrefix), ".")
+		if !ok {
+			return nil, domain.ErrInvalidFilter
+		}
+		for _, v := range values {
+			f := domain.AttributeFilter{Namespace: namespace, Field: field, Value: v}
+			if err := f.Validate(); err != nil {
+				return nil, err
+			}
+			filters = append(filters, f)
+		}
+	}
+	return filters, nil
+}
+
+// respondAttributeError - 속성 관련 에러면 응답을 쓰고 true 반환
+func respondAttributeError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var ve *usecase.AttributeValidationError
+	switch {
+	case errors.As(err, &ve):
+		details := make([]AttributeErrorDetail, len(ve.Errors))
+		for i, fe := range ve.Errors {
+			details[i] = AttributeErrorDetail{Path: fe.Path, Message: fe.Message}
+		}
+		base := toErrorResponse(r, http.StatusUnprocessableEntity, domain.ErrInvalidAttributes)
+		respondJSON(w, http.StatusUnprocessableEntity, AttributeErrorResponse{
+			Error:     base.Error,
+			Code:      base.Code,
+			Namespace: ve.Namespace,
+			Details:   details,
+		})
+	case errors.Is(err, domain.ErrInvalidAttributes):
+		respondError(w, r, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, domain.ErrUnknownNamespace),
+		errors.Is(err, domain.ErrInvalidNamespace),
+		errors.Is(err, domain.ErrInvalidSchema),
+		errors.Is(err, domain.ErrInvalidFilter):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrSchemaNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	default:
+		return false
+	}
+	return true
+}
+
+// respondUserAttributeError - 사용자 속성 변경 에러를 HTTP 상태로 변환
+func respondUserAttributeError(w http.ResponseWriter, r *http.Request, err error) {
+	if respondAttributeError(w, r, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrTenantRequired):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrCrossTenant):
+		respondError(w, r, http.StatusForbidden, err)
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated),
+		errors.Is(err, domain.ErrUserModified):
+		respondError(w, r, http.StatusConflict, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// SetUserAttributes - 네임스페이스 속성 전체 교체 (PUT /api/v1/users/{id}/attributes/{namespace})
+// 본문은 JSON 객체이며 네임스페이스에 등록된 스키마로 검증 (실패하면 422와 필드별 이유)
+func (h *UserHandler) SetUserAttributes(w http.ResponseWriter, r *http.Request) {
+	var values map[string]any
+	if err := decodeJSON(w, r, &values); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+	if values == nil {
+		respondError(w, r, http.StatusBadRequest, &DecodeError{Err: errInvalidJSONObject, Offset: -1})
+		return
+	}
+
+	user, err := h.userUseCase.SetUserAttributes(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "namespace"), values)
+	if err != nil {
+		respondUserAttributeError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toUserResponse(user))
+}
+
+// RemoveUserAttributes - 네임스페이스 속성 삭제 (DELETE /api/v1/users/{id}/attributes/{namespace})
+func (h *UserHandler) RemoveUserAttributes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userUseCase.RemoveUserAttributes(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "namespace"))
+	if err != nil {
+		respondUserAttributeError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toUserResponse(user))
+}
+
+// AttributeSchemaHandler - 속성 스키마 관리 HTTP 핸들러 (관리자용)
+type AttributeSchemaHandler struct {
+	schemas    *usecase.AttributeSchemaUseCase
+	adminToken string
+}
+
+// NewAttributeSchemaHandler - AttributeSchemaHandler 생성자
+// adminToken이 비어 있으면 모든 요청을 거부
+func NewAttributeSchemaHandler(schemas *usecase.AttributeSchemaUseCase, adminToken string) *AttributeSchemaHandler {
+	return &AttributeSchemaHandler{
+		schemas:    schemas,
+		adminToken: adminToken,
+	}
+}
+
+// RequireAdmin - Authorization: Bearer <관리자 토큰> 확인 미들웨어
+func (h *AttributeSchemaHandler) RequireAdmin(next http.Handler) http.Handler {
+	return requireAdmin(h.adminToken, next)
+}
+
+// ListSchemas - 스키마 목록 (GET /api/v1/admin/attribute-schemas)
+func (h *AttributeSchemaHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas, err := h.schemas.ListSchemas(r.Context())
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	responses := make([]AttributeSchemaResponse, len(schemas))
+	for i, s := range schemas {
+		responses[i] = toAttributeSchemaResponse(s)
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// GetSchema - 스키마 조회 (GET /api/v1/admin/attribute-schemas/{namespace})
+func (h *AttributeSchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	s, err := h.schemas.GetSchema(r.Context(), chi.URLParam(r, "namespace"))
+	if err != nil {
+		if !respondAttributeError(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, toAttributeSchemaResponse(s))
+}
+
+// PutSchema - 스키마 등록/교체 (PUT /api/v1/admin/attribute-schemas/{namespace})
+// 본문은 JSON Schema 문서 그대로이며, 교체할 때마다 버전이 올라감
+func (h *AttributeSchemaHandler) PutSchema(w http.ResponseWriter, r *http.Request) {
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSchemaBytes))
+	if err != nil {
+		respondError(w, r, http.StatusRequestEntityTooLarge, errSchemaTooLarge)
+		return
+	}
+
+	s, err := h.schemas.RegisterSchema(r.Context(), chi.URLParam(r, "namespace"), raw)
+	if err != nil {
+		if !respondAttributeError(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, toAttributeSchemaResponse(s))
+}
+
+// DeleteSchema - 스키마 삭제 (DELETE /api/v1/admin/attribute-schemas/{namespace})
+func (h *AttributeSchemaHandler) DeleteSchema(w http.ResponseWriter, r *http.Request) {
+	if err := h.schemas.DeleteSchema(r.Context(), chi.URLParam(r, "namespace")); err != nil {
+		if !respondAttributeError(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+const adminToken = "s3cret"
+
+func newAttributeRouter(token string) http.Handler {
+	schemas := usecase.NewAttributeSchemaUseCase(memory.NewAttributeSchemaRepository())
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithAttributeSchemas(schemas))
+	return deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+		deliveryhttp.WithAttributeSchemas(deliveryhttp.NewAttributeSchemaHandler(schemas, token)),
+	)
+}
+
+func TestAttributeSchemaAdminAuth(t *testing.T) {
+	schema := `{"type":"object"}`
+	tests := []struct {
+		name   string
+		token  string
+		header map[string]string
+		want   int
+	}{
+		{"no header", adminToken, nil, http.StatusUnauthorized},
+		{"wrong token", adminToken, map[string]string{"Authorization": "Bearer nope"}, http.StatusUnauthorized},
+		{"not bearer", adminToken, map[string]string{"Authorization": adminToken}, http.StatusUnauthorized},
+		{"unconfigured token fails closed", "", map[string]string{"Authorization": "Bearer "}, http.StatusUnauthorized},
+		{"valid", adminToken, map[string]string{"Authorization": "Bearer " + adminToken}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(newAttributeRouter(tt.token), http.MethodPut, "/api/v1/admin/attribute-schemas/hr", schema, tt.header)
+			if rec.Code != tt.want {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestUserAttributes(t *testing.T) {
+	h := newAttributeRouter(adminToken)
+	admin := map[string]string{"Authorization": "Bearer " + adminToken}
+
+	rec := serve(h, http.MethodPut, "/api/v1/admin/attribute-schemas/hr", `{
+		"type": "object",
+		"required": ["department"],
+		"properties": {
+			"department": {"type": "string"},
+			"level": {"type": "integer", "minimum": 1}
+		}
+	}`, admin)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("register schema: got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := serve(h, http.MethodPut, "/api/v1/admin/attribute-schemas/bad", `{"type":"string"}`, admin); rec.Code != http.StatusBadRequest {
+		t.Fatalf("non-object schema: got %d, want 400", rec.Code)
+	}
+
+	// 생성 시 속성 검증 실패 → 422 + 필드별 이유
+	rec = serve(h, http.MethodPost, "/api/v1/users",
+		`{"email":"kim@example.com","name":"Kim","attributes":{"hr":{"level":0}}}`, nil)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid attributes: got %d, want 422: %s", rec.Code, rec.Body)
+	}
+	var verr deliveryhttp.AttributeErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&verr); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if verr.Namespace != "hr" || len(verr.Details) != 2 ||
+		verr.Details[0].Path != "/department" || verr.Details[1].Path != "/level" {
+		t.Fatalf("validation details = %+v", verr)
+	}
+
+	if rec := serve(h, http.MethodPost, "/api/v1/users",
+		`{"email":"kim@example.com","name":"Kim","attributes":{"unknown":{}}}`, nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown namespace: got %d, want 400", rec.Code)
+	}
+
+	rec = serve(h, http.MethodPost, "/api/v1/users",
+		`{"email":"kim@example.com","name":"Kim","attributes":{"hr":{"department":"platform","level":2}}}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", rec.Code, rec.Body)
+	}
+	var kim deliveryhttp.UserResponse
+	if err := json.NewDecoder(rec.Body).Decode(&kim); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if kim.Attributes["hr"]["department"] != "platform" {
+		t.Fatalf("attributes = %v", kim.Attributes)
+	}
+
+	rec = serve(h, http.MethodPost, "/api/v1/users", `{"email":"lee@example.com","name":"Lee"}`, nil)
+	var lee deliveryhttp.UserResponse
+	if err := json.NewDecoder(rec.Body).Decode(&lee); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+lee.ID+"/attributes/hr", `{"department":"sales","level":2}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("set attributes: got %d: %s", rec.Code, rec.Body)
+	}
+
+	list := func(query string) []string {
+		t.Helper()
+		rec := serve(h, http.MethodGet, "/api/v1/users?"+query, "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list %q: got %d: %s", query, rec.Code, rec.Body)
+		}
+		var users []deliveryhttp.UserResponse
+		if err := json.NewDecoder(rec.Body).Decode(&users); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		names := make([]string, len(users))
+		for i, u := range users {
+			names[i] = u.Name
+		}
+		return names
+	}
+	if got := list("attr.hr.department=platform"); len(got) != 1 || got[0] != "Kim" {
+		t.Fatalf("filter by department = %v", got)
+	}
+	if got := list("attr.hr.level=2"); len(got) != 2 {
+		t.Fatalf("filter by level = %v", got)
+	}
+	if got := list("attr.hr.level=2&attr.hr.department=sales"); len(got) != 1 || got[0] != "Lee" {
+		t.Fatalf("AND filter = %v", got)
+	}
+	if rec := serve(h, http.MethodGet, "/api/v1/users?attr.hr=x", "", nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("malformed filter: got %d, want 400", rec.Code)
+	}
+
+	rec = serve(h, http.MethodDelete, "/api/v1/users/"+kim.ID+"/attributes/hr", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("remove attributes: got %d: %s", rec.Code, rec.Body)
+	}
+	if got := list("attr.hr.department=platform"); len(got) != 0 {
+		t.Fatalf("removed attributes still match: %v", got)
+	}
+	if rec := serve(h, http.MethodPut, "/api/v1/users/missing/attributes/hr", `{"department":"x"}`, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("missing user: got %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// avatarFormField - 아바타 업로드 multipart 필드 이름
+const avatarFormField = "avatar"
+
+// multipartOverhead - 파일 외 multipart 경계/헤더에 허용하는 여유
+const multipartOverhead = 64 << 10
+
+// avatarCacheControl - 아바타 응답 캐시 정책 (테넌트 데이터이므로 공유 캐시 금지, 짧게 두고 ETag로 재검증)
+const avatarCacheControl = "private, max-age=300"
+
+// AvatarResponse - 아바타 업로드 응답 DTO
+type AvatarResponse struct {
+	Width     int              `json:"width"`
+	Height    int              `json:"height"`
+	Original  AvatarVariantDTO `json:"original"`
+	Thumbnail AvatarVariantDTO `json:"thumbnail"`
+}
+
+// AvatarVariantDTO - 저장된 아바타 한 종류
+type AvatarVariantDTO struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	ETag        string `json:"etag"`
+}
+
+func toAvatarVariantDTO(url string, info *domain.BlobInfo) AvatarVariantDTO {
+	return AvatarVariantDTO{
+		URL:         url,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		ETag:        info.ETag,
+	}
+}
+
+// respondAvatarError - 아바타/도메인 에러를 HTTP 상태로 변환
+func respondAvatarError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrAvatarUnavailable):
+		respondError(w, r, http.StatusNotImplemented, err)
+	case errors.Is(err, domain.ErrImageTooLarge):
+		respondError(w, r, http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, domain.ErrUnsupportedImage):
+		respondError(w, r, http.StatusUnsupportedMediaType, err)
+	case errors.Is(err, domain.ErrInvalidImage):
+		respondError(w, r, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrAvatarNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidAvatarVariant),
+		errors.Is(err, domain.ErrTenantRequired):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrCrossTenant):
+		respondError(w, r, http.StatusForbidden, err)
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated):
+		respondError(w, r, http.StatusConflict, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// UploadAvatar - 아바타 업로드 (PUT /api/v1/users/{id}/avatar, multipart/form-data의 avatar 필드)
+func (h *UserHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, domain.MaxAvatarBytes+multipartOverhead)
+	data, err := readAvatarPart(r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge), errors.Is(err, domain.ErrImageTooLarge):
+			respondError(w, r, http.StatusRequestEntityTooLarge, domain.ErrImageTooLarge)
+		default:
+			respondError(w, r, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	upload, err := h.userUseCase.SetAvatar(r.Context(), id, data)
+	if err != nil {
+		respondAvatarError(w, r, err)
+		return
+	}
+
+	url := "/api/v1/users/" + id + "/avatar"
+	respondJSON(w, http.StatusOK, AvatarResponse{
+		Width:     upload.Width,
+		Height:    upload.Height,
+		Original:  toAvatarVariantDTO(url, upload.Original),
+		Thumbnail: toAvatarVariantDTO(url+"?variant="+string(domain.AvatarThumbnail), upload.Thumbnail),
+	})
+}
+
+// readAvatarPart - multipart 본문에서 avatar 필드 내용만 읽음 (다른 필드는 건너뜀)
+// 파일 전체를 메모리에 두므로 크기를 domain.MaxAvatarBytes로 제한
+func readAvatarPart(r *http.Request) ([]byte, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, errMultipartRequired
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, errAvatarFieldMissing
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != avatarFormField {
+			part.Close()
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, domain.MaxAvatarBytes+1))
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > domain.MaxAvatarBytes {
+			return nil, domain.ErrImageTooLarge
+		}
+		return data, nil
+	}
+}
+
+// GetAvatar - 아바타 이미지 (GET /api/v1/users/{id}/avatar?variant=original|thumbnail)
+// ETag/Last-Modified로 조건부 요청에 304, 저장소가 Seek를 지원하면 Range 요청도 처리
+func (h *UserHandler) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	variant, err := domain.ParseAvatarVariant(r.URL.Query().Get("variant"))
+	if err != nil {
+		respondAvatarError(w, r, err)
+		return
+	}
+
+	rc, info, err := h.userUseCase.GetAvatar(r.Context(), chi.URLParam(r, "id"), variant)
+	if err != nil {
+		respondAvatarError(w, r, err)
+		return
+	}
+	defer rc.Close()
+
+	header := w.Header()
+	header.Set("Content-Type", info.ContentType)
+	header.Set("ETag", info.ETag)
+	header.Set("Cache-Control", avatarCacheControl)
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "default-src 'none'")
+
+	if rs, ok := rc.(io.ReadSeeker); ok {
+		// ETag 헤더를 보고 If-None-Match/If-Range도 처리
+		http.ServeContent(w, r, "", info.ModTime, rs)
+		return
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" && etagMatch(inm, info.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	header.Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	header.Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		io.Copy(w, rc)
+	}
+}
+
+// DeleteAvatar - 아바타 삭제 (DELETE /api/v1/users/{id}/avatar)
+func (h *UserHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	if err := h.userUseCase.DeleteAvatar(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondAvatarError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,192 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func newAvatarRouter(t *testing.T) (http.Handler, *file.BlobStore) {
+	t.Helper()
+	blobs, err := file.NewBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewBlobStore: %v", err)
+	}
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithBlobStore(blobs))
+	return deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+	), blobs
+}
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func uploadAvatar(h http.Handler, userID, field string, data []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile(field, "avatar.png")
+	fw.Write(data)
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/"+userID+"/avatar", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func createUser(t *testing.T, h http.Handler) string {
+	t.Helper()
+	rec := serve(h, http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", rec.Code, rec.Body)
+	}
+	var user deliveryhttp.UserResponse
+	if err := json.NewDecoder(rec.Body).Decode(&user); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return user.ID
+}
+
+func TestAvatarUploadAndServe(t *testing.T) {
+	h, _ := newAvatarRouter(t)
+	id := createUser(t, h)
+
+	if rec := serve(h, http.MethodGet, "/api/v1/users/"+id+"/avatar", "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("avatar before upload: got %d, want 404", rec.Code)
+	}
+
+	original := pngImage(t, 300, 200)
+	rec := uploadAvatar(h, id, "avatar", original)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("upload: got %d: %s", rec.Code, rec.Body)
+	}
+	var resp deliveryhttp.AvatarResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Width != 300 || resp.Height != 200 || resp.Original.ContentType != "image/png" ||
+		resp.Original.Size != int64(len(original)) {
+		t.Fatalf("upload response = %+v", resp)
+	}
+
+	// 원본은 받은 그대로
+	rec = serve(h, http.MethodGet, resp.Original.URL, "", nil)
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), original) {
+		t.Fatalf("original: got %d, %d bytes", rec.Code, rec.Body.Len())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "image/png" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if got := rec.Header().Get("ETag"); got != resp.Original.ETag {
+		t.Fatalf("ETag = %q, want %q", got, resp.Original.ETag)
+	}
+	if rec.Header().Get("Cache-Control") == "" || rec.Header().Get("Last-Modified") == "" {
+		t.Fatalf("caching headers missing: %v", rec.Header())
+	}
+
+	// 조건부 요청
+	rec = serve(h, http.MethodGet, resp.Original.URL, "", map[string]string{"If-None-Match": resp.Original.ETag})
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("conditional GET: got %d, want 304", rec.Code)
+	}
+
+	// 썸네일은 정사각형
+	rec = serve(h, http.MethodGet, resp.Thumbnail.URL, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("thumbnail: got %d", rec.Code)
+	}
+	thumb, _, err := image.DecodeConfig(rec.Body)
+	if err != nil || thumb.Width != 128 || thumb.Height != 128 {
+		t.Fatalf("thumbnail = %+v, %v", thumb, err)
+	}
+
+	if rec := serve(h, http.MethodGet, resp.Original.URL+"?variant=huge", "", nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown variant: got %d, want 400", rec.Code)
+	}
+}
+
+func TestAvatarUploadRejects(t *testing.T) {
+	h, _ := newAvatarRouter(t)
+	id := createUser(t, h)
+
+	tests := []struct {
+		name  string
+		field string
+		data  []byte
+		want  int
+	}{
+		{"not an image", "avatar", []byte("<html><script>alert(1)</script></html>"), http.StatusUnsupportedMediaType},
+		{"truncated png", "avatar", pngImage(t, 64, 64)[:40], http.StatusUnprocessableEntity},
+		{"too small", "avatar", pngImage(t, 16, 16), http.StatusUnprocessableEntity},
+		{"too large", "avatar", append(pngImage(t, 64, 64), make([]byte, 5<<20)...), http.StatusRequestEntityTooLarge},
+		{"wrong field", "file", pngImage(t, 64, 64), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := uploadAvatar(h, id, tt.field, tt.data); rec.Code != tt.want {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+id+"/avatar", `{}`, nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("non-multipart: got %d, want 400", rec.Code)
+	}
+	if rec := uploadAvatar(h, "missing", "avatar", pngImage(t, 64, 64)); rec.Code != http.StatusNotFound {
+		t.Fatalf("missing user: got %d, want 404", rec.Code)
+	}
+}
+
+func TestAvatarRemovedWithUser(t *testing.T) {
+	h, blobs := newAvatarRouter(t)
+	id := createUser(t, h)
+	if rec := uploadAvatar(h, id, "avatar", pngImage(t, 64, 64)); rec.Code != http.StatusOK {
+		t.Fatalf("upload: got %d: %s", rec.Code, rec.Body)
+	}
+
+	if rec := serve(h, http.MethodDelete, "/api/v1/users/"+id, "", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete user: got %d", rec.Code)
+	}
+
+	for _, variant := range []domain.AvatarVariant{domain.AvatarOriginal, domain.AvatarThumbnail} {
+		if _, _, err := blobs.Get(context.Background(), domain.AvatarKey("acme", id, variant)); !errors.Is(err, domain.ErrBlobNotFound) {
+			t.Fatalf("%s blob after user delete: %v", variant, err)
+		}
+	}
+}
+
+func TestAvatarUnavailable(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{Default: "acme"})
+	id := createUser(t, h)
+	if rec := uploadAvatar(h, id, "avatar", pngImage(t, 64, 64)); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("upload without blob store: got %d, want 501", rec.Code)
+	}
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// BatchCreateRequest - 일괄 생성 요청 DTO
+// atomic이면 하나라도 실패할 때 아무것도 만들지 않음 (저장소가 트랜잭션을 지원해야 함)
+type BatchCreateRequest struct {
+	Items  []CreateUserRequest `json:"items"`
+	Atomic bool                `json:"atomic,omitempty"`
+}
+
+// BatchIDsRequest - 일괄 조회/삭제 요청 DTO (atomic은 삭제에만 적용)
+type BatchIDsRequest struct {
+	IDs    []string `json:"ids"`
+	Atomic bool     `json:"atomic,omitempty"`
+}
+
+// BatchItemResponse - 항목별 결과 DTO
+// Status는 같은 요청을 단건 API로 보냈을 때의 HTTP 상태 (201, 200, 204, 404, 409 등)
+type BatchItemResponse struct {
+	Index  int            `json:"index"`
+	Status int            `json:"status"`
+	User   *UserResponse  `json:"user,omitempty"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
+
+// BatchResponse - 일괄 처리 응답 DTO (항목은 요청 순서)
+type BatchResponse struct {
+	Results   []BatchItemResponse `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+// BatchCreateUsers - 사용자 일괄 생성 핸들러 (POST /api/v1/users:batchCreate)
+// 항목별 결과를 담아 200으로 응답하고, 요청 자체가 잘못됐을 때만 4xx/5xx
+func (h *UserHandler) BatchCreateUsers(w http.ResponseWriter, r *http.Request) {
+	var req BatchCreateRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	items := make([]usecase.BatchCreateItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.BatchCreateItem{
+			Email:      item.Email,
+			Name:       item.Name,
+			Attributes: item.Attributes,
+			Status:     domain.UserStatus(item.Status),
+		}
+	}
+
+	results, err := h.userUseCase.BatchCreateUsers(r.Context(), items, req.Atomic)
+	if err != nil {
+		respondBatchError(w, r, err)
+		return
+	}
+	respondBatch(w, r, results, http.StatusCreated)
+}
+
+// BatchGetUsers - 사용자 일괄 조회 핸들러 (POST /api/v1/users:batchGet)
+func (h *UserHandler) BatchGetUsers(w http.ResponseWriter, r *http.Request) {
+	var req BatchIDsRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	results, err := h.userUseCase.BatchGetUsers(r.Context(), req.IDs)
+	if err != nil {
+		respondBatchError(w, r, err)
+		return
+	}
+	respondBatch(w, r, results, http.StatusOK)
+}
+
+// BatchDeleteUsers - 사용자 일괄 삭제 핸들러 (POST /api/v1/users:batchDelete)
+// 성공한 항목은 204와 삭제 직전 사용자를 담지 않고 상태만 알림
+func (h *UserHandler) BatchDeleteUsers(w http.ResponseWriter, r *http.Request) {
+	var req BatchIDsRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	results, err := h.userUseCase.BatchDeleteUsers(r.Context(), req.IDs, req.Atomic)
+	if err != nil {
+		respondBatchError(w, r, err)
+		return
+	}
+	for i := range results {
+		results[i].User = nil
+	}
+	respondBatch(w, r, results, http.StatusNoContent)
+}
+
+// respondBatch - 항목별 결과 응답 (성공한 항목의 상태는 okStatus)
+func respondBatch(w http.ResponseWriter, r *http.Request, results []usecase.BatchResult, okStatus int) {
+	resp := BatchResponse{Results: make([]BatchItemResponse, len(results))}
+	for i, res := range results {
+		item := BatchItemResponse{Index: res.Index}
+		if res.Err != nil {
+			item.Status = batchItemStatus(res.Err)
+			errResp := toErrorResponse(r, item.Status, res.Err)
+			item.Error = &errResp
+			resp.Failed++
+		} else {
+			item.Status = okStatus
+			if res.User != nil {
+				user := toUserResponse(res.User)
+				item.User = &user
+			}
+			resp.Succeeded++
+		}
+		resp.Results[i] = item
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// respondBatchError - 요청 전체가 처리되지 않은 에러 응답
+func respondBatchError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidBatchSize), errors.Is(err, domain.ErrTenantRequired):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, usecase.ErrTransactionsUnsupported):
+		respondError(w, r, http.StatusNotImplemented, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// batchItemStatus - 항목 에러를 단건 API와 같은 HTTP 상태로 변환
+func batchItemStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUserExists), errors.Is(err, usecase.ErrDuplicateBatchItem),
+		errors.Is(err, usecase.ErrBatchAborted):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrCrossTenant):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrInvalidAttributes):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrInvalidEmail), errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidUserID), errors.Is(err, domain.ErrInvalidUserStatus),
+		errors.Is(err, domain.ErrUnknownNamespace), errors.Is(err, domain.ErrInvalidNamespace):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSchemaNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,176 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// plainRepository - 트랜잭션을 지원하지 않는 저장소 (UserRepository 메서드만 노출)
+type plainRepository struct {
+	usecase.UserRepository
+}
+
+func newBatchRouter(repo usecase.UserRepository) http.Handler {
+	handler := deliveryhttp.NewUserHandler(usecase.NewUserUseCase(repo, usecase.WithBatchConcurrency(2)))
+	return deliveryhttp.NewRouter(handler,
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})))
+}
+
+func batch(t *testing.T, h http.Handler, target, body string) deliveryhttp.BatchResponse {
+	t.Helper()
+	rec := serve(h, http.MethodPost, target, body, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: got %d: %s", target, rec.Code, rec.Body)
+	}
+	var resp deliveryhttp.BatchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return resp
+}
+
+// assertItems - 항목별 상태와 에러 코드 ("" 이면 성공)
+func assertItems(t *testing.T, resp deliveryhttp.BatchResponse, statuses []int, codes []string) {
+	t.Helper()
+	if len(resp.Results) != len(statuses) {
+		t.Fatalf("results = %+v", resp.Results)
+	}
+	for i, res := range resp.Results {
+		code := ""
+		if res.Error != nil {
+			code = res.Error.Code
+		}
+		if res.Index != i || res.Status != statuses[i] || code != codes[i] {
+			t.Errorf("item %d: index %d, status %d, code %q; want status %d, code %q", i, res.Index, res.Status, code, statuses[i], codes[i])
+		}
+	}
+}
+
+func TestBatchPartialFailure(t *testing.T) {
+	h := newBatchRouter(memory.NewUserRepository())
+	kim := createUser(t, h)
+
+	created := batch(t, h, "/api/v1/users:batchCreate", `{"items":[
+		{"email":"lee@example.com","name":"Lee"},
+		{"email":"kim@example.com","name":"Kim"},
+		{"email":"","name":"Park"},
+		{"email":"lee@example.com","name":"Lee again"},
+		{"email":"choi@example.com","name":"Choi","status":"pending"}
+	]}`)
+	assertItems(t, created,
+		[]int{201, 409, 400, 409, 201},
+		[]string{"", "user_exists", "invalid_email", "duplicate_batch_item", ""})
+	if created.Succeeded != 2 || created.Failed != 3 {
+		t.Fatalf("summary: %d succeeded, %d failed", created.Succeeded, created.Failed)
+	}
+	if u := created.Results[4].User; u == nil || u.Status != "pending" {
+		t.Fatalf("pending user = %+v", u)
+	}
+	lee := created.Results[0].User.ID
+
+	got := batch(t, h, "/api/v1/users:batchGet", fmt.Sprintf(`{"ids":[%q,"missing",%q]}`, kim, lee))
+	assertItems(t, got, []int{200, 404, 200}, []string{"", "user_not_found", ""})
+	if got.Results[2].User.Email != "lee@example.com" {
+		t.Fatalf("batchGet user = %+v", got.Results[2].User)
+	}
+
+	deleted := batch(t, h, "/api/v1/users:batchDelete", fmt.Sprintf(`{"ids":[%q,"missing",%q]}`, kim, kim))
+	assertItems(t, deleted, []int{204, 404, 409}, []string{"", "user_not_found", "duplicate_batch_item"})
+	if rec := serve(h, http.MethodGet, "/api/v1/users/"+kim, "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("get deleted user: got %d", rec.Code)
+	}
+}
+
+// TestBatchGetPaths - 한 번에 조회하는 저장소(UserBatchGetter)와 ID마다 조회하는 저장소의 결과가 같음
+func TestBatchGetPaths(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		repo usecase.UserRepository
+	}{
+		{"batch getter", memory.NewUserRepository()},
+		{"per id", plainRepository{memory.NewUserRepository()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newBatchRouter(tt.repo)
+			kim := createUser(t, h)
+
+			got := batch(t, h, "/api/v1/users:batchGet", fmt.Sprintf(`{"ids":["missing",%q,"",%q]}`, kim, kim))
+			assertItems(t, got, []int{404, 200, 400, 200}, []string{"user_not_found", "", "invalid_user_id", ""})
+			for _, i := range []int{1, 3} {
+				if u := got.Results[i].User; u == nil || u.ID != kim {
+					t.Fatalf("results[%d].user = %+v", i, u)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchAtomic(t *testing.T) {
+	h := newBatchRouter(memory.NewUserRepository())
+	kim := createUser(t, h)
+
+	// 하나라도 실패하면 아무것도 만들지 않음
+	aborted := batch(t, h, "/api/v1/users:batchCreate", `{"atomic":true,"items":[
+		{"email":"lee@example.com","name":"Lee"},
+		{"email":"","name":"Park"},
+		{"email":"choi@example.com","name":"Choi"}
+	]}`)
+	assertItems(t, aborted, []int{409, 400, 409}, []string{"batch_aborted", "invalid_email", "batch_aborted"})
+	if rec := serve(h, http.MethodGet, "/api/v1/users", "", nil); !strings.Contains(rec.Body.String(), kim) || strings.Contains(rec.Body.String(), "lee@example.com") {
+		t.Fatalf("users after aborted batch: %s", rec.Body)
+	}
+
+	deleted := batch(t, h, "/api/v1/users:batchDelete", fmt.Sprintf(`{"atomic":true,"ids":[%q,"missing"]}`, kim))
+	assertItems(t, deleted, []int{409, 404}, []string{"batch_aborted", "user_not_found"})
+	if rec := serve(h, http.MethodGet, "/api/v1/users/"+kim, "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("user deleted by aborted batch: got %d", rec.Code)
+	}
+
+	created := batch(t, h, "/api/v1/users:batchCreate", `{"atomic":true,"items":[
+		{"email":"lee@example.com","name":"Lee"},
+		{"email":"choi@example.com","name":"Choi"}
+	]}`)
+	assertItems(t, created, []int{201, 201}, []string{"", ""})
+}
+
+func TestBatchRequestErrors(t *testing.T) {
+	h := newBatchRouter(memory.NewUserRepository())
+
+	ids := make([]string, usecase.MaxBatchSize+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%q", fmt.Sprint("id-", i))
+	}
+	tests := []struct {
+		name, target, body string
+		status             int
+		code               string
+	}{
+		{"empty", "/api/v1/users:batchCreate", `{"items":[]}`, http.StatusBadRequest, "invalid_batch_size"},
+		{"too many", "/api/v1/users:batchGet", `{"ids":[` + strings.Join(ids, ",") + `]}`, http.StatusBadRequest, "invalid_batch_size"},
+		{"unknown field", "/api/v1/users:batchDelete", `{"ids":["a"],"force":true}`, http.StatusBadRequest, "unknown_field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, http.MethodPost, tt.target, tt.body, nil)
+			if rec.Code != tt.status || !strings.Contains(rec.Body.String(), `"code":"`+tt.code+`"`) {
+				t.Fatalf("got %d: %s; want %d %s", rec.Code, rec.Body, tt.status, tt.code)
+			}
+		})
+	}
+
+	// 트랜잭션을 지원하지 않는 저장소에서는 원자적 처리를 거절 (부분 처리는 가능)
+	plain := newBatchRouter(plainRepository{memory.NewUserRepository()})
+	body := `{"items":[{"email":"lee@example.com","name":"Lee"}]}`
+	rec := serve(plain, http.MethodPost, "/api/v1/users:batchCreate", `{"atomic":true,"items":[{"email":"lee@example.com","name":"Lee"}]}`, nil)
+	if rec.Code != http.StatusNotImplemented || !strings.Contains(rec.Body.String(), "transactions_unsupported") {
+		t.Fatalf("atomic without transactions: got %d: %s", rec.Code, rec.Body)
+	}
+	assertItems(t, batch(t, plain, "/api/v1/users:batchCreate", body), []int{201}, []string{""})
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/milman2/go-api/clean-architecture/internal/delivery/bulk"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// flushEvery - 스트리밍 응답을 내보내는 주기 (행 수)
+const flushEvery = 100
+
+// ImportRowResult - 가져오기 행별 결과 DTO
+type ImportRowResult struct {
+	Line   int    `json:"line"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportSummaryResponse - 가져오기 요약 DTO (마지막 줄)
+type ImportSummaryResponse struct {
+	Summary struct {
+		Total     int  `json:"total"`
+		Succeeded int  `json:"succeeded"`
+		Failed    int  `json:"failed"`
+		DryRun    bool `json:"dry_run"`
+	} `json:"summary"`
+}
+
+// flush - 지금까지 쓴 응답을 클라이언트로 전송 (지원하지 않는 writer는 무시)
+func flush(rc *http.ResponseController) error {
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// importFormat - Content-Type으로 입력 형식 판단
+func importFormat(r *http.Request) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case "text/csv":
+		return bulk.FormatCSV, true
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return bulk.FormatNDJSON, true
+	default:
+		return "", false
+	}
+}
+
+// ImportUsers - 사용자 일괄 가져오기 핸들러 (POST /api/v1/users:import)
+// CSV 또는 NDJSON 요청 본문을 스트리밍으로 처리하고,
+// 행별 결과를 NDJSON으로 내보낸 뒤 마지막 줄에 요약을 기록
+func (h *UserHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	format, ok := importFormat(r)
+	if !ok {
+		respondError(w, r, http.StatusUnsupportedMediaType, errUnsupportedContentType)
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, errInvalidDryRun)
+			return
+		}
+		dryRun = parsed
+	}
+
+	reader, err := bulk.NewReader(format, r.Body)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// 헤더를 보낸 뒤에는 상태 코드를 바꿀 수 없으므로 테넌트를 먼저 확인
+	if _, ok := tenant.FromContext(r.Context()); !ok {
+		respondError(w, r, http.StatusBadRequest, domain.ErrTenantRequired)
+		return
+	}
+
+	// 요청 본문을 읽는 동안 응답을 쓰기 위해 HTTP/1.x 전이중 모드 사용
+	rc := http.NewResponseController(w)
+	_ = rc.EnableFullDuplex()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+
+	rows := 0
+	summary, err := h.userUseCase.ImportUsers(r.Context(), reader, dryRun, func(res usecase.ImportResult) error {
+		if err := enc.Encode(toImportRowResult(res, dryRun)); err != nil {
+			return err
+		}
+		rows++
+		if rows%flushEvery == 0 {
+			return flush(rc)
+		}
+		return nil
+	})
+	if err != nil {
+		// 헤더를 이미 보냈으므로 에러를 마지막 줄로 기록
+		log.Printf("사용자 가져오기 중단: %v", err)
+		enc.Encode(ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var resp ImportSummaryResponse
+	resp.Summary.Total = summary.Total
+	resp.Summary.Succeeded = summary.Succeeded
+	resp.Summary.Failed = summary.Failed
+	resp.Summary.DryRun = summary.DryRun
+	enc.Encode(resp)
+}
+
+// toImportRowResult - 행별 결과를 DTO로 변환
+func toImportRowResult(res usecase.ImportResult, dryRun bool) ImportRowResult {
+	out := ImportRowResult{Line: res.Line, Email: res.Email}
+	switch {
+	case res.Err != nil:
+		out.Status = "failed"
+		out.Error = res.Err.Error()
+	case dryRun:
+		out.Status = "valid"
+	default:
+		out.Status = "created"
+		out.ID = res.User.ID
+	}
+	return out
+}
+
+// ExportUsers - 사용자 내보내기 핸들러 (GET /api/v1/users:export?format=csv|ndjson)
+// 전체 목록을 메모리에 올리지 않고 한 명씩 스트리밍
+func (h *UserHandler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = bulk.FormatNDJSON
+	}
+
+	writer, err := bulk.NewWriter(format, w)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, ok := tenant.FromContext(r.Context()); !ok {
+		respondError(w, r, http.StatusBadRequest, domain.ErrTenantRequired)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", bulk.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="users.`+format+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	rows := 0
+	err = h.userUseCase.ExportUsers(r.Context(), func(user *domain.User) error {
+		if err := writer.Write(user); err != nil {
+			return err
+		}
+		rows++
+		if rows%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			return flush(rc)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("사용자 내보내기 중단: %v", err)
+		return
+	}
+
+	writer.Flush()
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// userETag - 사용자 표현의 ETag (ID + 수정 시각)
+func userETag(user *domain.User) string {
+	sum := sha256.Sum256([]byte(user.ID + "|" + strconv.FormatInt(user.UpdatedAt.UnixNano(), 10)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// setValidators - ETag / Last-Modified 헤더 설정
+func setValidators(w http.ResponseWriter, user *domain.User) {
+	w.Header().Set("ETag", userETag(user))
+	w.Header().Set("Last-Modified", user.UpdatedAt.UTC().Format(http.TimeFormat))
+}
+
+// notModified - 조건부 요청 검사 (RFC 9110 13.2.2)
+// If-None-Match가 있으면 If-Modified-Since는 무시
+func notModified(r *http.Request, user *domain.User) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatch(inm, userETag(user))
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		// Last-Modified는 초 단위이므로 잘라서 비교
+		return !user.UpdatedAt.Truncate(time.Second).After(t)
+	}
+
+	return false
+}
+
+// withIfMatch - If-Match가 있으면 수정/삭제 직전에 읽은 사용자의 ETag와 비교하도록 컨텍스트에 조건 추가 (RFC 9110 13.1.1)
+// 맞지 않으면 usecase가 domain.ErrPreconditionFailed를 반환하고 아무것도 바꾸지 않음
+func withIfMatch(r *http.Request) context.Context {
+	im := r.Header.Get("If-Match")
+	if im == "" {
+		return r.Context()
+	}
+	return usecase.WithPrecondition(r.Context(), func(user *domain.User) error {
+		if !ifMatch(im, userETag(user)) {
+			return domain.ErrPreconditionFailed
+		}
+		return nil
+	})
+}
+
+// ifMatch - If-Match 목록과 ETag 강한 비교 (약한 ETag는 일치하지 않음)
+func ifMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagMatch - If-None-Match 목록과 ETag 약한 비교
+func etagMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
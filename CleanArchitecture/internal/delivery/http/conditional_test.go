@@ -0,0 +1,147 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// getValidators - 사용자를 조회해 ETag, Last-Modified 반환
+func getValidators(t *testing.T, h http.Handler, id string) (etag, lastModified string) {
+	t.Helper()
+	rec := serve(h, http.MethodGet, "/api/v1/users/"+id, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("get: got %d: %s", rec.Code, rec.Body)
+	}
+	etag, lastModified = rec.Header().Get("ETag"), rec.Header().Get("Last-Modified")
+	if etag == "" || lastModified == "" {
+		t.Fatalf("missing validators: ETag %q, Last-Modified %q", etag, lastModified)
+	}
+	return etag, lastModified
+}
+
+func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("got %d, want %d: %s", rec.Code, status, rec.Body)
+	}
+	var resp deliveryhttp.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Code != code {
+		t.Fatalf("code = %q, want %q", resp.Code, code)
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	h := newBatchRouter(memory.NewUserRepository())
+	id := createUser(t, h)
+	etag, lastModified := getValidators(t, h, id)
+	modified, _ := http.ParseTime(lastModified)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"no condition", nil, http.StatusOK},
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"weak etag", map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{"etag in list", map[string]string{"If-None-Match": `"other", ` + etag}, http.StatusNotModified},
+		{"wildcard", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"other etag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified},
+		{"later date", map[string]string{"If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, http.StatusOK},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		// If-None-Match가 있으면 If-Modified-Since는 무시
+		{"etag wins over date", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, http.MethodGet, "/api/v1/users/"+id, "", tt.header)
+			if rec.Code != tt.want {
+				t.Fatalf("got %d, want %d", rec.Code, tt.want)
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Fatalf("ETag = %q, want %q", got, etag)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Fatalf("304 with body: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestETagChangesOnUpdate(t *testing.T) {
+	h := newBatchRouter(memory.NewUserRepository())
+	id := createUser(t, h)
+	before, _ := getValidators(t, h, id)
+
+	rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update: got %d: %s", rec.Code, rec.Body)
+	}
+	after, _ := getValidators(t, h, id)
+	if after == before {
+		t.Fatalf("ETag did not change after update: %s", after)
+	}
+	if got := rec.Header().Get("ETag"); got != after {
+		t.Fatalf("update ETag = %q, want %q", got, after)
+	}
+	if rec := serve(h, http.MethodGet, "/api/v1/users/"+id, "", map[string]string{"If-None-Match": before}); rec.Code != http.StatusOK {
+		t.Fatalf("old ETag: got %d, want 200", rec.Code)
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	repos := map[string]func() usecase.UserRepository{
+		"transactional":     func() usecase.UserRepository { return memory.NewUserRepository() },
+		"non-transactional": func() usecase.UserRepository { return plainRepository{memory.NewUserRepository()} },
+	}
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			h := newBatchRouter(newRepo())
+			id := createUser(t, h)
+			stale, _ := getValidators(t, h, id)
+			if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, map[string]string{"If-Match": stale}); rec.Code != http.StatusOK {
+				t.Fatalf("update with current ETag: got %d: %s", rec.Code, rec.Body)
+			}
+			current, _ := getValidators(t, h, id)
+
+			// 조회한 뒤 바뀌었으면 412, 아무것도 바꾸지 않음
+			rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Park"}`, map[string]string{"If-Match": stale})
+			assertErrorCode(t, rec, http.StatusPreconditionFailed, "precondition_failed")
+			// If-Match는 강한 비교이므로 약한 ETag는 맞지 않음
+			rec = serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Park"}`, map[string]string{"If-Match": "W/" + current})
+			assertErrorCode(t, rec, http.StatusPreconditionFailed, "precondition_failed")
+			rec = serve(h, http.MethodDelete, "/api/v1/users/"+id, "", map[string]string{"If-Match": stale})
+			assertErrorCode(t, rec, http.StatusPreconditionFailed, "precondition_failed")
+			if got, _ := getValidators(t, h, id); got != current {
+				t.Fatalf("rejected requests changed the user: ETag %q, want %q", got, current)
+			}
+
+			rec = serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Park"}`, map[string]string{"If-Match": `"other", ` + current})
+			if rec.Code != http.StatusOK || decodeUser(t, rec.Body.Bytes()).Name != "Park" {
+				t.Fatalf("update with ETag in list: got %d: %s", rec.Code, rec.Body)
+			}
+			if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Choi"}`, map[string]string{"If-Match": "*"}); rec.Code != http.StatusOK {
+				t.Fatalf("update with wildcard: got %d: %s", rec.Code, rec.Body)
+			}
+
+			current, _ = getValidators(t, h, id)
+			if rec := serve(h, http.MethodDelete, "/api/v1/users/"+id, "", map[string]string{"If-Match": current}); rec.Code != http.StatusNoContent {
+				t.Fatalf("delete with current ETag: got %d: %s", rec.Code, rec.Body)
+			}
+			rec = serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Kang"}`, map[string]string{"If-Match": "*"})
+			assertErrorCode(t, rec, http.StatusNotFound, "user_not_found")
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// maxJSONBodyBytes - JSON 요청 본문 최대 크기
+const maxJSONBodyBytes = 1 << 20
+
+// JSON 본문 디코딩 에러
+var (
+	errJSONRequired      = domain.NewError("json_required", "content type must be application/json")
+	errEmptyBody         = domain.NewError("empty_body", "request body is empty")
+	errMalformedJSON     = domain.NewError("malformed_json", "malformed JSON")
+	errInvalidFieldType  = domain.NewError("invalid_field_type", "invalid value type")
+	errUnknownField      = domain.NewError("unknown_field", "unknown field")
+	errTrailingJSON      = domain.NewError("trailing_json", "request body must contain a single JSON value")
+	errInvalidJSONObject = domain.NewError("invalid_json_object", "request body must be a JSON object")
+)
+
+// DecodeError - 본문 디코딩 실패 위치 (필드 경로 또는 바이트 오프셋)
+type DecodeError struct {
+	Err    error  // 코드가 있는 에러 (errMalformedJSON 등)
+	Field  string // 문제가 된 필드 경로 (예: "name", "attributes.hr"), 모르면 빈 문자열
+	Offset int64  // 본문 기준 바이트 오프셋, 모르면 -1
+}
+
+func (e *DecodeError) Error() string {
+	msg := e.Err.Error()
+	if e.Field != "" {
+		msg += fmt.Sprintf(" (field %q)", e.Field)
+	}
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeJSON - JSON 요청 본문을 dst로 디코딩
+// Content-Type은 application/json이어야 하고, 본문은 maxJSONBodyBytes 이하의 JSON 값 하나여야 함
+// 구조체에 없는 필드, 타입이 맞지 않는 값, 뒤에 붙은 데이터는 위치와 함께 거부
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return errJSONRequired
+	}
+
+	body := &countingReader{r: http.MaxBytesReader(w, r.Body, maxJSONBodyBytes)}
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return toDecodeError(dec, body.n, err)
+	}
+
+	// 값 하나 뒤에는 공백만 허용
+	end := dec.InputOffset()
+	var extra json.RawMessage
+	if err := dec.Decode(&extra); err != io.EOF {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errBodyTooLarge
+		}
+		return &DecodeError{Err: errTrailingJSON, Offset: end}
+	}
+	return nil
+}
+
+// toDecodeError - encoding/json 에러를 위치가 있는 DecodeError로 변환
+// read는 지금까지 읽은 본문 길이 (값이 끝나기 전에 본문이 끝나면 그 위치를 오프셋으로 씀)
+func toDecodeError(dec *json.Decoder, read int64, err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		tooLarge  *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &tooLarge):
+		return errBodyTooLarge
+	case errors.Is(err, io.EOF):
+		return errEmptyBody
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &DecodeError{Err: errMalformedJSON, Offset: read}
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{Err: errMalformedJSON, Offset: syntaxErr.Offset}
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return &DecodeError{Err: errInvalidJSONObject, Offset: typeErr.Offset}
+		}
+		return &DecodeError{Err: errInvalidFieldType, Field: typeErr.Field, Offset: typeErr.Offset}
+	}
+
+	// DisallowUnknownFields 에러는 타입이 없어 메시지로 구분 (json: unknown field "x")
+	if field, ok := strings.CutPrefix(err.Error(), `json: unknown field "`); ok {
+		return &DecodeError{Err: errUnknownField, Field: strings.TrimSuffix(field, `"`), Offset: dec.InputOffset()}
+	}
+	return &DecodeError{Err: errMalformedJSON, Offset: dec.InputOffset()}
+}
+
+// countingReader - 읽은 바이트 수를 세는 Reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// respondDecodeError - 디코딩 에러를 HTTP 상태로 변환
+func respondDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errJSONRequired):
+		respondError(w, r, http.StatusUnsupportedMediaType, err)
+	case errors.Is(err, errBodyTooLarge):
+		respondError(w, r, http.StatusRequestEntityTooLarge, err)
+	default:
+		respondError(w, r, http.StatusBadRequest, err)
+	}
+}
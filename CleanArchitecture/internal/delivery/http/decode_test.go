@@ -0,0 +1,88 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+)
+
+func TestCreateUserDecoding(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{Default: "acme"})
+
+	offset := func(n int64) *int64 { return &n }
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        string
+		field       string
+		offset      *int64
+	}{
+		{"valid", "application/json", `{"email":"kim@example.com","name":"Kim"}`, http.StatusCreated, "", "", nil},
+		{"charset parameter", "application/json; charset=utf-8", `{"email":"lee@example.com","name":"Lee"}`, http.StatusCreated, "", "", nil},
+		{"trailing whitespace", "application/json", "{\"email\":\"park@example.com\",\"name\":\"Park\"}\n\t ", http.StatusCreated, "", "", nil},
+		{"missing content type", "", `{"email":"a@example.com","name":"A"}`, http.StatusUnsupportedMediaType, "json_required", "", nil},
+		{"wrong content type", "text/plain", `{"email":"a@example.com","name":"A"}`, http.StatusUnsupportedMediaType, "json_required", "", nil},
+		{"empty body", "application/json", ``, http.StatusBadRequest, "empty_body", "", nil},
+		{"syntax error", "application/json", `{"email":"a@example.com",,}`, http.StatusBadRequest, "malformed_json", "", offset(26)},
+		{"truncated", "application/json", `{"email":"a@exa`, http.StatusBadRequest, "malformed_json", "", offset(15)},
+		{"wrong type", "application/json", `{"email":"a@example.com","name":42}`, http.StatusBadRequest, "invalid_field_type", "name", offset(34)},
+		{"nested wrong type", "application/json", `{"email":"a@example.com","name":"A","attributes":{"hr":"x"}}`, http.StatusBadRequest, "invalid_field_type", "attributes.hr", offset(58)},
+		{"unknown field", "application/json", `{"email":"a@example.com","name":"A","admin":true}`, http.StatusBadRequest, "unknown_field", "admin", nil},
+		{"not an object", "application/json", `["a@example.com"]`, http.StatusBadRequest, "invalid_json_object", "", offset(1)},
+		{"multiple values", "application/json", `{"email":"a@example.com","name":"A"}{"email":"b@example.com","name":"B"}`, http.StatusBadRequest, "trailing_json", "", offset(36)},
+		{"trailing garbage", "application/json", `{"email":"a@example.com","name":"A"} garbage`, http.StatusBadRequest, "trailing_json", "", offset(36)},
+		{"too large", "application/json", `{"email":"a@example.com","name":"` + strings.Repeat("x", 1<<20) + `"}`, http.StatusRequestEntityTooLarge, "request_too_large", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.code == "" {
+				return
+			}
+			var resp deliveryhttp.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Code != tt.code || resp.Field != tt.field {
+				t.Fatalf("got code=%q field=%q, want code=%q field=%q", resp.Code, resp.Field, tt.code, tt.field)
+			}
+			if tt.offset != nil && (resp.Offset == nil || *resp.Offset != *tt.offset) {
+				t.Fatalf("got offset %v, want %d (%s)", resp.Offset, *tt.offset, resp.Detail)
+			}
+		})
+	}
+}
+
+func TestUpdateUserRejectsUnknownFields(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{Default: "acme"})
+
+	rec := serve(h, http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, nil)
+	var created deliveryhttp.UserResponse
+	json.NewDecoder(rec.Body).Decode(&created)
+
+	// 오타 난 필드가 조용히 무시되면 변경이 없는데도 200이 나감
+	rec = serve(h, http.MethodPut, "/api/v1/users/"+created.ID, `{"nmae":"Lee"}`, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("got %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+	var resp deliveryhttp.ErrorResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Code != "unknown_field" || resp.Field != "nmae" {
+		t.Fatalf("unexpected %+v", resp)
+	}
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// HTTP 계층 에러 (요청 형식 문제 등 도메인 밖의 에러)
+var (
+	errInvalidBody            = domain.NewError("invalid_request_body", "invalid request body")
+	errBodyTooLarge           = domain.NewError("request_too_large", "request body too large")
+	errInvalidLimit           = domain.NewError("invalid_limit", "invalid limit")
+	errRateLimited            = domain.NewError("rate_limited", "rate limit exceeded")
+	errUnsupportedContentType = domain.NewError("unsupported_content_type", "content type must be text/csv or application/x-ndjson")
+	errInvalidDryRun          = domain.NewError("invalid_dry_run", "invalid dry_run parameter")
+	errInvalidIdempotencyKey  = domain.NewError("invalid_idempotency_key", "invalid idempotency key")
+	errAdminTokenRequired     = domain.NewError("admin_token_required", "admin token required")
+	errSchemaTooLarge         = domain.NewError("schema_too_large", "schema too large")
+	errMultipartRequired      = domain.NewError("multipart_required", "expected multipart/form-data body")
+	errAvatarFieldMissing     = domain.NewError("avatar_field_missing", "missing avatar field")
+	errInternal               = domain.NewError("internal", "internal server error")
+)
+
+// statusErrors - 코드가 없는 에러를 응답할 때 상태별로 쓰는 일반 에러 (원문은 detail로)
+var statusErrors = map[int]error{
+	http.StatusBadRequest:            domain.NewError("bad_request", "bad request"),
+	http.StatusUnauthorized:          domain.NewError("unauthorized", "unauthorized"),
+	http.StatusForbidden:             domain.NewError("forbidden", "forbidden"),
+	http.StatusNotFound:              domain.NewError("not_found", "not found"),
+	http.StatusConflict:              domain.NewError("conflict", "conflict"),
+	http.StatusRequestEntityTooLarge: errBodyTooLarge,
+	http.StatusUnsupportedMediaType:  domain.NewError("unsupported_media_type", "unsupported media type"),
+	http.StatusUnprocessableEntity:   domain.NewError("unprocessable_entity", "unprocessable entity"),
+	http.StatusTooManyRequests:       errRateLimited,
+	http.StatusNotImplemented:        domain.NewError("not_implemented", "not implemented"),
+	http.StatusServiceUnavailable:    domain.NewError("unavailable", "service unavailable"),
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/changefeed"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// EventStreamConfig - 변경 피드 스트림 설정 (0 값은 기본값 사용)
+type EventStreamConfig struct {
+	Heartbeat    time.Duration // 이벤트가 없을 때 연결 유지용 주석을 보내는 주기 (기본 15초)
+	WriteTimeout time.Duration // 한 번 쓰는 데 허용하는 시간, 넘기면 느린 클라이언트로 보고 끊음 (기본 10초)
+	Retry        time.Duration // 클라이언트 재연결 대기 (SSE retry 필드, 기본 3초)
+}
+
+// UserEventsHandler - 사용자 변경 피드 SSE 핸들러
+type UserEventsHandler struct {
+	feed *changefeed.Feed
+	cfg  EventStreamConfig
+}
+
+// NewUserEventsHandler - UserEventsHandler 생성자
+func NewUserEventsHandler(feed *changefeed.Feed, cfg EventStreamConfig) *UserEventsHandler {
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = 15 * time.Second
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+	if cfg.Retry <= 0 {
+		cfg.Retry = 3 * time.Second
+	}
+	return &UserEventsHandler{
+		feed: feed,
+		cfg:  cfg,
+	}
+}
+
+// UserEventResponse - 변경 피드 이벤트 DTO (SSE data 필드)
+// 삭제/개인정보 삭제 이벤트의 user는 변경 직전 스냅샷 또는 ID만 있음
+type UserEventResponse struct {
+	ID         string       `json:"id"` // 도메인 이벤트 ID (웹훅 전송의 이벤트 ID와 같음)
+	Type       string       `json:"type"`
+	OccurredAt string       `json:"occurred_at"`
+	User       UserResponse `json:"user"`
+}
+
+// StreamEvents - 사용자 변경 피드 (GET /api/v1/users/events, text/event-stream)
+// 이벤트 이름은 도메인 이벤트 종류 (user.created, user.updated, user.deleted 등)이고 id로 이어 받기 위치를 알림
+// Last-Event-ID 헤더(또는 ?last_event_id=)로 다시 연결하면 그 뒤 이벤트부터 보내고,
+// 이어 받을 수 없으면 "reset" 이벤트를 먼저 보내 클라이언트가 목록을 다시 읽게 함
+// 버퍼를 넘기거나 쓰기가 WriteTimeout을 넘길 만큼 느린 클라이언트는 끊음
+func (h *UserEventsHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := tenant.FromContext(r.Context())
+	if !ok {
+		respondError(w, r, http.StatusBadRequest, domain.ErrTenantRequired)
+		return
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		// 브라우저 EventSource는 첫 연결에 헤더를 지정할 수 없음
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	sub, err := h.feed.Subscribe(tenantID, lastEventID)
+	if err != nil {
+		respondError(w, r, http.StatusServiceUnavailable, err)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // 프록시 버퍼링 끄기
+	w.WriteHeader(http.StatusOK)
+
+	stream := &eventWriter{w: w, rc: rc, timeout: h.cfg.WriteTimeout}
+	stream.printf("retry: %d\n\n", h.cfg.Retry.Milliseconds())
+	if sub.Reset {
+		stream.printf("event: reset\ndata: {}\n\n")
+	}
+	for _, e := range sub.Backlog {
+		stream.event(e)
+	}
+	if err := stream.flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.cfg.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			stream.printf(": ping\n\n")
+		case e, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); errors.Is(err, changefeed.ErrSlowConsumer) {
+					log.Printf("사용자 변경 피드: 느린 클라이언트 연결 종료 (테넌트 %s)", tenantID)
+				}
+				return
+			}
+			stream.event(e)
+		}
+		if err := stream.flush(); err != nil {
+			return
+		}
+	}
+}
+
+// eventWriter - SSE 쓰기 (첫 실패 이후 쓰기는 무시하고 flush에서 에러 반환)
+type eventWriter struct {
+	w       io.Writer
+	rc      *http.ResponseController
+	timeout time.Duration
+	err     error
+}
+
+func (s *eventWriter) printf(format string, args ...any) {
+	if s.err != nil {
+		return
+	}
+	if err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.err = err
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
+
+func (s *eventWriter) event(e changefeed.Event) {
+	data, err := json.Marshal(UserEventResponse{
+		ID:         e.UserEvent.ID,
+		Type:       string(e.Type),
+		OccurredAt: e.OccurredAt.Format(time.RFC3339Nano),
+		User:       toUserResponse(&e.User),
+	})
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.printf("id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+}
+
+func (s *eventWriter) flush() error {
+	if s.err != nil {
+		return s.err
+	}
+	s.err = flush(s.rc)
+	return s.err
+}
@@ -0,0 +1,145 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/changefeed"
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// sseEvent - 테스트용으로 읽은 SSE 이벤트 (주석은 event가 ":")
+type sseEvent struct {
+	id, event, data string
+}
+
+// sseStream - 스트림을 연결하고 이벤트를 채널로 읽음 (테스트가 끝나면 연결을 끊음)
+func sseStream(t *testing.T, url, lastEventID string) <-chan sseEvent {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("connect: got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	events := make(chan sseEvent, 16)
+	send := func(e sseEvent) {
+		select {
+		case events <- e:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer resp.Body.Close()
+		defer close(events)
+		var e sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if e != (sseEvent{}) {
+					send(e)
+				}
+				e = sseEvent{}
+			case strings.HasPrefix(line, ":"):
+				send(sseEvent{event: ":"})
+			default:
+				field, value, _ := strings.Cut(line, ": ")
+				switch field {
+				case "id":
+					e.id = value
+				case "event":
+					e.event = value
+				case "data":
+					e.data = value
+				}
+			}
+		}
+	}()
+	return events
+}
+
+// nextEvent - 주석과 retry를 건너뛰고 다음 이벤트
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatal("stream closed")
+			}
+			if e.event != ":" && e.event != "" {
+				return e
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for event")
+		}
+	}
+}
+
+func TestUserEventStream(t *testing.T) {
+	feed := changefeed.New(changefeed.Config{})
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithEventPublisher(feed))
+	srv := httptest.NewServer(deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+		deliveryhttp.WithUserEvents(deliveryhttp.NewUserEventsHandler(feed, deliveryhttp.EventStreamConfig{Heartbeat: 20 * time.Millisecond})),
+	))
+	t.Cleanup(srv.Close) // 스트림 연결을 먼저 끊어야 Close가 끝남 (Cleanup은 역순 실행)
+	url := srv.URL + "/api/v1/users/events"
+
+	events := sseStream(t, url, "")
+	id := createUser(t, srv.Config.Handler)
+
+	created := nextEvent(t, events)
+	var payload deliveryhttp.UserEventResponse
+	if err := json.Unmarshal([]byte(created.data), &payload); err != nil {
+		t.Fatalf("decode %q: %v", created.data, err)
+	}
+	if created.event != "user.created" || created.id == "" || payload.Type != "user.created" || payload.User.ID != id {
+		t.Fatalf("created event = %+v, payload %+v", created, payload)
+	}
+
+	// 하트비트 주석
+	timeout := time.After(2 * time.Second)
+	for ping := false; !ping; {
+		select {
+		case e := <-events:
+			ping = e.event == ":"
+		case <-timeout:
+			t.Fatal("no heartbeat")
+		}
+	}
+
+	// Last-Event-ID로 다시 연결하면 그 뒤 변경부터 받음
+	if rec := serve(srv.Config.Handler, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update: got %d: %s", rec.Code, rec.Body)
+	}
+	resumed := sseStream(t, url, created.id)
+	if e := nextEvent(t, resumed); e.event != "user.updated" || !strings.Contains(e.data, `"name":"Lee"`) {
+		t.Fatalf("resumed event = %+v", e)
+	}
+
+	// 이어 받을 수 없는 ID면 reset부터
+	if e := nextEvent(t, sseStream(t, url, "unknown-1")); e.event != "reset" {
+		t.Fatalf("first event with unknown Last-Event-ID = %+v, want reset", e)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
 	"github.com/milman2/go-api/clean-architecture/internal/usecase"
 )
 
@@ -24,8 +26,10 @@ func NewUserHandler(userUseCase *usecase.UserUseCase) *UserHandler {
 
 // CreateUserRequest - 사용자 생성 요청 DTO
 type CreateUserRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email      string                    `json:"email"`
+	Name       string                    `json:"name"`
+	Attributes map[string]map[string]any `json:"attributes,omitempty"` // 네임스페이스 → 속성 (스키마로 검증)
+	Status     string                    `json:"status,omitempty"`     // 초기 상태: active(기본) | pending
 }
 
 // UpdateUserRequest - 사용자 수정 요청 DTO
@@ -36,15 +40,37 @@ type UpdateUserRequest struct {
 // UserResponse - 사용자 응답 DTO
 type UserResponse struct {
 	ID        string `json:"id"`
+	TenantID  string `json:"tenant_id"`
 	Email     string `json:"email"`
 	Name      string `json:"name"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+
+	Attributes map[string]map[string]any `json:"attributes,omitempty"`
+
+	Status        string                 `json:"status"`
+	StatusHistory []StatusChangeResponse `json:"status_history,omitempty"`
+}
+
+// StatusChangeResponse - 상태 변경 기록 DTO
+type StatusChangeResponse struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+	Actor  string `json:"actor"`
+	At     string `json:"at"`
 }
 
 // ErrorResponse - 에러 응답 DTO
+// Error는 Accept-Language에 맞춘 메시지, Code는 언어와 무관한 안정된 식별자
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+
+	// 본문 디코딩 실패 위치 (DecodeError일 때만)
+	Field  string `json:"field,omitempty"`
+	Offset *int64 `json:"offset,omitempty"`
 }
 
 // respondJSON - JSON 응답 헬퍼
@@ -55,38 +81,91 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 // respondError - 에러 응답 헬퍼
-func respondError(w http.ResponseWriter, status int, err error) {
-	respondJSON(w, status, ErrorResponse{Error: err.Error()})
+func respondError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	respondJSON(w, status, toErrorResponse(r, status, err))
+}
+
+// toErrorResponse - 에러를 요청 언어의 ErrorResponse로 변환
+// 코드가 없는 에러는 상태 코드별 기본 코드/메시지를 쓰고 원문은 Detail에 남김
+func toErrorResponse(r *http.Request, status int, err error) ErrorResponse {
+	lang := i18n.FromContext(r.Context())
+	l := i18n.Localize(lang, err)
+	if l.Code == "" {
+		fallback, ok := statusErrors[status]
+		if !ok {
+			fallback = errInternal
+		}
+		l = i18n.Localize(lang, fallback)
+		l.Detail = err.Error()
+	}
+	resp := ErrorResponse{Error: l.Message, Code: l.Code, Detail: l.Detail}
+
+	var de *DecodeError
+	if errors.As(err, &de) {
+		resp.Field = de.Field
+		if de.Offset >= 0 {
+			resp.Offset = &de.Offset
+		}
+	}
+	return resp
 }
 
 // toUserResponse - 도메인 엔티티를 DTO로 변환
 func toUserResponse(user *domain.User) UserResponse {
-	return UserResponse{
+	resp := UserResponse{
 		ID:        user.ID,
+		TenantID:  user.TenantID,
 		Email:     user.Email,
 		Name:      user.Name,
 		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+
+		Attributes: user.Attributes,
+
+		Status: string(user.CurrentStatus()),
+	}
+	for _, c := range user.StatusHistory {
+		resp.StatusHistory = append(resp.StatusHistory, StatusChangeResponse{
+			From:   string(c.From),
+			To:     string(c.To),
+			Reason: c.Reason,
+			Actor:  c.Actor,
+			At:     c.At.Format("2006-01-02T15:04:05Z07:00"),
+		})
 	}
+	return resp
 }
 
 // CreateUser - 사용자 생성 핸들러
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req CreateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, errors.New("invalid request body"))
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	create := h.userUseCase.CreateUserWithAttributes
+	switch domain.UserStatus(req.Status) {
+	case "", domain.UserActive:
+	case domain.UserPending:
+		create = h.userUseCase.CreatePendingUser
+	default:
+		respondError(w, r, http.StatusBadRequest, domain.ErrInvalidUserStatus)
 		return
 	}
 
-	user, err := h.userUseCase.CreateUser(r.Context(), req.Email, req.Name)
+	user, err := create(r.Context(), req.Email, req.Name, req.Attributes)
 	if err != nil {
+		if respondAttributeError(w, r, err) {
+			return
+		}
 		switch err {
-		case domain.ErrInvalidEmail, domain.ErrInvalidName:
-			respondError(w, http.StatusBadRequest, err)
+		case domain.ErrInvalidEmail, domain.ErrInvalidName, domain.ErrTenantRequired:
+			respondError(w, r, http.StatusBadRequest, err)
 		case domain.ErrUserExists:
-			respondError(w, http.StatusConflict, err)
+			respondError(w, r, http.StatusConflict, err)
 		default:
-			respondError(w, http.StatusInternalServerError, err)
+			respondError(w, r, http.StatusInternalServerError, err)
 		}
 		return
 	}
@@ -102,23 +181,84 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case domain.ErrUserNotFound:
-			respondError(w, http.StatusNotFound, err)
-		case domain.ErrInvalidUserID:
-			respondError(w, http.StatusBadRequest, err)
+			respondError(w, r, http.StatusNotFound, err)
+		case domain.ErrInvalidUserID, domain.ErrTenantRequired:
+			respondError(w, r, http.StatusBadRequest, err)
+		case domain.ErrCrossTenant:
+			respondError(w, r, http.StatusForbidden, err)
 		default:
-			respondError(w, http.StatusInternalServerError, err)
+			respondError(w, r, http.StatusInternalServerError, err)
 		}
 		return
 	}
 
+	// 조건부 GET: 변경이 없으면 본문 없이 304
+	setValidators(w, user)
+	if notModified(r, user) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	respondJSON(w, http.StatusOK, toUserResponse(user))
 }
 
 // GetAllUsers - 모든 사용자 조회 핸들러
+// ?attr.<네임스페이스>.<필드>=값 으로 사용자 정의 속성 조건을 줄 수 있음 (여러 개면 AND)
+// ?status=suspended,pending 으로 계정 상태 조건을 줄 수 있음 (여러 개면 OR)
 func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := h.userUseCase.GetAllUsers(r.Context())
+	filters, err := attributeFilters(r.URL.Query())
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	statuses, err := statusFilter(r.URL.Query().Get("status"))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err)
+		respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	users, err := h.userUseCase.ListUsers(r.Context(), usecase.UserFilter{Attributes: filters, Statuses: statuses})
+	if err != nil {
+		switch err {
+		case domain.ErrTenantRequired, domain.ErrInvalidFilter, domain.ErrInvalidUserStatus:
+			respondError(w, r, http.StatusBadRequest, err)
+		default:
+			respondError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = toUserResponse(user)
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// SearchUsers - 사용자 검색 핸들러 (GET /api/v1/users/search?q=&limit=)
+// 이름/이메일 부분 일치, 한글 이름 중간 일치, 오타 허용 일치를 관련도 순으로 반환
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			respondError(w, r, http.StatusBadRequest, errInvalidLimit)
+			return
+		}
+		limit = parsed
+	}
+
+	users, err := h.userUseCase.SearchUsers(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidQuery, domain.ErrTenantRequired:
+			respondError(w, r, http.StatusBadRequest, err)
+		case usecase.ErrSearchUnavailable:
+			respondError(w, r, http.StatusNotImplemented, err)
+		default:
+			respondError(w, r, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
@@ -130,44 +270,57 @@ func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, responses)
 }
 
-// UpdateUser - 사용자 수정 핸들러
+// UpdateUser - 사용자 수정 핸들러 (If-Match가 있으면 ETag가 맞을 때만 수정, 아니면 412)
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	var req UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, errors.New("invalid request body"))
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
 		return
 	}
 
-	user, err := h.userUseCase.UpdateUser(r.Context(), id, req.Name)
+	user, err := h.userUseCase.UpdateUser(withIfMatch(r), id, req.Name)
 	if err != nil {
 		switch err {
 		case domain.ErrUserNotFound:
-			respondError(w, http.StatusNotFound, err)
-		case domain.ErrInvalidName:
-			respondError(w, http.StatusBadRequest, err)
+			respondError(w, r, http.StatusNotFound, err)
+		case domain.ErrInvalidName, domain.ErrTenantRequired:
+			respondError(w, r, http.StatusBadRequest, err)
+		case domain.ErrCrossTenant:
+			respondError(w, r, http.StatusForbidden, err)
+		case domain.ErrUserSuspended, domain.ErrUserDeactivated, domain.ErrUserModified:
+			respondError(w, r, http.StatusConflict, err)
+		case domain.ErrPreconditionFailed:
+			respondError(w, r, http.StatusPreconditionFailed, err)
 		default:
-			respondError(w, http.StatusInternalServerError, err)
+			respondError(w, r, http.StatusInternalServerError, err)
 		}
 		return
 	}
 
+	setValidators(w, user)
 	respondJSON(w, http.StatusOK, toUserResponse(user))
 }
 
-// DeleteUser - 사용자 삭제 핸들러
+// DeleteUser - 사용자 삭제 핸들러 (If-Match가 있으면 ETag가 맞을 때만 삭제, 아니면 412)
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.userUseCase.DeleteUser(r.Context(), id); err != nil {
+	if err := h.userUseCase.DeleteUser(withIfMatch(r), id); err != nil {
 		switch err {
 		case domain.ErrUserNotFound:
-			respondError(w, http.StatusNotFound, err)
-		case domain.ErrInvalidUserID:
-			respondError(w, http.StatusBadRequest, err)
+			respondError(w, r, http.StatusNotFound, err)
+		case domain.ErrInvalidUserID, domain.ErrTenantRequired:
+			respondError(w, r, http.StatusBadRequest, err)
+		case domain.ErrCrossTenant:
+			respondError(w, r, http.StatusForbidden, err)
+		case domain.ErrUserModified:
+			respondError(w, r, http.StatusConflict, err)
+		case domain.ErrPreconditionFailed:
+			respondError(w, r, http.StatusPreconditionFailed, err)
 		default:
-			respondError(w, http.StatusInternalServerError, err)
+			respondError(w, r, http.StatusInternalServerError, err)
 		}
 		return
 	}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/idempotency"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// 멱등성 설정
+const (
+	idempotencyKeyHeader   = "Idempotency-Key"
+	idempotencyMaxKeyLen   = 255
+	idempotencyMaxBodySize = 1 << 20
+)
+
+// Idempotency - Idempotency-Key 헤더 처리 미들웨어
+// 첫 응답(상태, 헤더, 본문)을 저장해 두고 같은 키의 재시도에 그대로 재생
+type Idempotency struct {
+	store idempotency.Store
+	ttl   time.Duration
+}
+
+// NewIdempotency - Idempotency 생성자
+func NewIdempotency(store idempotency.Store, ttl time.Duration) *Idempotency {
+	return &Idempotency{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Middleware - chi 미들웨어 (헤더가 없는 요청은 그대로 통과)
+func (m *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > idempotencyMaxKeyLen {
+			respondError(w, r, http.StatusBadRequest, errInvalidIdempotencyKey)
+			return
+		}
+
+		// 요청 지문 계산을 위해 본문을 읽고 다시 채워 넣음
+		body, err := io.ReadAll(io.LimitReader(r.Body, idempotencyMaxBodySize+1))
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, errInvalidBody)
+			return
+		}
+		if len(body) > idempotencyMaxBodySize {
+			respondError(w, r, http.StatusRequestEntityTooLarge, errBodyTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 저장 키: 테넌트 + 클라이언트 + Idempotency-Key (응답은 같은 요청 지문에만 재생)
+		tenantID, _ := tenant.FromContext(r.Context())
+		storeKey := tenantID + "|" + idempotencyScope(r) + "|" + key
+		fingerprint := requestFingerprint(r, body)
+
+		saved, err := m.store.Begin(r.Context(), storeKey, fingerprint, m.ttl)
+		switch {
+		case errors.Is(err, idempotency.ErrFingerprintMismatch):
+			respondError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			w.Header().Set("Retry-After", "1")
+			respondError(w, r, http.StatusConflict, err)
+			return
+		case err != nil:
+			log.Printf("멱등성 저장소 오류: %v", err)
+			respondError(w, r, http.StatusInternalServerError, errInternal)
+			return
+		case saved != nil:
+			replay(w, saved)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			// 클라이언트가 연결을 끊어도 결과는 기록
+			ctx := context.WithoutCancel(r.Context())
+
+			// 5xx나 패닉이면 저장하지 않고 예약만 해제해 재시도 허용
+			if p := recover(); p != nil {
+				m.store.Release(ctx, storeKey)
+				panic(p)
+			}
+			if rec.status >= http.StatusInternalServerError {
+				m.store.Release(ctx, storeKey)
+				return
+			}
+			if err := m.store.Complete(ctx, storeKey, idempotency.Response{
+				Status: rec.status,
+				Header: rec.header,
+				Body:   rec.body.Bytes(),
+			}); err != nil {
+				log.Printf("멱등성 응답 저장 실패: %v", err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// idempotencyScope - 저장 키의 클라이언트 부분 (인증 주체 → 인증된 API 키 → 실제 IP 순, KeyByClient)
+// 인증된 클라이언트는 재시도 중 IP가 바뀌어도 같은 응답을 재생하고, 다른 주체는 같은 키를 보내도 서로의 응답을 받지 못함
+// 인증되지 않은 요청은 IP로 구분해 다른 클라이언트가 같은 키로 남의 응답(생성된 사용자)을 받지 못하게 함
+func idempotencyScope(r *http.Request) string {
+	return KeyByClient(r)
+}
+
+// requestFingerprint - 메서드 + 경로 + 본문 해시
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.Path)
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay - 저장된 응답 재생
+// 속도 제한 헤더는 이번 요청 기준 값을 유지
+func replay(w http.ResponseWriter, saved *idempotency.Response) {
+	for k, v := range saved.Header {
+		if strings.HasPrefix(k, "Ratelimit-") {
+			continue
+		}
+		w.Header()[k] = v
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(saved.Status)
+	w.Write(saved.Body)
+}
+
+// responseRecorder - 응답을 그대로 전달하면서 사본 기록
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	header      http.Header
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.wroteHeader = true
+		r.status = status
+		r.header = r.ResponseWriter.Header().Clone()
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
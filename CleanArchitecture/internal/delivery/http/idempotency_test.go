@@ -0,0 +1,211 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/idempotency"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func newIdempotentRouter() http.Handler {
+	handler := deliveryhttp.NewUserHandler(usecase.NewUserUseCase(memory.NewUserRepository()))
+	return deliveryhttp.NewRouter(handler,
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{TrustHeader: true})),
+		deliveryhttp.WithIdempotency(deliveryhttp.NewIdempotency(idempotency.NewMemoryStore(), time.Hour)),
+	)
+}
+
+// withPrincipal - 인증 미들웨어 대신 X-Test-Principal 헤더를 인증된 주체로 설정
+func withPrincipal(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p := r.Header.Get("X-Test-Principal"); p != "" {
+			r = r.WithContext(deliveryhttp.WithPrincipal(r.Context(), p))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+type idempotentRequest struct {
+	remoteAddr string
+	principal  string
+	key        string
+	body       string
+}
+
+func (req idempotentRequest) serve(h http.Handler) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(req.body))
+	r.RemoteAddr = req.remoteAddr
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Tenant-ID", "acme")
+	r.Header.Set("Idempotency-Key", req.key)
+	if req.principal != "" {
+		r.Header.Set("X-Test-Principal", req.principal)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestIdempotencyScope(t *testing.T) {
+	kim := `{"email":"kim@example.com","name":"Kim"}`
+	lee := `{"email":"lee@example.com","name":"Lee"}`
+
+	tests := []struct {
+		name         string
+		first        idempotentRequest
+		second       idempotentRequest
+		wantStatus   int
+		wantReplayed bool
+	}{
+		{
+			name:         "retry from a new IP replays",
+			first:        idempotentRequest{remoteAddr: "192.0.2.1:1234", principal: "alice", key: "k1", body: kim},
+			second:       idempotentRequest{remoteAddr: "198.51.100.9:5678", principal: "alice", key: "k1", body: kim},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+		},
+		{
+			name:         "anonymous retry from the same IP replays",
+			first:        idempotentRequest{remoteAddr: "192.0.2.1:1234", key: "k1", body: kim},
+			second:       idempotentRequest{remoteAddr: "192.0.2.1:5678", key: "k1", body: kim},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+		},
+		{
+			// 인증되지 않은 두 클라이언트가 같은 키를 써도 서로의 응답을 받지 못함
+			name:       "other anonymous caller is not replayed",
+			first:      idempotentRequest{remoteAddr: "192.0.2.1:1234", key: "k1", body: kim},
+			second:     idempotentRequest{remoteAddr: "198.51.100.9:5678", key: "k1", body: lee},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			// 같은 본문이어도 다른 클라이언트면 자기 요청으로 처리 (이메일 중복으로 409)
+			name:       "other anonymous caller with the same body is not replayed",
+			first:      idempotentRequest{remoteAddr: "192.0.2.1:1234", key: "k1", body: kim},
+			second:     idempotentRequest{remoteAddr: "198.51.100.9:5678", key: "k1", body: kim},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			// 다른 주체는 같은 키를 보내도 남의 응답을 받지 못하고 자기 요청으로 처리됨
+			name:       "other principal is not replayed",
+			first:      idempotentRequest{remoteAddr: "192.0.2.1:1234", principal: "alice", key: "k1", body: kim},
+			second:     idempotentRequest{remoteAddr: "192.0.2.1:1234", principal: "mallory", key: "k1", body: lee},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "same key with a different body",
+			first:      idempotentRequest{remoteAddr: "192.0.2.1:1234", principal: "alice", key: "k1", body: kim},
+			second:     idempotentRequest{remoteAddr: "192.0.2.1:1234", principal: "alice", key: "k1", body: lee},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := withPrincipal(newIdempotentRouter())
+
+			first := tt.first.serve(h)
+			if first.Code != http.StatusCreated {
+				t.Fatalf("first: got %d: %s", first.Code, first.Body)
+			}
+			second := tt.second.serve(h)
+			if second.Code != tt.wantStatus {
+				t.Fatalf("second: got %d, want %d: %s", second.Code, tt.wantStatus, second.Body)
+			}
+			if replayed := second.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Fatalf("replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+			if tt.wantReplayed && second.Body.String() != first.Body.String() {
+				t.Fatalf("replayed body = %s, want %s", second.Body, first.Body)
+			}
+		})
+	}
+}
+
+// 첫 요청이 처리 중일 때 들어온 중복 요청은 409, 끝난 뒤에는 첫 응답을 재생 (핸들러는 한 번만 실행)
+func TestIdempotencyConcurrentDuplicates(t *testing.T) {
+	var calls atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			close(entered)
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "u1"})
+	})
+	h := deliveryhttp.NewIdempotency(idempotency.NewMemoryStore(), time.Hour).Middleware(slow)
+	req := idempotentRequest{remoteAddr: "192.0.2.1:1234", key: "k1", body: `{"name":"Kim"}`}
+
+	firstDone := make(chan *httptest.ResponseRecorder)
+	go func() { firstDone <- req.serve(h) }()
+	<-entered
+
+	const duplicates = 8
+	var wg sync.WaitGroup
+	codes := make([]int, duplicates)
+	retryAfter := make([]string, duplicates)
+	for i := range duplicates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := req.serve(h)
+			codes[i] = rec.Code
+			retryAfter[i] = rec.Header().Get("Retry-After")
+		}()
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusConflict || retryAfter[i] == "" {
+			t.Fatalf("duplicate %d: got %d (Retry-After %q), want 409", i, code, retryAfter[i])
+		}
+	}
+
+	close(release)
+	first := <-firstDone
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first: got %d", first.Code)
+	}
+
+	again := req.serve(h)
+	if again.Code != http.StatusCreated || again.Header().Get("Idempotent-Replayed") != "true" || again.Body.String() != first.Body.String() {
+		t.Fatalf("after completion: got %d %q: %s", again.Code, again.Header().Get("Idempotent-Replayed"), again.Body)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler ran %d times, want 1", n)
+	}
+}
+
+// 5xx 응답은 저장하지 않아 재시도가 다시 처리됨
+func TestIdempotencyServerErrorNotStored(t *testing.T) {
+	var calls atomic.Int32
+	flaky := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	h := deliveryhttp.NewIdempotency(idempotency.NewMemoryStore(), time.Hour).Middleware(flaky)
+	req := idempotentRequest{remoteAddr: "192.0.2.1:1234", key: "k1", body: `{}`}
+
+	if rec := req.serve(h); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("first: got %d", rec.Code)
+	}
+	if rec := req.serve(h); rec.Code != http.StatusCreated || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry: got %d, replayed %q", rec.Code, rec.Header().Get("Idempotent-Replayed"))
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("handler ran %d times, want 2", n)
+	}
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+)
+
+// JobHandler - 주기 작업 조회/수동 실행 HTTP 핸들러 (관리자용)
+type JobHandler struct {
+	jobs       *scheduler.Scheduler
+	adminToken string
+}
+
+// NewJobHandler - JobHandler 생성자
+// adminToken이 비어 있으면 모든 요청을 거부
+func NewJobHandler(jobs *scheduler.Scheduler, adminToken string) *JobHandler {
+	return &JobHandler{
+		jobs:       jobs,
+		adminToken: adminToken,
+	}
+}
+
+// JobResponse - 작업 응답 DTO
+type JobResponse struct {
+	Name      string          `json:"name"`
+	Schedule  string          `json:"schedule"`
+	Timeout   string          `json:"timeout"`
+	NextRunAt string          `json:"next_run_at,omitempty"`
+	Running   bool            `json:"running"`
+	LastRun   *JobRunResponse `json:"last_run,omitempty"`
+
+	PerInstance bool `json:"per_instance"` // 리스 없이 인스턴스마다 실행
+}
+
+// JobRunResponse - 실행 기록 응답 DTO
+type JobRunResponse struct {
+	ID         string `json:"id"`
+	Holder     string `json:"holder"`
+	Occurrence string `json:"occurrence"`
+	Manual     bool   `json:"manual"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+func toJobRunResponse(run *scheduler.Run) *JobRunResponse {
+	return &JobRunResponse{
+		ID:         run.ID,
+		Holder:     run.Holder,
+		Occurrence: run.Occurrence.Format(time.RFC3339),
+		Manual:     run.Manual,
+		Status:     string(run.Status),
+		Error:      run.Error,
+		StartedAt:  run.StartedAt.Format(time.RFC3339),
+		FinishedAt: run.FinishedAt.Format(time.RFC3339),
+		DurationMS: run.Duration().Milliseconds(),
+	}
+}
+
+// RequireAdmin - Authorization: Bearer <관리자 토큰> 확인 미들웨어
+func (h *JobHandler) RequireAdmin(next http.Handler) http.Handler {
+	return requireAdmin(h.adminToken, next)
+}
+
+// respondJobError - 작업 에러를 HTTP 상태로 변환
+func respondJobError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, scheduler.ErrJobRunning):
+		respondError(w, r, http.StatusConflict, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// ListJobs - 작업 목록과 마지막 실행 (GET /api/v1/admin/jobs)
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	infos := h.jobs.Jobs()
+	responses := make([]JobResponse, len(infos))
+	for i, info := range infos {
+		resp := JobResponse{
+			Name:     info.Name,
+			Schedule: info.Schedule,
+			Timeout:  info.Timeout.String(),
+			Running:  info.Running,
+
+			PerInstance: info.PerInstance,
+		}
+		if !info.Next.IsZero() {
+			resp.NextRunAt = info.Next.Format(time.RFC3339)
+		}
+		runs, err := h.jobs.History(r.Context(), info.Name, 1)
+		if err != nil {
+			respondJobError(w, r, err)
+			return
+		}
+		if len(runs) > 0 {
+			resp.LastRun = toJobRunResponse(runs[0])
+		}
+		responses[i] = resp
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// ListRuns - 작업 실행 기록 (GET /api/v1/admin/jobs/{name}/runs?limit=, 최신순)
+// 기록은 실행한 인스턴스에 남으므로 여러 인스턴스면 응답한 인스턴스의 기록만 보임
+func (h *JobHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			respondError(w, r, http.StatusBadRequest, errInvalidLimit)
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.jobs.History(r.Context(), chi.URLParam(r, "name"), limit)
+	if err != nil {
+		respondJobError(w, r, err)
+		return
+	}
+	responses := make([]*JobRunResponse, len(runs))
+	for i, run := range runs {
+		responses[i] = toJobRunResponse(run)
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// TriggerJob - 작업 수동 실행 (POST /api/v1/admin/jobs/{name}/run)
+// 백그라운드에서 실행하고 202를 반환 (결과는 실행 기록으로 확인)
+func (h *JobHandler) TriggerJob(w http.ResponseWriter, r *http.Request) {
+	if err := h.jobs.Trigger(chi.URLParam(r, "name")); err != nil {
+		respondJobError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
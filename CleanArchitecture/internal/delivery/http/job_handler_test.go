@@ -0,0 +1,73 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/scheduler"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func TestJobAdminAPI(t *testing.T) {
+	jobs := scheduler.New(scheduler.NewMemoryLeaseStore(), scheduler.NewMemoryHistory(10), scheduler.Config{Holder: "test"})
+	ran := make(chan struct{}, 1)
+	if err := jobs.Register(scheduler.Job{Name: "cleanup", Schedule: "@daily", Run: func(context.Context) error {
+		ran <- struct{}{}
+		return errors.New("nothing to clean")
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	users := usecase.NewUserUseCase(memory.NewUserRepository())
+	h := deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithJobs(deliveryhttp.NewJobHandler(jobs, adminToken)),
+	)
+	admin := map[string]string{"Authorization": "Bearer " + adminToken}
+
+	if rec := serve(h, http.MethodGet, "/api/v1/admin/jobs", "", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("no token: got %d", rec.Code)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/jobs/missing/run", "", admin); rec.Code != http.StatusNotFound {
+		t.Fatalf("missing job: got %d", rec.Code)
+	}
+
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/jobs/cleanup/run", "", admin); rec.Code != http.StatusAccepted {
+		t.Fatalf("trigger: got %d: %s", rec.Code, rec.Body)
+	}
+	<-ran
+
+	// 실행 기록은 작업이 끝난 뒤 저장됨
+	var runs []deliveryhttp.JobRunResponse
+	deadline := time.Now().Add(5 * time.Second)
+	for len(runs) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("run was not recorded")
+		}
+		time.Sleep(5 * time.Millisecond)
+		rec := serve(h, http.MethodGet, "/api/v1/admin/jobs/cleanup/runs", "", admin)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("runs: got %d", rec.Code)
+		}
+		json.NewDecoder(rec.Body).Decode(&runs)
+	}
+	if runs[0].Status != "failed" || runs[0].Error != "nothing to clean" || !runs[0].Manual {
+		t.Fatalf("got %+v", runs[0])
+	}
+
+	rec := serve(h, http.MethodGet, "/api/v1/admin/jobs", "", admin)
+	var list []deliveryhttp.JobResponse
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list) != 1 || list[0].NextRunAt == "" || list[0].LastRun == nil || list[0].LastRun.ID != runs[0].ID {
+		t.Fatalf("got %+v", list)
+	}
+
+	if rec := serve(h, http.MethodGet, "/api/v1/admin/jobs/cleanup/runs?limit=0", "", admin); rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad limit: got %d", rec.Code)
+	}
+}
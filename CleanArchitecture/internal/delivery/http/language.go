@@ -0,0 +1,18 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+)
+
+// Localize - Accept-Language로 응답 언어를 골라 컨텍스트에 저장하는 미들웨어
+// 에러 메시지만 지역화하고 에러 코드(code)는 언어와 무관하게 유지
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", lang.String())
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), lang)))
+	})
+}
@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+)
+
+func TestLocalizedErrors(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{Default: "acme"})
+	missing := "/api/v1/users/00000000-0000-0000-0000-000000000000"
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		language string
+		want     deliveryhttp.ErrorResponse
+		contLang string
+	}{
+		{"default english", http.MethodGet, missing, "", "",
+			deliveryhttp.ErrorResponse{Error: "user not found", Code: "user_not_found"}, "en"},
+		{"korean", http.MethodGet, missing, "", "ko-KR,ko;q=0.9",
+			deliveryhttp.ErrorResponse{Error: "사용자를 찾을 수 없습니다", Code: "user_not_found"}, "ko"},
+		{"unsupported falls back to english", http.MethodGet, missing, "", "fr",
+			deliveryhttp.ErrorResponse{Error: "user not found", Code: "user_not_found"}, "en"},
+		{"http layer error", http.MethodGet, "/api/v1/users/search?q=kim&limit=x", "", "ko",
+			deliveryhttp.ErrorResponse{Error: "limit 값이 올바르지 않습니다", Code: "invalid_limit"}, "ko"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := map[string]string{}
+			if tt.language != "" {
+				header["Accept-Language"] = tt.language
+			}
+			rec := serve(h, tt.method, tt.target, tt.body, header)
+			var got deliveryhttp.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			if cl := rec.Header().Get("Content-Language"); cl != tt.contLang {
+				t.Fatalf("Content-Language = %q, want %q", cl, tt.contLang)
+			}
+			if rec.Header().Get("Vary") == "" {
+				t.Fatal("missing Vary: Accept-Language")
+			}
+		})
+	}
+}
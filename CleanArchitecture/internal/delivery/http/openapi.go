@@ -0,0 +1,239 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// errInvalidRequest - 요청이 OpenAPI 문서와 맞지 않음 (이유는 ValidationErrorResponse.Details)
+var errInvalidRequest = domain.NewError("invalid_request", "request does not match the API contract")
+
+// ValidationErrorResponse - 요청 검증 실패 응답 DTO
+type ValidationErrorResponse struct {
+	ErrorResponse
+	Details []ValidationErrorDetail `json:"details"`
+}
+
+// ValidationErrorDetail - 검증 실패 이유 하나
+// In은 path, query, header, body 중 하나이고 Name은 파라미터 이름 또는 본문 안 위치 (JSON Pointer)
+type ValidationErrorDetail struct {
+	In      string `json:"in"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// OpenAPI - OpenAPI 문서로 요청을 검증하고 문서를 제공
+// 문서에 있는 라우트만 검사하고, 나머지(웹훅/관리자 API 등)는 그대로 통과
+type OpenAPI struct {
+	doc  *openapi3.T
+	spec []byte
+}
+
+// NewOpenAPI - OpenAPI 생성자 (YAML 또는 JSON 문서를 읽고 문서 자체의 유효성도 확인)
+func NewOpenAPI(spec []byte) (*OpenAPI, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI 문서 읽기: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("OpenAPI 문서 검증: %w", err)
+	}
+	return &OpenAPI{doc: doc, spec: spec}, nil
+}
+
+// Doc - 읽은 OpenAPI 문서
+func (o *OpenAPI) Doc() *openapi3.T {
+	return o.doc
+}
+
+// ServeYAML - 문서 원문 (GET /api/v1/openapi.yaml)
+func (o *OpenAPI) ServeYAML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(o.spec)
+}
+
+// ServeJSON - 문서의 JSON 표현 (GET /api/v1/openapi.json)
+func (o *OpenAPI) ServeJSON(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, o.doc)
+}
+
+// Route - 요청에 해당하는 문서의 라우트 (routes로 라우트 패턴을 찾아 문서 경로와 맞춤)
+func (o *OpenAPI) Route(routes chi.Routes, r *http.Request) (*routers.Route, map[string]string, bool) {
+	rctx := chi.NewRouteContext()
+	if !routes.Match(rctx, r.Method, r.URL.Path) {
+		return nil, nil, false
+	}
+	// r.Route 안의 "/"는 "/api/v1/users/" 패턴이 됨
+	path := rctx.RoutePattern()
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	item := o.doc.Paths.Value(path)
+	if item == nil {
+		return nil, nil, false
+	}
+	op := item.GetOperation(r.Method)
+	if op == nil {
+		return nil, nil, false
+	}
+
+	params := make(map[string]string, len(rctx.URLParams.Keys))
+	for i, key := range rctx.URLParams.Keys {
+		params[key] = rctx.URLParams.Values[i]
+	}
+	return &routers.Route{Spec: o.doc, Path: path, PathItem: item, Method: r.Method, Operation: op}, params, true
+}
+
+// Middleware - 요청 검증 chi 미들웨어 (경로/쿼리/헤더 파라미터와 JSON 본문)
+// 라우팅 전에 실행되므로 routes로 요청의 라우트 패턴을 미리 찾음
+// JSON이 아닌 본문(CSV/NDJSON 가져오기, multipart 아바타)은 스트리밍을 위해 핸들러에 맡기고,
+// 형식이 깨졌거나 너무 큰 JSON 본문도 위치를 알려 주는 핸들러의 디코딩 에러로 응답하도록 검사하지 않음
+func (o *OpenAPI) Middleware(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, params, ok := o.Route(routes, r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf, checkBody, err := readJSONBody(r)
+			if err != nil {
+				respondError(w, r, http.StatusBadRequest, errInvalidBody)
+				return
+			}
+			// 검증은 읽어 둔 본문으로 하고 (읽은 뒤 닫으므로 원래 본문은 넘기지 않음),
+			// 핸들러에는 읽어 둔 부분과 남은 원래 본문을 이어 넘김
+			rest := r.Body
+			if buf != nil {
+				r.Body = io.NopCloser(bytes.NewReader(buf))
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: params,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					ExcludeRequestBody: !checkBody,
+					MultiError:         true,
+					// 인증은 테넌트 확인/관리자 토큰 미들웨어가 담당
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			err = openapi3filter.ValidateRequest(r.Context(), input)
+			if buf != nil {
+				r.Body = readCloser{io.MultiReader(bytes.NewReader(buf), rest), rest}
+			}
+			if err != nil {
+				respondValidationError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readJSONBody - JSON 본문을 최대 maxJSONBodyBytes+1 바이트까지 읽음 (JSON이 아니면 읽지 않고 nil)
+// 검사할 수 있는 본문(application/json, maxJSONBodyBytes 이하, 올바른 JSON)이면 true
+// 한도를 넘은 본문은 나머지가 r.Body에 남아 핸들러가 request_too_large로 응답함
+func readJSONBody(r *http.Request) ([]byte, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil, false, nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(r.Body, maxJSONBodyBytes+1))
+	if err != nil {
+		return nil, false, err
+	}
+	return buf, len(buf) <= maxJSONBodyBytes && json.Valid(buf), nil
+}
+
+// readCloser - 읽기는 Reader로, 닫기는 원래 본문으로
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// respondValidationError - 검증 실패 이유를 모아 400 응답
+func respondValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	resp := ValidationErrorResponse{
+		ErrorResponse: toErrorResponse(r, http.StatusBadRequest, errInvalidRequest),
+		Details:       validationDetails(err),
+	}
+	respondJSON(w, http.StatusBadRequest, resp)
+}
+
+// validationDetails - kin-openapi 에러를 in/name/message 목록으로 펼침
+func validationDetails(err error) []ValidationErrorDetail {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var details []ValidationErrorDetail
+		for _, err := range e {
+			details = append(details, validationDetails(err)...)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			return []ValidationErrorDetail{{In: e.Parameter.In, Name: e.Parameter.Name, Message: parameterReason(e)}}
+		}
+		return bodyDetails(e)
+	default:
+		return []ValidationErrorDetail{{In: "body", Message: err.Error()}}
+	}
+}
+
+// parameterReason - 파라미터 에러 이유 (스키마 에러면 이유만, 아니면 전체 메시지)
+func parameterReason(reqErr *openapi3filter.RequestError) string {
+	var se *openapi3.SchemaError
+	if errors.As(reqErr.Err, &se) {
+		return se.Reason
+	}
+	if reqErr.Err != nil {
+		return reqErr.Err.Error()
+	}
+	return reqErr.Reason
+}
+
+// bodyDetails - 본문 스키마 에러를 필드 위치별로 펼침
+func bodyDetails(reqErr *openapi3filter.RequestError) []ValidationErrorDetail {
+	if multi, ok := reqErr.Err.(openapi3.MultiError); ok {
+		details := make([]ValidationErrorDetail, len(multi))
+		for i, err := range multi {
+			details[i] = schemaDetail(err)
+		}
+		return details
+	}
+	if reqErr.Err != nil {
+		return []ValidationErrorDetail{schemaDetail(reqErr.Err)}
+	}
+	return []ValidationErrorDetail{{In: "body", Message: reqErr.Reason}}
+}
+
+// schemaDetail - 스키마 에러 하나 (Name은 본문 안 위치)
+func schemaDetail(err error) ValidationErrorDetail {
+	var se *openapi3.SchemaError
+	if errors.As(err, &se) {
+		return ValidationErrorDetail{In: "body", Name: "/" + strings.Join(se.JSONPointer(), "/"), Message: se.Reason}
+	}
+	return ValidationErrorDetail{In: "body", Message: err.Error()}
+}
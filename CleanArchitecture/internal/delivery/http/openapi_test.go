@@ -0,0 +1,318 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/api"
+	"github.com/milman2/go-api/clean-architecture/internal/changefeed"
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// newOpenAPIRouter - 사용자 API를 모두 등록하고 api/openapi.yaml로 검증하는 라우터
+func newOpenAPIRouter(t *testing.T) (*chi.Mux, *deliveryhttp.OpenAPI) {
+	t.Helper()
+	oa, err := deliveryhttp.NewOpenAPI(api.Spec())
+	if err != nil {
+		t.Fatalf("NewOpenAPI: %v", err)
+	}
+	blobs, err := file.NewBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewBlobStore: %v", err)
+	}
+	feed := changefeed.New(changefeed.Config{})
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithBlobStore(blobs), usecase.WithEventPublisher(feed))
+	r := deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+		deliveryhttp.WithUserEvents(deliveryhttp.NewUserEventsHandler(feed, deliveryhttp.EventStreamConfig{})),
+		deliveryhttp.WithOpenAPI(oa),
+	)
+	return r, oa
+}
+
+// 라우터에 등록된 /api/v1/users 라우트와 문서의 operation이 같아야 함
+func TestOpenAPIRoutesMatchSpec(t *testing.T) {
+	r, oa := newOpenAPIRouter(t)
+
+	var routes []string
+	chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/api/v1/users") {
+			routes = append(routes, method+" "+strings.TrimSuffix(route, "/"))
+		}
+		return nil
+	})
+
+	var documented []string
+	for path, item := range oa.Doc().Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	if !slices.Equal(routes, documented) {
+		t.Fatalf("router and spec differ\nrouter: %v\nspec:   %v", routes, documented)
+	}
+}
+
+// DTO의 JSON 필드와 문서 스키마의 속성이 같아야 함
+func TestOpenAPIDTOsMatchSpec(t *testing.T) {
+	_, oa := newOpenAPIRouter(t)
+	schemas := oa.Doc().Components.Schemas
+
+	dtos := map[string]any{
+		"CreateUserRequest":      deliveryhttp.CreateUserRequest{},
+		"UpdateUserRequest":      deliveryhttp.UpdateUserRequest{},
+		"UserResponse":           deliveryhttp.UserResponse{},
+		"StatusChangeResponse":   deliveryhttp.StatusChangeResponse{},
+		"UserEventResponse":      deliveryhttp.UserEventResponse{},
+		"ErrorResponse":          deliveryhttp.ValidationErrorResponse{},
+		"ValidationErrorDetail":  deliveryhttp.ValidationErrorDetail{},
+		"AttributeErrorResponse": deliveryhttp.AttributeErrorResponse{},
+		"AttributeErrorDetail":   deliveryhttp.AttributeErrorDetail{},
+		"AvatarResponse":         deliveryhttp.AvatarResponse{},
+		"AvatarVariantDTO":       deliveryhttp.AvatarVariantDTO{},
+		"ImportRowResult":        deliveryhttp.ImportRowResult{},
+		"ImportSummaryResponse":  deliveryhttp.ImportSummaryResponse{},
+		"BatchCreateRequest":     deliveryhttp.BatchCreateRequest{},
+		"BatchIDsRequest":        deliveryhttp.BatchIDsRequest{},
+		"BatchItemResponse":      deliveryhttp.BatchItemResponse{},
+		"BatchResponse":          deliveryhttp.BatchResponse{},
+	}
+	for name, dto := range dtos {
+		ref, ok := schemas[name]
+		if !ok {
+			t.Errorf("schema %s not in spec", name)
+			continue
+		}
+		fields := jsonFields(reflect.TypeOf(dto))
+		var props []string
+		for prop := range ref.Value.Properties {
+			props = append(props, prop)
+		}
+		sort.Strings(fields)
+		sort.Strings(props)
+		if !slices.Equal(fields, props) {
+			t.Errorf("%s: DTO fields %v, spec properties %v", name, fields, props)
+		}
+	}
+}
+
+// jsonFields - 구조체의 JSON 필드 이름 (임베드한 구조체의 필드 포함)
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if f.Anonymous {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// contractServer - 요청을 보내고 응답이 문서와 맞는지 확인
+type contractServer struct {
+	t      *testing.T
+	router *chi.Mux
+	oa     *deliveryhttp.OpenAPI
+}
+
+func (s contractServer) do(method, target, contentType string, body []byte, header map[string]string) *http.Response {
+	s.t.Helper()
+	req, _ := http.NewRequest(method, "http://example.com"+target, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	resp := rec.Result()
+
+	route, params, ok := s.oa.Route(s.router, req)
+	if !ok {
+		s.t.Fatalf("%s %s: not in spec", method, target)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, PathParams: params, Route: route},
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Body:                   io.NopCloser(bytes.NewReader(data)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			// NDJSON/CSV/SSE/이미지 본문은 형식만 확인
+			ExcludeResponseBody: mediaType != "application/json",
+			MultiError:          true,
+		},
+	})
+	if err != nil {
+		s.t.Errorf("%s %s -> %d does not match spec: %v\n%s", method, target, resp.StatusCode, err, data)
+	}
+	return resp
+}
+
+func (s contractServer) json(method, target, body string, status int) map[string]any {
+	s.t.Helper()
+	var b []byte
+	ct := ""
+	if body != "" {
+		b, ct = []byte(body), "application/json"
+	}
+	resp := s.do(method, target, ct, b, nil)
+	if resp.StatusCode != status {
+		data, _ := io.ReadAll(resp.Body)
+		s.t.Fatalf("%s %s: got %d, want %d: %s", method, target, resp.StatusCode, status, data)
+	}
+	var out map[string]any
+	json.NewDecoder(resp.Body).Decode(&out)
+	return out
+}
+
+// 주요 흐름의 응답이 문서의 상태 코드/스키마와 맞아야 함
+func TestOpenAPIContract(t *testing.T) {
+	router, oa := newOpenAPIRouter(t)
+	s := contractServer{t: t, router: router, oa: oa}
+
+	user := s.json(http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, http.StatusCreated)
+	id := user["id"].(string)
+	s.json(http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, http.StatusConflict)
+	s.json(http.MethodPost, "/api/v1/users", `{"email":"lee@example.com","name":"Lee","status":"pending"}`, http.StatusCreated)
+
+	s.do(http.MethodGet, "/api/v1/users", "", nil, nil)
+	s.do(http.MethodGet, "/api/v1/users?status=active,pending", "", nil, nil)
+	s.do(http.MethodGet, "/api/v1/users/search?q=kim", "", nil, nil)
+	got := s.do(http.MethodGet, "/api/v1/users/"+id, "", nil, nil)
+	if resp := s.do(http.MethodGet, "/api/v1/users/"+id, "", nil, map[string]string{"If-None-Match": got.Header.Get("ETag")}); resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("conditional get: got %d", resp.StatusCode)
+	}
+	s.json(http.MethodGet, "/api/v1/users/missing", "", http.StatusNotFound)
+	s.json(http.MethodPut, "/api/v1/users/"+id, `{"name":"Kim Minsu"}`, http.StatusOK)
+	s.json(http.MethodPut, "/api/v1/users/"+id+"/attributes/profile", `{"nickname":"kim"}`, http.StatusBadRequest)
+	s.json(http.MethodDelete, "/api/v1/users/"+id+"/attributes/profile", "", http.StatusOK)
+
+	// 아바타
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	part, _ := mw.CreateFormFile("avatar", "avatar.png")
+	part.Write(pngImage(t, 64, 48))
+	mw.Close()
+	if resp := s.do(http.MethodPut, "/api/v1/users/"+id+"/avatar", mw.FormDataContentType(), form.Bytes(), nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("upload avatar: got %d", resp.StatusCode)
+	}
+	s.do(http.MethodGet, "/api/v1/users/"+id+"/avatar?variant=thumbnail", "", nil, nil)
+	s.do(http.MethodDelete, "/api/v1/users/"+id+"/avatar", "", nil, nil)
+
+	// 일괄 처리
+	s.do(http.MethodPost, "/api/v1/users:import?dry_run=true", "text/csv", []byte("email,name\nchoi@example.com,Choi\n"), nil)
+	s.do(http.MethodGet, "/api/v1/users:export?format=csv", "", nil, nil)
+	batch := s.json(http.MethodPost, "/api/v1/users:batchCreate", `{"items":[{"email":"park@example.com","name":"Park"},{"email":"","name":"X"}]}`, http.StatusOK)
+	if batch["succeeded"] != float64(1) {
+		t.Fatalf("batchCreate: %v", batch)
+	}
+	s.json(http.MethodPost, "/api/v1/users:batchGet", `{"ids":["`+id+`","missing"]}`, http.StatusOK)
+	s.json(http.MethodPost, "/api/v1/users:batchDelete", `{"ids":["missing"]}`, http.StatusOK)
+
+	s.do(http.MethodDelete, "/api/v1/users/"+id, "", nil, nil)
+}
+
+func TestOpenAPIRequestValidation(t *testing.T) {
+	h, _ := newOpenAPIRouter(t)
+	id := createUser(t, h)
+
+	tests := []struct {
+		name, method, target, body string
+		in, field                  string
+	}{
+		{"missing name", http.MethodPost, "/api/v1/users", `{"email":"lee@example.com"}`, "body", "/name"},
+		{"wrong type", http.MethodPost, "/api/v1/users", `{"email":"lee@example.com","name":7}`, "body", "/name"},
+		{"bad initial status", http.MethodPost, "/api/v1/users", `{"email":"lee@example.com","name":"Lee","status":"suspended"}`, "body", "/status"},
+		{"bad status filter", http.MethodGet, "/api/v1/users?status=active,gone", "", "query", "status"},
+		{"missing query", http.MethodGet, "/api/v1/users/search", "", "query", "q"},
+		{"bad limit", http.MethodGet, "/api/v1/users/search?q=kim&limit=0", "", "query", "limit"},
+		{"bad variant", http.MethodGet, "/api/v1/users/" + id + "/avatar?variant=huge", "", "query", "variant"},
+		{"bad format", http.MethodGet, "/api/v1/users:export?format=xml", "", "query", "format"},
+		{"empty batch", http.MethodPost, "/api/v1/users:batchGet", `{"ids":[]}`, "body", "/ids"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, tt.method, tt.target, tt.body, nil)
+			var resp deliveryhttp.ValidationErrorResponse
+			json.NewDecoder(rec.Body).Decode(&resp)
+			if rec.Code != http.StatusBadRequest || resp.Code != "invalid_request" || len(resp.Details) == 0 {
+				t.Fatalf("got %d: %+v", rec.Code, resp)
+			}
+			if d := resp.Details[0]; d.In != tt.in || d.Name != tt.field || d.Message == "" {
+				t.Fatalf("details = %+v; want in=%s name=%s", resp.Details, tt.in, tt.field)
+			}
+		})
+	}
+
+	// 본문 형식 에러는 위치를 알려 주는 핸들러의 코드를 그대로 사용
+	passthrough := []struct {
+		name, body string
+		code       string
+	}{
+		{"malformed", `{"email":`, "malformed_json"},
+		{"empty", ``, "empty_body"},
+		{"unknown field", `{"email":"lee@example.com","name":"Lee","role":"admin"}`, "unknown_field"},
+	}
+	for _, tt := range passthrough {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, http.MethodPost, "/api/v1/users", tt.body, nil)
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"code":"`+tt.code+`"`) {
+				t.Fatalf("got %d: %s; want %s", rec.Code, rec.Body, tt.code)
+			}
+		})
+	}
+
+	// 검증을 통과한 본문은 핸들러가 그대로 읽음
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Kim Minsu"}`, nil); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Kim Minsu") {
+		t.Fatalf("update: got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestOpenAPIServeSpec(t *testing.T) {
+	h, _ := newOpenAPIRouter(t)
+
+	rec := serve(h, http.MethodGet, "/api/v1/openapi.yaml", "", nil)
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), api.Spec()) {
+		t.Fatalf("openapi.yaml: got %d", rec.Code)
+	}
+
+	rec = serve(h, http.MethodGet, "/api/v1/openapi.json", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("openapi.json: got %d", rec.Code)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	if err != nil || doc.Paths.Find("/api/v1/users/{id}") == nil {
+		t.Fatalf("openapi.json: %v", err)
+	}
+}
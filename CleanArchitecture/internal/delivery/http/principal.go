@@ -0,0 +1,45 @@
+package http
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal - 인증된 주체(사용자/서비스 ID)를 컨텍스트에 저장
+// 인증 미들웨어가 호출하며, 속도 제한 등 주체별 정책에서 사용
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom - 컨텍스트에서 인증된 주체 조회
+func PrincipalFrom(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok && principal != ""
+}
+
+type tenantClaimKey struct{}
+
+// WithTenantClaim - 인증 토큰의 테넌트 클레임을 컨텍스트에 저장
+// 인증 미들웨어가 호출하며, 테넌트 확인에서 헤더/서브도메인보다 우선
+func WithTenantClaim(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantClaimKey{}, tenantID)
+}
+
+// TenantClaimFrom - 컨텍스트에서 토큰의 테넌트 클레임 조회
+func TenantClaimFrom(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantClaimKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+type apiKeyKey struct{}
+
+// WithAPIKey - 인증 미들웨어가 확인한 API 키의 식별자를 컨텍스트에 저장
+// 확인하지 않은 X-API-Key 헤더는 요청마다 바꿀 수 있으므로 속도 제한 등 클라이언트 구분에 쓰지 않음
+func WithAPIKey(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, keyID)
+}
+
+// APIKeyFrom - 컨텍스트에서 인증된 API 키 식별자 조회
+func APIKeyFrom(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(apiKeyKey{}).(string)
+	return keyID, ok && keyID != ""
+}
@@ -0,0 +1,299 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/privacy"
+)
+
+// PrivacyHandler - 개인정보 내보내기/삭제 요청 HTTP 핸들러 (관리자용)
+type PrivacyHandler struct {
+	privacy    *privacy.Service
+	adminToken string
+}
+
+// NewPrivacyHandler - PrivacyHandler 생성자
+// adminToken이 비어 있으면 모든 요청을 거부
+func NewPrivacyHandler(svc *privacy.Service, adminToken string) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacy:    svc,
+		adminToken: adminToken,
+	}
+}
+
+// ExportRequest - 내보내기 요청 DTO (본문 생략 가능)
+type ExportRequest struct {
+	Format string `json:"format"` // zip(기본) 또는 json
+}
+
+// ErasureRequest - 삭제 요청 DTO (본문 생략 가능)
+type ErasureRequest struct {
+	Mode string `json:"mode"` // delete 또는 anonymize (비어 있으면 보존 규칙의 기본값)
+}
+
+// PrivacyRequestResponse - 요청 응답 DTO
+type PrivacyRequestResponse struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Kind        string           `json:"kind"`
+	Status      string           `json:"status"`
+	Actor       string           `json:"actor"`
+	Error       string           `json:"error,omitempty"`
+	Format      string           `json:"format,omitempty"`
+	Mode        string           `json:"mode,omitempty"`
+	Archive     *ArchiveResponse `json:"archive,omitempty"`
+	Receipt     *ReceiptDTO      `json:"receipt,omitempty"`
+	RequestedAt string           `json:"requested_at"`
+	StartedAt   string           `json:"started_at,omitempty"`
+	CompletedAt string           `json:"completed_at,omitempty"`
+}
+
+// ArchiveResponse - 내보내기 파일 정보 DTO
+type ArchiveResponse struct {
+	URL       string `json:"url"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	Files     int    `json:"files"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// ReceiptDTO - 삭제 완료 기록 DTO (응답과 검증 요청에 같은 형식 사용)
+type ReceiptDTO struct {
+	RequestID     string           `json:"request_id"`
+	TenantID      string           `json:"tenant_id"`
+	UserID        string           `json:"user_id"`
+	RequestedMode string           `json:"requested_mode"`
+	Mode          string           `json:"mode"`
+	RetentionRule string           `json:"retention_rule"`
+	Steps         []ReceiptStepDTO `json:"steps"`
+	CompletedAt   time.Time        `json:"completed_at"` // 검증에 나노초까지 필요하므로 time.Time 그대로
+	Digest        string           `json:"digest"`
+	Signature     string           `json:"signature"`
+}
+
+// ReceiptStepDTO - 완료 기록의 소스별 처리 결과 DTO
+type ReceiptStepDTO struct {
+	Source string `json:"source"`
+	Action string `json:"action"`
+	Items  int    `json:"items"`
+}
+
+// ReceiptVerifyResponse - 완료 기록 검증 응답 DTO
+type ReceiptVerifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+func toPrivacyRequestResponse(req *privacy.Request) PrivacyRequestResponse {
+	resp := PrivacyRequestResponse{
+		ID:          req.ID,
+		UserID:      req.UserID,
+		Kind:        string(req.Kind),
+		Status:      string(req.Status),
+		Actor:       req.Actor,
+		Error:       req.Error,
+		Format:      string(req.Format),
+		Mode:        string(req.Mode),
+		RequestedAt: req.RequestedAt.Format(time.RFC3339),
+	}
+	if !req.StartedAt.IsZero() {
+		resp.StartedAt = req.StartedAt.Format(time.RFC3339)
+	}
+	if !req.CompletedAt.IsZero() {
+		resp.CompletedAt = req.CompletedAt.Format(time.RFC3339)
+	}
+	if req.Archive != nil && req.Status == privacy.StatusCompleted {
+		resp.Archive = &ArchiveResponse{
+			URL:       "/api/v1/admin/privacy/requests/" + req.ID + "/archive",
+			Size:      req.Archive.Size,
+			SHA256:    req.Archive.SHA256,
+			Files:     req.Archive.Files,
+			ExpiresAt: req.Archive.ExpiresAt.Format(time.RFC3339),
+		}
+	}
+	if req.Receipt != nil {
+		resp.Receipt = toReceiptDTO(req.Receipt)
+	}
+	return resp
+}
+
+func toReceiptDTO(r *privacy.Receipt) *ReceiptDTO {
+	dto := &ReceiptDTO{
+		RequestID:     r.RequestID,
+		TenantID:      r.TenantID,
+		UserID:        r.UserID,
+		RequestedMode: string(r.RequestedMode),
+		Mode:          string(r.Mode),
+		RetentionRule: r.RetentionRule,
+		Steps:         make([]ReceiptStepDTO, len(r.Steps)),
+		CompletedAt:   r.CompletedAt,
+		Digest:        r.Digest,
+		Signature:     r.Signature,
+	}
+	for i, s := range r.Steps {
+		dto.Steps[i] = ReceiptStepDTO{Source: s.Source, Action: s.Action, Items: s.Items}
+	}
+	return dto
+}
+
+func (dto *ReceiptDTO) toReceipt() *privacy.Receipt {
+	r := &privacy.Receipt{
+		RequestID:     dto.RequestID,
+		TenantID:      dto.TenantID,
+		UserID:        dto.UserID,
+		RequestedMode: domain.ErasureMode(dto.RequestedMode),
+		Mode:          domain.ErasureMode(dto.Mode),
+		RetentionRule: dto.RetentionRule,
+		Steps:         make([]privacy.Step, len(dto.Steps)),
+		CompletedAt:   dto.CompletedAt,
+		Digest:        dto.Digest,
+		Signature:     dto.Signature,
+	}
+	for i, s := range dto.Steps {
+		r.Steps[i] = privacy.Step{Source: s.Source, Action: s.Action, Items: s.Items}
+	}
+	return r
+}
+
+// RequireAdmin - Authorization: Bearer <관리자 토큰> 확인 미들웨어
+func (h *PrivacyHandler) RequireAdmin(next http.Handler) http.Handler {
+	return requireAdmin(h.adminToken, next)
+}
+
+// respondPrivacyError - 개인정보 요청 에러를 HTTP 상태로 변환
+func respondPrivacyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, privacy.ErrRequestNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrTenantRequired),
+		errors.Is(err, domain.ErrInvalidErasureMode),
+		errors.Is(err, privacy.ErrInvalidArchiveFormat):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrCrossTenant):
+		respondError(w, r, http.StatusForbidden, err)
+	case errors.Is(err, privacy.ErrRequestInProgress),
+		errors.Is(err, privacy.ErrArchiveNotReady),
+		errors.Is(err, domain.ErrUserModified):
+		respondError(w, r, http.StatusConflict, err)
+	case errors.Is(err, privacy.ErrArchiveExpired):
+		respondError(w, r, http.StatusGone, err)
+	case errors.Is(err, privacy.ErrExportUnavailable):
+		respondError(w, r, http.StatusNotImplemented, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// privacyActor - 요청 기록에 남길 주체 (인증된 주체가 없으면 admin)
+func privacyActor(r *http.Request) string {
+	if actor, ok := PrincipalFrom(r.Context()); ok {
+		return actor
+	}
+	return defaultStatusActor
+}
+
+// RequestExport - 데이터 내보내기 요청 (POST /api/v1/admin/privacy/users/{id}/exports)
+// 백그라운드에서 파일을 만들고 202를 반환 (Location의 요청을 조회해 완료 확인)
+func (h *PrivacyHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	var body ExportRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &body); err != nil {
+			respondDecodeError(w, r, err)
+			return
+		}
+	}
+
+	req, err := h.privacy.RequestExport(r.Context(), chi.URLParam(r, "id"), privacy.Format(body.Format), privacyActor(r))
+	if err != nil {
+		respondPrivacyError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/admin/privacy/requests/"+req.ID)
+	respondJSON(w, http.StatusAccepted, toPrivacyRequestResponse(req))
+}
+
+// RequestErasure - 개인정보 삭제 요청 (POST /api/v1/admin/privacy/users/{id}/erasures)
+// 백그라운드에서 처리하고 202를 반환 (완료된 요청에 완료 기록이 붙음)
+func (h *PrivacyHandler) RequestErasure(w http.ResponseWriter, r *http.Request) {
+	var body ErasureRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &body); err != nil {
+			respondDecodeError(w, r, err)
+			return
+		}
+	}
+
+	req, err := h.privacy.RequestErasure(r.Context(), chi.URLParam(r, "id"), domain.ErasureMode(body.Mode), privacyActor(r))
+	if err != nil {
+		respondPrivacyError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/admin/privacy/requests/"+req.ID)
+	respondJSON(w, http.StatusAccepted, toPrivacyRequestResponse(req))
+}
+
+// ListUserRequests - 사용자의 요청 목록 (GET /api/v1/admin/privacy/users/{id}/requests, 오래된 순)
+func (h *PrivacyHandler) ListUserRequests(w http.ResponseWriter, r *http.Request) {
+	reqs, err := h.privacy.Requests(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondPrivacyError(w, r, err)
+		return
+	}
+	responses := make([]PrivacyRequestResponse, len(reqs))
+	for i, req := range reqs {
+		responses[i] = toPrivacyRequestResponse(req)
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// GetRequest - 요청 조회 (GET /api/v1/admin/privacy/requests/{requestID})
+func (h *PrivacyHandler) GetRequest(w http.ResponseWriter, r *http.Request) {
+	req, err := h.privacy.Request(r.Context(), chi.URLParam(r, "requestID"))
+	if err != nil {
+		respondPrivacyError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toPrivacyRequestResponse(req))
+}
+
+// DownloadArchive - 내보내기 파일 받기 (GET /api/v1/admin/privacy/requests/{requestID}/archive)
+// 처리 중이면 409, 보관 기간이 지났으면 410
+func (h *PrivacyHandler) DownloadArchive(w http.ResponseWriter, r *http.Request) {
+	rc, req, err := h.privacy.OpenArchive(r.Context(), chi.URLParam(r, "requestID"))
+	if err != nil {
+		respondPrivacyError(w, r, err)
+		return
+	}
+	defer rc.Close()
+
+	header := w.Header()
+	header.Set("Content-Type", req.Format.ContentType())
+	header.Set("Content-Length", strconv.FormatInt(req.Archive.Size, 10))
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s-%s.%s"`, req.UserID, req.ID, req.Format))
+	header.Set("Cache-Control", "no-store")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("X-Checksum-SHA256", req.Archive.SHA256)
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		io.Copy(w, rc)
+	}
+}
+
+// VerifyReceipt - 삭제 완료 기록 검증 (POST /api/v1/admin/privacy/receipts/verify)
+// 본문은 요청 응답의 receipt 그대로이며, 내용이 바뀌었거나 다른 키로 서명됐으면 valid=false
+func (h *PrivacyHandler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
+	var body ReceiptDTO
+	if err := decodeJSON(w, r, &body); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, ReceiptVerifyResponse{Valid: h.privacy.VerifyReceipt(body.toReceipt())})
+}
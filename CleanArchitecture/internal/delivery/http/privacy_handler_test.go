@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/privacy"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func newPrivacyRouter(t *testing.T) (http.Handler, *privacy.Service) {
+	t.Helper()
+	blobs, err := file.NewBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewBlobStore: %v", err)
+	}
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithBlobStore(blobs))
+	svc := privacy.NewService(privacy.NewMemoryStore(), users, blobs, privacy.Config{ReceiptKey: []byte("receipt-key")})
+	return deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+		deliveryhttp.WithPrivacy(deliveryhttp.NewPrivacyHandler(svc, adminToken)),
+	), svc
+}
+
+func decodePrivacyRequest(t *testing.T, body []byte) deliveryhttp.PrivacyRequestResponse {
+	t.Helper()
+	var req deliveryhttp.PrivacyRequestResponse
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return req
+}
+
+func TestPrivacyExportAndErasure(t *testing.T) {
+	h, svc := newPrivacyRouter(t)
+	admin := map[string]string{"Authorization": "Bearer " + adminToken}
+	id := createUser(t, h)
+	base := "/api/v1/admin/privacy/users/" + id
+
+	if rec := serve(h, http.MethodPost, base+"/exports", "", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("export without token: got %d, want 401", rec.Code)
+	}
+	if rec := serve(h, http.MethodPost, base+"/exports", `{"format":"tar"}`, admin); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown format: got %d, want 400", rec.Code)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/privacy/users/missing/exports", "", admin); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown user: got %d, want 404", rec.Code)
+	}
+
+	rec := serve(h, http.MethodPost, base+"/exports", "", admin)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("export: got %d: %s", rec.Code, rec.Body)
+	}
+	export := decodePrivacyRequest(t, rec.Body.Bytes())
+	if export.Status != "pending" || export.Format != "zip" || rec.Header().Get("Location") != "/api/v1/admin/privacy/requests/"+export.ID {
+		t.Fatalf("export request = %+v (Location %q)", export, rec.Header().Get("Location"))
+	}
+	archiveURL := "/api/v1/admin/privacy/requests/" + export.ID + "/archive"
+	if rec := serve(h, http.MethodGet, archiveURL, "", admin); rec.Code != http.StatusConflict {
+		t.Fatalf("download before processing: got %d, want 409", rec.Code)
+	}
+
+	svc.ProcessPending(context.Background())
+	rec = serve(h, http.MethodGet, "/api/v1/admin/privacy/requests/"+export.ID, "", admin)
+	export = decodePrivacyRequest(t, rec.Body.Bytes())
+	if export.Status != "completed" || export.Archive == nil || export.Archive.URL != archiveURL {
+		t.Fatalf("completed export = %+v", export)
+	}
+
+	rec = serve(h, http.MethodGet, archiveURL, "", admin)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" ||
+		rec.Header().Get("X-Checksum-SHA256") != export.Archive.SHA256 {
+		t.Fatalf("download: got %d %v", rec.Code, rec.Header())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	if len(zr.File) != export.Archive.Files+1 {
+		t.Fatalf("archive entries: got %d, want %d + manifest", len(zr.File), export.Archive.Files)
+	}
+
+	rec = serve(h, http.MethodPost, base+"/erasures", `{"mode":"delete"}`, admin)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("erasure: got %d: %s", rec.Code, rec.Body)
+	}
+	erasure := decodePrivacyRequest(t, rec.Body.Bytes())
+	if rec := serve(h, http.MethodPost, base+"/erasures", "", admin); rec.Code != http.StatusConflict {
+		t.Fatalf("second erasure while pending: got %d, want 409", rec.Code)
+	}
+
+	svc.ProcessPending(context.Background())
+	if rec := serve(h, http.MethodGet, "/api/v1/users/"+id, "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("user after erasure: got %d, want 404", rec.Code)
+	}
+	if rec := serve(h, http.MethodGet, archiveURL, "", admin); rec.Code != http.StatusGone {
+		t.Fatalf("download after erasure: got %d, want 410", rec.Code)
+	}
+
+	// 삭제된 사용자의 요청 기록은 남음
+	rec = serve(h, http.MethodGet, base+"/requests", "", admin)
+	var listed []deliveryhttp.PrivacyRequestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Status != "expired" || listed[1].ID != erasure.ID || listed[1].Receipt == nil {
+		t.Fatalf("requests after erasure = %+v", listed)
+	}
+
+	receipt := listed[1].Receipt
+	body, _ := json.Marshal(receipt)
+	rec = serve(h, http.MethodPost, "/api/v1/admin/privacy/receipts/verify", string(body), admin)
+	var verified deliveryhttp.ReceiptVerifyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&verified); err != nil || !verified.Valid {
+		t.Fatalf("verify receipt: got %d %+v, %v", rec.Code, verified, err)
+	}
+
+	receipt.Steps[0].Items++
+	body, _ = json.Marshal(receipt)
+	rec = serve(h, http.MethodPost, "/api/v1/admin/privacy/receipts/verify", string(body), admin)
+	verified = deliveryhttp.ReceiptVerifyResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&verified); err != nil || verified.Valid {
+		t.Fatalf("verify tampered receipt: got %d %+v, %v", rec.Code, verified, err)
+	}
+}
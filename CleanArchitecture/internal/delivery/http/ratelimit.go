@@ -0,0 +1,184 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/ratelimit"
+)
+
+// RateLimitRule - 라우트 패턴별 속도 제한 규칙
+type RateLimitRule struct {
+	Method  string // 빈 문자열이면 모든 메서드
+	Pattern string // chi 라우트 패턴 (예: "/api/v1/users/{id}")
+	Limit   ratelimit.Limit
+}
+
+// RateLimitKeyFunc - 요청에서 클라이언트 식별 키 추출
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitConfig - 속도 제한 설정
+type RateLimitConfig struct {
+	Rules   []RateLimitRule
+	Default *ratelimit.Limit // 규칙에 없는 라우트 (nil이면 제한 없음)
+	KeyFunc RateLimitKeyFunc // nil이면 KeyByClient
+}
+
+// RateLimiter - 클라이언트별 속도 제한 미들웨어
+type RateLimiter struct {
+	limiter *ratelimit.Limiter
+	rules   []RateLimitRule
+	def     *ratelimit.Limit
+	keyFunc RateLimitKeyFunc
+}
+
+// NewRateLimiter - RateLimiter 생성자 (규칙과 기본값의 제한이 잘못되면 ratelimit.ErrInvalidLimit)
+func NewRateLimiter(store ratelimit.Store, cfg RateLimitConfig) (*RateLimiter, error) {
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = KeyByClient
+	}
+
+	rules := make([]RateLimitRule, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		if err := rule.Limit.Validate(); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", rule.Method, rule.Pattern, err)
+		}
+		rule.Method = strings.ToUpper(rule.Method)
+		rule.Pattern = normalizePattern(rule.Pattern)
+		rules[i] = rule
+	}
+	if cfg.Default != nil {
+		if err := cfg.Default.Validate(); err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+	}
+
+	return &RateLimiter{
+		limiter: ratelimit.NewLimiter(store),
+		rules:   rules,
+		def:     cfg.Default,
+		keyFunc: keyFunc,
+	}, nil
+}
+
+// KeyByClient - 인증 주체 → 인증된 API 키 → 실제 IP 순으로 클라이언트 키 결정
+func KeyByClient(r *http.Request) string {
+	if principal, ok := PrincipalFrom(r.Context()); ok {
+		return "principal:" + principal
+	}
+	if key := KeyByAPIKey(r); key != "" {
+		return key
+	}
+	return KeyByRealIP(r)
+}
+
+// KeyByAPIKey - 인증 미들웨어가 확인한 API 키 기준 (WithAPIKey, 식별자 대신 해시 저장)
+// 확인되지 않은 X-API-Key 헤더는 무시 (요청마다 새 키를 보내 새 버킷을 받을 수 있으므로)
+func KeyByAPIKey(r *http.Request) string {
+	keyID, ok := APIKeyFrom(r.Context())
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(keyID))
+	return "apikey:" + hex.EncodeToString(sum[:8])
+}
+
+// KeyByRealIP - 클라이언트 IP 기준
+// 프록시 뒤라면 TrustedRealIP가 먼저 RemoteAddr를 바꿔 놓아야 함 (WithTrustedProxies)
+func KeyByRealIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// normalizePattern - 끝의 "/" 제거 ("/api/v1/users/" == "/api/v1/users")
+func normalizePattern(pattern string) string {
+	if len(pattern) > 1 {
+		return strings.TrimSuffix(pattern, "/")
+	}
+	return pattern
+}
+
+// match - 요청 메서드와 라우트 패턴에 맞는 제한 찾기
+func (rl *RateLimiter) match(method, pattern string) (ratelimit.Limit, string, bool) {
+	pattern = normalizePattern(pattern)
+	for _, rule := range rl.rules {
+		if rule.Pattern != pattern {
+			continue
+		}
+		if rule.Method != "" && rule.Method != method {
+			continue
+		}
+		ruleMethod := rule.Method
+		if ruleMethod == "" {
+			ruleMethod = "*"
+		}
+		return rule.Limit, ruleMethod + " " + rule.Pattern, true
+	}
+	if rl.def != nil {
+		return *rl.def, "default", true
+	}
+	return ratelimit.Limit{}, "", false
+}
+
+// Middleware - chi 미들웨어
+// 라우팅 전에 실행되므로 routes로 요청의 라우트 패턴을 미리 찾아 규칙 적용
+func (rl *RateLimiter) Middleware(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !routes.Match(rctx, r.Method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limit, bucket, ok := rl.match(r.Method, rctx.RoutePattern())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := rl.limiter.Allow(r.Context(), bucket+"|"+rl.keyFunc(r), limit)
+			if err != nil {
+				// 저장소 장애 시 요청을 막지 않음 (fail open)
+				log.Printf("속도 제한 저장소 오류: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+			h.Set("RateLimit-Policy", strconv.Itoa(res.Limit)+";w="+strconv.Itoa(ceilSeconds(limit.Period)))
+
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				respondError(w, r, http.StatusTooManyRequests, errRateLimited)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ceilSeconds - 헤더용 초 단위 (올림, 최소 0)
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,154 @@
+package http_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/ratelimit"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// newRateLimitedRouter - 모든 요청을 클라이언트당 분당 1회로 제한하는 라우터
+func newRateLimitedRouter(t *testing.T, opts ...deliveryhttp.RouterOption) http.Handler {
+	t.Helper()
+	limit := ratelimit.PerMinute(1)
+	rl, err := deliveryhttp.NewRateLimiter(ratelimit.NewMemoryStore(), deliveryhttp.RateLimitConfig{Default: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := deliveryhttp.NewUserHandler(usecase.NewUserUseCase(memory.NewUserRepository()))
+	return deliveryhttp.NewRouter(handler, append(opts, deliveryhttp.WithRateLimiter(rl))...)
+}
+
+func serveFrom(h http.Handler, remoteAddr string, header map[string]string) int {
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestNewRateLimiterRejectsInvalidLimit(t *testing.T) {
+	zero := ratelimit.Limit{Rate: 0, Period: time.Minute}
+	tests := []struct {
+		name string
+		cfg  deliveryhttp.RateLimitConfig
+	}{
+		{"rule", deliveryhttp.RateLimitConfig{Rules: []deliveryhttp.RateLimitRule{{Method: "POST", Pattern: "/api/v1/users", Limit: zero}}}},
+		{"default", deliveryhttp.RateLimitConfig{Default: &zero}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := deliveryhttp.NewRateLimiter(ratelimit.NewMemoryStore(), tt.cfg); !errors.Is(err, ratelimit.ErrInvalidLimit) {
+				t.Fatalf("NewRateLimiter() = %v, want ErrInvalidLimit", err)
+			}
+		})
+	}
+}
+
+// 확인하지 않은 X-API-Key를 바꿔 보내도 새 버킷을 받지 못함
+func TestRateLimitIgnoresUnauthenticatedAPIKey(t *testing.T) {
+	h := newRateLimitedRouter(t)
+
+	if code := serveFrom(h, "192.0.2.1:1234", map[string]string{"X-API-Key": "a"}); code != http.StatusOK {
+		t.Fatalf("first: got %d", code)
+	}
+	if code := serveFrom(h, "192.0.2.1:1234", map[string]string{"X-API-Key": "b"}); code != http.StatusTooManyRequests {
+		t.Fatalf("forged key: got %d, want 429", code)
+	}
+}
+
+// 인증 미들웨어가 확인한 API 키는 키별 버킷
+func TestRateLimitByAuthenticatedAPIKey(t *testing.T) {
+	router := newRateLimitedRouter(t)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := deliveryhttp.WithAPIKey(r.Context(), r.Header.Get("X-API-Key"))
+		router.ServeHTTP(w, r.WithContext(ctx))
+	})
+
+	for _, key := range []string{"key-a", "key-b"} {
+		if code := serveFrom(h, "192.0.2.1:1234", map[string]string{"X-API-Key": key}); code != http.StatusOK {
+			t.Fatalf("%s: got %d", key, code)
+		}
+	}
+	if code := serveFrom(h, "192.0.2.1:1234", map[string]string{"X-API-Key": "key-a"}); code != http.StatusTooManyRequests {
+		t.Fatalf("key-a again: got %d, want 429", code)
+	}
+}
+
+func TestRateLimitTrustedProxies(t *testing.T) {
+	proxies, err := deliveryhttp.ParseTrustedProxies("10.0.0.0/8, 192.168.1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("untrusted peer headers ignored", func(t *testing.T) {
+		h := newRateLimitedRouter(t, deliveryhttp.WithTrustedProxies(proxies))
+		serveFrom(h, "198.51.100.7:1234", map[string]string{"X-Forwarded-For": "203.0.113.1"})
+		code := serveFrom(h, "198.51.100.7:1234", map[string]string{"X-Forwarded-For": "203.0.113.2", "X-Real-IP": "203.0.113.3"})
+		if code != http.StatusTooManyRequests {
+			t.Fatalf("spoofed header: got %d, want 429", code)
+		}
+	})
+
+	t.Run("trusted proxy forwards client", func(t *testing.T) {
+		h := newRateLimitedRouter(t, deliveryhttp.WithTrustedProxies(proxies))
+		for _, client := range []string{"203.0.113.1", "203.0.113.2"} {
+			if code := serveFrom(h, "10.1.2.3:1234", map[string]string{"X-Forwarded-For": client}); code != http.StatusOK {
+				t.Fatalf("%s: got %d", client, code)
+			}
+		}
+		if code := serveFrom(h, "192.168.1.10:1234", map[string]string{"X-Real-IP": "203.0.113.1"}); code != http.StatusTooManyRequests {
+			t.Fatalf("X-Real-IP via proxy: got %d, want 429", code)
+		}
+	})
+
+	t.Run("client-supplied hops before the proxy ignored", func(t *testing.T) {
+		h := newRateLimitedRouter(t, deliveryhttp.WithTrustedProxies(proxies))
+		serveFrom(h, "10.1.2.3:1234", map[string]string{"X-Forwarded-For": "1.1.1.1, 203.0.113.9"})
+		code := serveFrom(h, "10.1.2.3:1234", map[string]string{"X-Forwarded-For": "2.2.2.2, 203.0.113.9, 10.9.9.9"})
+		if code != http.StatusTooManyRequests {
+			t.Fatalf("forged leftmost hop: got %d, want 429", code)
+		}
+	})
+
+	t.Run("no proxies configured", func(t *testing.T) {
+		h := newRateLimitedRouter(t)
+		serveFrom(h, "10.1.2.3:1234", map[string]string{"X-Forwarded-For": "203.0.113.1"})
+		if code := serveFrom(h, "10.1.2.3:1234", map[string]string{"X-Forwarded-For": "203.0.113.2"}); code != http.StatusTooManyRequests {
+			t.Fatalf("got %d, want 429", code)
+		}
+	})
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	got, err := deliveryhttp.ParseTrustedProxies(" 10.0.0.0/8 ,192.168.1.10,,::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+		netip.MustParsePrefix("::1/128"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if _, err := deliveryhttp.ParseTrustedProxies("10.0.0.0/33"); err == nil {
+		t.Fatal("expected error for invalid prefix")
+	}
+}
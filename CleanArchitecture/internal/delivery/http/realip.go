@@ -0,0 +1,96 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ParseTrustedProxies - "10.0.0.0/8, 192.168.1.10" 형식의 프록시 목록 (주소 하나는 /32 또는 /128)
+func ParseTrustedProxies(s string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if strings.Contains(field, "/") {
+			prefix, err := netip.ParsePrefix(field)
+			if err != nil {
+				return nil, fmt.Errorf("신뢰할 프록시 %q: %w", field, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(field)
+		if err != nil {
+			return nil, fmt.Errorf("신뢰할 프록시 %q: %w", field, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// TrustedRealIP - 신뢰할 프록시에서 온 요청만 X-Forwarded-For / X-Real-IP로 RemoteAddr를 바꾸는 미들웨어
+// 그 밖의 연결이 보낸 헤더는 무시함 (누구나 헤더를 바꿔 속도 제한 버킷을 고를 수 있으므로)
+// X-Forwarded-For는 오른쪽부터 신뢰할 프록시를 건너뛰고 처음 만나는 주소를 클라이언트로 봄
+func TrustedRealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if peer, ok := remoteAddr(r.RemoteAddr); ok && isTrusted(trusted, peer) {
+				if client, ok := forwardedClient(r, trusted); ok {
+					r.RemoteAddr = client.String()
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedClient - 신뢰할 프록시가 기록한 클라이언트 주소
+func forwardedClient(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	if len(hops) == 0 {
+		addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+		return addr.Unmap(), err == nil
+	}
+
+	var client netip.Addr
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// 형식이 틀린 값부터는 믿지 않고 마지막으로 확인한 주소 사용
+			break
+		}
+		client = addr.Unmap()
+		if !isTrusted(trusted, client) {
+			break
+		}
+	}
+	return client, client.IsValid()
+}
+
+// remoteAddr - "host:port" 또는 포트 없는 주소
+func remoteAddr(s string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(s)
+	if err != nil {
+		host = s
+	}
+	addr, err := netip.ParseAddr(host)
+	return addr.Unmap(), err == nil
+}
+
+func isTrusted(trusted []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
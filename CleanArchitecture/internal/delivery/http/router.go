@@ -2,19 +2,134 @@ package http
 
 import (
 	"net/http"
+	"net/netip"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+// RouterOption - 선택적 라우터 설정
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	rateLimiter *RateLimiter
+	idempotency *Idempotency
+	tenants     *TenantResolver
+	webhooks    *WebhookHandler
+	schemas     *AttributeSchemaHandler
+	jobs        *JobHandler
+	userAdmin   *UserAdminHandler
+	privacy     *PrivacyHandler
+	events      *UserEventsHandler
+	openAPI     *OpenAPI
+	proxies     []netip.Prefix
+}
+
+// WithRateLimiter - 속도 제한 미들웨어 사용
+func WithRateLimiter(rl *RateLimiter) RouterOption {
+	return func(o *routerOptions) {
+		o.rateLimiter = rl
+	}
+}
+
+// WithTrustedProxies - 이 프록시에서 온 요청만 X-Forwarded-For / X-Real-IP로 클라이언트 IP 확인
+// 지정하지 않으면 헤더를 무시하고 연결 주소만 사용
+func WithTrustedProxies(prefixes []netip.Prefix) RouterOption {
+	return func(o *routerOptions) {
+		o.proxies = prefixes
+	}
+}
+
+// WithIdempotency - 사용자 생성과 일괄 생성에 Idempotency-Key 지원
+func WithIdempotency(m *Idempotency) RouterOption {
+	return func(o *routerOptions) {
+		o.idempotency = m
+	}
+}
+
+// WithTenantResolver - 요청마다 테넌트 확인
+func WithTenantResolver(tr *TenantResolver) RouterOption {
+	return func(o *routerOptions) {
+		o.tenants = tr
+	}
+}
+
+// WithWebhooks - 웹훅 구독 관리 API 등록 (관리자 토큰 필요)
+func WithWebhooks(h *WebhookHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.webhooks = h
+	}
+}
+
+// WithAttributeSchemas - 속성 스키마 관리 API 등록 (관리자 토큰 필요)
+func WithAttributeSchemas(h *AttributeSchemaHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.schemas = h
+	}
+}
+
+// WithJobs - 주기 작업 관리 API 등록 (관리자 토큰 필요)
+func WithJobs(h *JobHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.jobs = h
+	}
+}
+
+// WithUserAdmin - 계정 상태 관리 API 등록 (관리자 토큰 필요)
+func WithUserAdmin(h *UserAdminHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.userAdmin = h
+	}
+}
+
+// WithPrivacy - 개인정보 내보내기/삭제 API 등록 (관리자 토큰 필요)
+func WithPrivacy(h *PrivacyHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.privacy = h
+	}
+}
+
+// WithUserEvents - 사용자 변경 피드(SSE) 등록
+func WithUserEvents(h *UserEventsHandler) RouterOption {
+	return func(o *routerOptions) {
+		o.events = h
+	}
+}
+
+// WithOpenAPI - OpenAPI 문서로 /api/v1/users 요청 검증, 문서 제공 (GET /api/v1/openapi.yaml, /api/v1/openapi.json)
+func WithOpenAPI(o *OpenAPI) RouterOption {
+	return func(opts *routerOptions) {
+		opts.openAPI = o
+	}
+}
+
 // NewRouter - HTTP 라우터 설정
-func NewRouter(userHandler *UserHandler) *chi.Mux {
+func NewRouter(userHandler *UserHandler, opts ...RouterOption) *chi.Mux {
+	var o routerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	r := chi.NewRouter()
 
 	// 미들웨어
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
+	if len(o.proxies) > 0 {
+		r.Use(TrustedRealIP(o.proxies))
+	}
+	r.Use(Localize)
+	if o.tenants != nil {
+		r.Use(o.tenants.Middleware)
+	}
+	if o.rateLimiter != nil {
+		r.Use(o.rateLimiter.Middleware(r))
+	}
+	if o.openAPI != nil {
+		// 속도 제한 뒤에 두어 거부된 요청의 본문은 읽지 않음
+		r.Use(o.openAPI.Middleware(r))
+	}
 
 	// 헬스 체크
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -22,15 +137,100 @@ func NewRouter(userHandler *UserHandler) *chi.Mux {
 		w.Write([]byte(`{"status":"ok"}`))
 	})
 
+	if o.openAPI != nil {
+		r.Get("/api/v1/openapi.yaml", o.openAPI.ServeYAML)
+		r.Get("/api/v1/openapi.json", o.openAPI.ServeJSON)
+	}
+
+	// 일괄 가져오기/내보내기 (users 하위 라우트와 겹치지 않도록 별도 등록)
+	r.Post("/api/v1/users:import", userHandler.ImportUsers)
+	r.Get("/api/v1/users:export", userHandler.ExportUsers)
+
+	// 일괄 생성/조회/삭제 (항목별 결과, 생성은 Idempotency-Key 지원)
+	if o.idempotency != nil {
+		r.With(o.idempotency.Middleware).Post("/api/v1/users:batchCreate", userHandler.BatchCreateUsers)
+	} else {
+		r.Post("/api/v1/users:batchCreate", userHandler.BatchCreateUsers)
+	}
+	r.Post("/api/v1/users:batchGet", userHandler.BatchGetUsers)
+	r.Post("/api/v1/users:batchDelete", userHandler.BatchDeleteUsers)
+
 	// API 라우트
 	r.Route("/api/v1/users", func(r chi.Router) {
 		r.Get("/", userHandler.GetAllUsers)
-		r.Post("/", userHandler.CreateUser)
+		if o.idempotency != nil {
+			r.With(o.idempotency.Middleware).Post("/", userHandler.CreateUser)
+		} else {
+			r.Post("/", userHandler.CreateUser)
+		}
+		r.Get("/search", userHandler.SearchUsers)
+		if o.events != nil {
+			r.Get("/events", o.events.StreamEvents)
+		}
 		r.Get("/{id}", userHandler.GetUser)
 		r.Put("/{id}", userHandler.UpdateUser)
 		r.Delete("/{id}", userHandler.DeleteUser)
+		r.Put("/{id}/attributes/{namespace}", userHandler.SetUserAttributes)
+		r.Delete("/{id}/attributes/{namespace}", userHandler.RemoveUserAttributes)
+		r.Put("/{id}/avatar", userHandler.UploadAvatar)
+		r.Get("/{id}/avatar", userHandler.GetAvatar)
+		r.Delete("/{id}/avatar", userHandler.DeleteAvatar)
 	})
 
+	if o.webhooks != nil {
+		r.Route("/api/v1/webhooks", func(r chi.Router) {
+			r.Use(o.webhooks.RequireAdmin)
+			r.Get("/", o.webhooks.ListWebhooks)
+			r.Post("/", o.webhooks.CreateWebhook)
+			r.Get("/{id}", o.webhooks.GetWebhook)
+			r.Patch("/{id}", o.webhooks.UpdateWebhook)
+			r.Delete("/{id}", o.webhooks.DeleteWebhook)
+			r.Get("/{id}/deliveries", o.webhooks.ListDeliveries)
+			r.Post("/{id}/deliveries/{deliveryID}/redeliver", o.webhooks.Redeliver)
+		})
+	}
+
+	if o.schemas != nil {
+		r.Route("/api/v1/admin/attribute-schemas", func(r chi.Router) {
+			r.Use(o.schemas.RequireAdmin)
+			r.Get("/", o.schemas.ListSchemas)
+			r.Get("/{namespace}", o.schemas.GetSchema)
+			r.Put("/{namespace}", o.schemas.PutSchema)
+			r.Delete("/{namespace}", o.schemas.DeleteSchema)
+		})
+	}
+
+	if o.jobs != nil {
+		r.Route("/api/v1/admin/jobs", func(r chi.Router) {
+			r.Use(o.jobs.RequireAdmin)
+			r.Get("/", o.jobs.ListJobs)
+			r.Get("/{name}/runs", o.jobs.ListRuns)
+			r.Post("/{name}/run", o.jobs.TriggerJob)
+		})
+	}
+
+	if o.userAdmin != nil {
+		r.Route("/api/v1/admin/users", func(r chi.Router) {
+			r.Use(o.userAdmin.RequireAdmin)
+			r.Post("/{id}/activate", o.userAdmin.ActivateUser)
+			r.Post("/{id}/suspend", o.userAdmin.SuspendUser)
+			r.Post("/{id}/reinstate", o.userAdmin.ReinstateUser)
+			r.Post("/{id}/deactivate", o.userAdmin.DeactivateUser)
+		})
+	}
+
+	if o.privacy != nil {
+		r.Route("/api/v1/admin/privacy", func(r chi.Router) {
+			r.Use(o.privacy.RequireAdmin)
+			r.Post("/users/{id}/exports", o.privacy.RequestExport)
+			r.Post("/users/{id}/erasures", o.privacy.RequestErasure)
+			r.Get("/users/{id}/requests", o.privacy.ListUserRequests)
+			r.Get("/requests/{requestID}", o.privacy.GetRequest)
+			r.Get("/requests/{requestID}/archive", o.privacy.DownloadArchive)
+			r.Post("/receipts/verify", o.privacy.VerifyReceipt)
+		})
+	}
+
 	return r
 }
 
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// TenantConfig - 테넌트 확인 설정
+type TenantConfig struct {
+	Header     string // 테넌트 헤더 (빈 문자열이면 "X-Tenant-ID")
+	BaseDomain string // 서브도메인 확인용 도메인 (예: "example.com" → acme.example.com), 빈 문자열이면 사용 안 함
+	Default    string // 어디에서도 확인되지 않을 때 쓸 테넌트 (빈 문자열이면 테넌트 없이 진행 → 400)
+
+	// TrustHeader - 헤더로 테넌트를 고를 수 있게 함
+	// 클라이언트가 헤더를 마음대로 보낼 수 있으므로, 앞단 게이트웨이가 인증 후 헤더를 덮어쓰는 신뢰 네트워크에서만 켬
+	// 끄면 헤더는 다른 출처(클레임/서브도메인/기본값)로 정한 테넌트와 같을 때만 허용 (다르면 403)
+	TrustHeader bool
+}
+
+// TenantResolver - 요청의 테넌트를 확인해 컨텍스트에 넣는 미들웨어
+// 우선순위: 토큰 클레임 → 서브도메인 → 헤더 (TrustHeader일 때만) → 기본값
+// 출처끼리 값이 다르면 다른 테넌트 접근 시도로 보고 거부
+type TenantResolver struct {
+	header      string
+	baseDomain  string
+	def         string
+	trustHeader bool
+}
+
+// NewTenantResolver - TenantResolver 생성자
+func NewTenantResolver(cfg TenantConfig) *TenantResolver {
+	header := cfg.Header
+	if header == "" {
+		header = "X-Tenant-ID"
+	}
+	return &TenantResolver{
+		header:      header,
+		baseDomain:  strings.ToLower(strings.Trim(cfg.BaseDomain, ".")),
+		def:         cfg.Default,
+		trustHeader: cfg.TrustHeader,
+	}
+}
+
+// Middleware - chi 미들웨어
+func (tr *TenantResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		candidates := []string{tr.fromClaim(r), tr.fromSubdomain(r)}
+		if tr.trustHeader {
+			candidates = append(candidates, tr.fromHeader(r))
+		}
+
+		var resolved string
+		for _, candidate := range candidates {
+			if candidate == "" {
+				continue
+			}
+			if resolved != "" && candidate != resolved {
+				respondError(w, r, http.StatusForbidden, domain.ErrCrossTenant)
+				return
+			}
+			resolved = candidate
+		}
+		if resolved == "" {
+			resolved = tr.def
+		}
+		// 믿지 않는 헤더는 테넌트를 고르지 못하고, 정해진 테넌트와 같은지만 확인
+		if header := tr.fromHeader(r); !tr.trustHeader && header != "" && header != resolved {
+			respondError(w, r, http.StatusForbidden, domain.ErrCrossTenant)
+			return
+		}
+
+		if resolved == "" {
+			// 테넌트가 필요한 유스케이스에서 domain.ErrTenantRequired로 거부
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !tenant.Valid(resolved) {
+			respondError(w, r, http.StatusBadRequest, domain.ErrInvalidTenant)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), resolved)))
+	})
+}
+
+func (tr *TenantResolver) fromClaim(r *http.Request) string {
+	tenantID, _ := TenantClaimFrom(r.Context())
+	return tenantID
+}
+
+// fromSubdomain - BaseDomain 바로 아래 레이블 (acme.example.com → acme)
+func (tr *TenantResolver) fromSubdomain(r *http.Request) string {
+	if tr.baseDomain == "" {
+		return ""
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	label, ok := strings.CutSuffix(host, "."+tr.baseDomain)
+	if !ok || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
+
+func (tr *TenantResolver) fromHeader(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(tr.header))
+}
@@ -0,0 +1,162 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func newTenantRouter(cfg deliveryhttp.TenantConfig) http.Handler {
+	handler := deliveryhttp.NewUserHandler(usecase.NewUserUseCase(memory.NewUserRepository()))
+	return deliveryhttp.NewRouter(handler, deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(cfg)))
+}
+
+func serve(h http.Handler, method, target, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTenantIsolation(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{TrustHeader: true})
+	acme := map[string]string{"X-Tenant-ID": "acme"}
+	globex := map[string]string{"X-Tenant-ID": "globex"}
+
+	rec := serve(h, http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, acme)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create in acme: got %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	var created deliveryhttp.UserResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.TenantID != "acme" {
+		t.Fatalf("tenant_id: got %q, want %q", created.TenantID, "acme")
+	}
+
+	// 같은 이메일이라도 다른 테넌트에서는 생성 가능
+	rec = serve(h, http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, globex)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create in globex: got %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	rec = serve(h, http.MethodPost, "/api/v1/users", `{"email":"kim@example.com","name":"Kim"}`, acme)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("duplicate in acme: got %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	path := "/api/v1/users/" + created.ID
+	for _, tc := range []struct {
+		method, body string
+	}{
+		{http.MethodGet, ""},
+		{http.MethodPut, `{"name":"Lee"}`},
+		{http.MethodDelete, ""},
+	} {
+		if rec := serve(h, tc.method, path, tc.body, globex); rec.Code != http.StatusForbidden {
+			t.Errorf("%s from globex: got %d, want %d", tc.method, rec.Code, http.StatusForbidden)
+		}
+	}
+
+	if rec := serve(h, http.MethodGet, path, "", acme); rec.Code != http.StatusOK {
+		t.Fatalf("get in acme: got %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestTenantResolver(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{BaseDomain: "example.com", TrustHeader: true})
+
+	tests := []struct {
+		name   string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{"header", "api.internal", map[string]string{"X-Tenant-ID": "acme"}, http.StatusOK},
+		{"subdomain", "acme.example.com", nil, http.StatusOK},
+		{"subdomain matches header", "acme.example.com:8080", map[string]string{"X-Tenant-ID": "acme"}, http.StatusOK},
+		{"subdomain conflicts with header", "acme.example.com", map[string]string{"X-Tenant-ID": "globex"}, http.StatusForbidden},
+		{"invalid tenant", "api.internal", map[string]string{"X-Tenant-ID": "Bad_Tenant"}, http.StatusBadRequest},
+		{"missing tenant", "api.internal", nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			req.Host = tt.host
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+// 신뢰 네트워크가 아니면 헤더로 테넌트를 고를 수 없고, 다른 출처로 정한 테넌트와 같을 때만 허용
+func TestTenantHeaderUntrusted(t *testing.T) {
+	h := newTenantRouter(deliveryhttp.TenantConfig{BaseDomain: "example.com", Default: "default"})
+
+	tests := []struct {
+		name   string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{"no header uses default", "api.internal", nil, http.StatusOK},
+		{"header selects other tenant", "api.internal", map[string]string{"X-Tenant-ID": "acme"}, http.StatusForbidden},
+		{"header matches default", "api.internal", map[string]string{"X-Tenant-ID": "default"}, http.StatusOK},
+		{"header matches subdomain", "acme.example.com", map[string]string{"X-Tenant-ID": "acme"}, http.StatusOK},
+		{"header conflicts with subdomain", "acme.example.com", map[string]string{"X-Tenant-ID": "globex"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			req.Host = tt.host
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("got %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	// 인증 미들웨어가 넣은 클레임으로는 기본값이 아닌 테넌트도 선택 가능
+	claimed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(deliveryhttp.WithTenantClaim(r.Context(), "acme")))
+	})
+	if rec := serve(claimed, http.MethodGet, "/api/v1/users", "", map[string]string{"X-Tenant-ID": "acme"}); rec.Code != http.StatusOK {
+		t.Fatalf("header matches claim: got %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestTenantClaimTakesPrecedence(t *testing.T) {
+	router := newTenantRouter(deliveryhttp.TenantConfig{Default: "default"})
+
+	// 인증 미들웨어 역할: 토큰의 테넌트 클레임을 컨텍스트에 저장
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r.WithContext(deliveryhttp.WithTenantClaim(r.Context(), "acme")))
+	})
+
+	if rec := serve(h, http.MethodGet, "/api/v1/users", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("claim only: got %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec := serve(h, http.MethodGet, "/api/v1/users", "", map[string]string{"X-Tenant-ID": "globex"}); rec.Code != http.StatusForbidden {
+		t.Fatalf("header conflicts with claim: got %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// defaultStatusActor - 인증된 주체가 없을 때 상태 변경 기록에 남기는 주체
+const defaultStatusActor = "admin"
+
+// UserAdminHandler - 계정 상태 변경 HTTP 핸들러 (관리자용)
+type UserAdminHandler struct {
+	users      *usecase.UserUseCase
+	adminToken string
+}
+
+// NewUserAdminHandler - UserAdminHandler 생성자
+// adminToken이 비어 있으면 모든 요청을 거부
+func NewUserAdminHandler(users *usecase.UserUseCase, adminToken string) *UserAdminHandler {
+	return &UserAdminHandler{
+		users:      users,
+		adminToken: adminToken,
+	}
+}
+
+// StatusChangeRequest - 상태 변경 요청 DTO (본문 생략 가능)
+type StatusChangeRequest struct {
+	Reason string `json:"reason"` // 정지/비활성화는 필수
+}
+
+// RequireAdmin - Authorization: Bearer <관리자 토큰> 확인 미들웨어
+func (h *UserAdminHandler) RequireAdmin(next http.Handler) http.Handler {
+	return requireAdmin(h.adminToken, next)
+}
+
+// ActivateUser - 활성화 전 계정 활성화 핸들러
+func (h *UserAdminHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, func(ctx context.Context, id, _, actor string) (*domain.User, error) {
+		return h.users.ActivateUser(ctx, id, actor)
+	})
+}
+
+// SuspendUser - 계정 정지 핸들러
+func (h *UserAdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.users.SuspendUser)
+}
+
+// ReinstateUser - 정지된 계정 복구 핸들러
+func (h *UserAdminHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.users.ReinstateUser)
+}
+
+// DeactivateUser - 계정 비활성화 핸들러
+func (h *UserAdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.users.DeactivateUser)
+}
+
+// changeStatus - 본문에서 사유를 읽고 주체를 정해 상태 변경 실행
+func (h *UserAdminHandler) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, id, reason, actor string) (*domain.User, error)) {
+	var req StatusChangeRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &req); err != nil {
+			respondDecodeError(w, r, err)
+			return
+		}
+	}
+
+	actor, ok := PrincipalFrom(r.Context())
+	if !ok {
+		actor = defaultStatusActor
+	}
+
+	user, err := change(r.Context(), chi.URLParam(r, "id"), strings.TrimSpace(req.Reason), actor)
+	if err != nil {
+		respondStatusError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toUserResponse(user))
+}
+
+// respondStatusError - 상태 변경 에러를 HTTP 상태로 변환
+func respondStatusError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrTenantRequired),
+		errors.Is(err, domain.ErrStatusReasonRequired),
+		errors.Is(err, domain.ErrStatusActorRequired):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrCrossTenant):
+		respondError(w, r, http.StatusForbidden, err)
+	case errors.Is(err, domain.ErrInvalidStatusTransition),
+		errors.Is(err, domain.ErrUserModified):
+		respondError(w, r, http.StatusConflict, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// statusFilter - status=a,b 쿼리 값을 상태 목록으로 변환 (비어 있으면 조건 없음)
+func statusFilter(value string) ([]domain.UserStatus, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var statuses []domain.UserStatus
+	for _, s := range strings.Split(value, ",") {
+		status, err := domain.ParseUserStatus(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
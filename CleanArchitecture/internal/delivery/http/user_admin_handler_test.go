@@ -0,0 +1,243 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/cache"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func newLifecycleRouter() http.Handler {
+	users := usecase.NewUserUseCase(memory.NewUserRepository())
+	return deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+		deliveryhttp.WithUserAdmin(deliveryhttp.NewUserAdminHandler(users, adminToken)),
+	)
+}
+
+func decodeUser(t *testing.T, body []byte) deliveryhttp.UserResponse {
+	t.Helper()
+	var user deliveryhttp.UserResponse
+	if err := json.Unmarshal(body, &user); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return user
+}
+
+func TestUserLifecycle(t *testing.T) {
+	h := newLifecycleRouter()
+	admin := map[string]string{"Authorization": "Bearer " + adminToken}
+	id := createUser(t, h)
+
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/suspend", `{"reason":"spam"}`, nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("suspend without token: got %d, want 401", rec.Code)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/suspend", "", admin); rec.Code != http.StatusBadRequest {
+		t.Fatalf("suspend without reason: got %d, want 400: %s", rec.Code, rec.Body)
+	}
+	rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/suspend", `{"reason":"spam"}`, admin)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("suspend: got %d: %s", rec.Code, rec.Body)
+	}
+	user := decodeUser(t, rec.Body.Bytes())
+	if user.Status != "suspended" || len(user.StatusHistory) != 1 ||
+		user.StatusHistory[0].Reason != "spam" || user.StatusHistory[0].Actor != "admin" {
+		t.Fatalf("suspended user = %+v", user)
+	}
+
+	// 정지된 계정은 수정 불가, 같은 전이를 반복해도 409
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil); rec.Code != http.StatusConflict {
+		t.Fatalf("update while suspended: got %d, want 409: %s", rec.Code, rec.Body)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/suspend", `{"reason":"again"}`, admin); rec.Code != http.StatusConflict {
+		t.Fatalf("suspend twice: got %d, want 409", rec.Code)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/activate", "", admin); rec.Code != http.StatusConflict {
+		t.Fatalf("activate suspended: got %d, want 409", rec.Code)
+	}
+
+	// 상태 조건 목록
+	other := serve(h, http.MethodPost, "/api/v1/users", `{"email":"park@example.com","name":"Park","status":"pending"}`, nil)
+	if other.Code != http.StatusCreated || decodeUser(t, other.Body.Bytes()).Status != "pending" {
+		t.Fatalf("create pending: got %d: %s", other.Code, other.Body)
+	}
+	rec = serve(h, http.MethodGet, "/api/v1/users?status=suspended", "", nil)
+	var listed []deliveryhttp.UserResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != id {
+		t.Fatalf("status=suspended: got %+v", listed)
+	}
+	if rec := serve(h, http.MethodGet, "/api/v1/users?status=banned", "", nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown status filter: got %d, want 400", rec.Code)
+	}
+
+	rec = serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/reinstate", `{"reason":"appeal accepted"}`, admin)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reinstate: got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update after reinstate: got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 비활성화는 되돌릴 수 없음
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/deactivate", `{"reason":"closed"}`, admin); rec.Code != http.StatusOK {
+		t.Fatalf("deactivate: got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/reinstate", `{"reason":"oops"}`, admin); rec.Code != http.StatusConflict {
+		t.Fatalf("reinstate deactivated: got %d, want 409", rec.Code)
+	}
+	rec = serve(h, http.MethodGet, "/api/v1/users/"+id, "", nil)
+	if user := decodeUser(t, rec.Body.Bytes()); user.Status != "deactivated" || len(user.StatusHistory) != 3 {
+		t.Fatalf("final user = %+v", user)
+	}
+
+	if rec := serve(h, http.MethodPost, "/api/v1/admin/users/missing/suspend", `{"reason":"x"}`, admin); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown user: got %d, want 404", rec.Code)
+	}
+}
+
+// slowRepository - 읽은 뒤 잠시 멈춰 읽기-수정-쓰기 사이에 다른 요청이 끼어들 틈을 만드는 저장소
+// (트랜잭션 안의 조회는 memory 저장소 그대로)
+type slowRepository struct {
+	*memory.UserRepository
+}
+
+func (r *slowRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.UserRepository.GetByID(ctx, id)
+	time.Sleep(time.Millisecond)
+	return user, err
+}
+
+// TestConcurrentUserChanges - 동시에 들어온 상태 변경/수정이 서로를 덮어쓰지 않음
+// 트랜잭션 저장소와 트랜잭션 없는 저장소(유스케이스 안에서 직렬화) 모두 확인
+func TestConcurrentUserChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		repo usecase.UserRepository
+	}{
+		{"transaction", &slowRepository{memory.NewUserRepository()}},
+		{"no transaction", plainRepository{&slowRepository{memory.NewUserRepository()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := usecase.NewUserUseCase(tt.repo)
+			h := deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+				deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{Default: "acme"})),
+				deliveryhttp.WithUserAdmin(deliveryhttp.NewUserAdminHandler(users, adminToken)),
+			)
+			admin := map[string]string{"Authorization": "Bearer " + adminToken}
+			id := createUser(t, h)
+
+			var (
+				wg          sync.WaitGroup
+				transitions atomic.Int32
+			)
+			for i := range 40 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var rec *httptest.ResponseRecorder
+					switch i % 3 {
+					case 0:
+						rec = serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/suspend", `{"reason":"spam"}`, admin)
+					case 1:
+						rec = serve(h, http.MethodPost, "/api/v1/admin/users/"+id+"/reinstate", `{"reason":"appeal"}`, admin)
+					default:
+						rec = serve(h, http.MethodPut, "/api/v1/users/"+id, fmt.Sprintf(`{"name":"Kim %d"}`, i), nil)
+					}
+					switch {
+					case rec.Code == http.StatusOK && i%3 != 2:
+						transitions.Add(1)
+					case rec.Code != http.StatusOK && rec.Code != http.StatusConflict:
+						t.Errorf("request %d: got %d: %s", i, rec.Code, rec.Body)
+					}
+				}()
+			}
+			wg.Wait()
+
+			user := decodeUser(t, serve(h, http.MethodGet, "/api/v1/users/"+id, "", nil).Body.Bytes())
+			history := user.StatusHistory
+			if len(history) != int(transitions.Load()) {
+				t.Fatalf("history has %d changes, %d transitions succeeded: %+v", len(history), transitions.Load(), history)
+			}
+			prev := "active"
+			for i, c := range history {
+				if c.From != prev {
+					t.Fatalf("history[%d] from %s, previous state %s: %+v", i, c.From, prev, history)
+				}
+				prev = c.To
+			}
+			if user.Status != prev {
+				t.Fatalf("status %s, history ends in %s", user.Status, prev)
+			}
+		})
+	}
+}
+
+// racingRepository - 첫 조회 직후 다른 인스턴스가 같은 사용자를 바꾼 것처럼 동작하는 트랜잭션 없는 저장소
+type racingRepository struct {
+	usecase.UserRepository
+	once sync.Once
+}
+
+func (r *racingRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.UserRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.once.Do(func() {
+		other := user.Clone()
+		other.Name = "Changed elsewhere"
+		other.UpdatedAt = other.UpdatedAt.Add(time.Second)
+		if err := r.UserRepository.Update(ctx, other); err != nil {
+			panic(err)
+		}
+	})
+	return user, nil
+}
+
+func TestUpdateDetectsConcurrentWriter(t *testing.T) {
+	repo := &racingRepository{UserRepository: memory.NewUserRepository()}
+	h := newBatchRouter(repo)
+	id := createUser(t, h)
+	repo.once = sync.Once{}
+
+	rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil)
+	var resp deliveryhttp.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusConflict || resp.Code != "user_modified" {
+		t.Fatalf("update: got %d: %s, want 409 user_modified", rec.Code, rec.Body)
+	}
+	if got := decodeUser(t, serve(h, http.MethodGet, "/api/v1/users/"+id, "", nil).Body.Bytes()); got.Name != "Changed elsewhere" {
+		t.Fatalf("name = %q, the other writer's change was overwritten", got.Name)
+	}
+
+	// 다시 시도하면 성공
+	if rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("retry: got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+// TestUpdateBehindDecoratorWithoutTransactions - 캐시 데코레이터가 트랜잭션 없는 저장소를 감싸도 수정 가능
+func TestUpdateBehindDecoratorWithoutTransactions(t *testing.T) {
+	repo := cache.NewUserRepository(plainRepository{memory.NewUserRepository()}, cache.Config{Size: 16, TTL: time.Minute})
+	h := newBatchRouter(repo)
+	id := createUser(t, h)
+
+	rec := serve(h, http.MethodPut, "/api/v1/users/"+id, `{"name":"Lee"}`, nil)
+	if rec.Code != http.StatusOK || decodeUser(t, rec.Body.Bytes()).Name != "Lee" {
+		t.Fatalf("update: got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/webhook"
+)
+
+// WebhookHandler - 웹훅 구독 관리 핸들러 (관리자 토큰 필요)
+type WebhookHandler struct {
+	service    *webhook.Service
+	adminToken string
+}
+
+// NewWebhookHandler - WebhookHandler 생성자
+// adminToken이 비어 있으면 모든 요청을 거부
+func NewWebhookHandler(service *webhook.Service, adminToken string) *WebhookHandler {
+	return &WebhookHandler{
+		service:    service,
+		adminToken: adminToken,
+	}
+}
+
+// RequireAdmin - Authorization: Bearer <관리자 토큰> 확인 미들웨어
+func (h *WebhookHandler) RequireAdmin(next http.Handler) http.Handler {
+	return requireAdmin(h.adminToken, next)
+}
+
+// CreateWebhookRequest - 구독 생성 요청 DTO
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // 비어 있으면 모든 이벤트
+	Secret string   `json:"secret"` // 비어 있으면 서버가 생성
+}
+
+// UpdateWebhookRequest - 구독 수정 요청 DTO (생략한 필드는 유지)
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url"`
+	Events *[]string `json:"events"`
+	Active *bool     `json:"active"`
+}
+
+// WebhookResponse - 구독 응답 DTO (비밀 키는 생성 응답에만 포함)
+type WebhookResponse struct {
+	ID                  string   `json:"id"`
+	URL                 string   `json:"url"`
+	Events              []string `json:"events"`
+	Secret              string   `json:"secret,omitempty"`
+	Active              bool     `json:"active"`
+	ConsecutiveFailures int      `json:"consecutive_failures"`
+	DisabledAt          *string  `json:"disabled_at,omitempty"`
+	CreatedAt           string   `json:"created_at"`
+	UpdatedAt           string   `json:"updated_at"`
+}
+
+// DeliveryAttemptResponse - 전송 시도 DTO
+type DeliveryAttemptResponse struct {
+	At         string `json:"at"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// DeliveryResponse - 전송 기록 DTO
+type DeliveryResponse struct {
+	ID             string                    `json:"id"`
+	SubscriptionID string                    `json:"subscription_id"`
+	EventID        string                    `json:"event_id"`
+	EventType      string                    `json:"event_type"`
+	Status         string                    `json:"status"`
+	Attempts       []DeliveryAttemptResponse `json:"attempts"`
+	NextAttemptAt  *string                   `json:"next_attempt_at,omitempty"`
+	RedeliveryOf   string                    `json:"redelivery_of,omitempty"`
+	Payload        json.RawMessage           `json:"payload"`
+	CreatedAt      string                    `json:"created_at"`
+}
+
+// toWebhookResponse - 구독을 DTO로 변환
+func toWebhookResponse(sub *webhook.Subscription) WebhookResponse {
+	events := make([]string, len(sub.Events))
+	for i, e := range sub.Events {
+		events[i] = string(e)
+	}
+
+	resp := WebhookResponse{
+		ID:                  sub.ID,
+		URL:                 sub.URL,
+		Events:              events,
+		Active:              sub.Active,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		CreatedAt:           sub.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           sub.UpdatedAt.Format(time.RFC3339),
+	}
+	if sub.DisabledAt != nil {
+		disabledAt := sub.DisabledAt.Format(time.RFC3339)
+		resp.DisabledAt = &disabledAt
+	}
+	return resp
+}
+
+// toDeliveryResponse - 전송 기록을 DTO로 변환
+func toDeliveryResponse(d *webhook.Delivery) DeliveryResponse {
+	attempts := make([]DeliveryAttemptResponse, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = DeliveryAttemptResponse{
+			At:         a.At.Format(time.RFC3339),
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMS: a.Duration.Milliseconds(),
+		}
+	}
+
+	resp := DeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       attempts,
+		RedeliveryOf:   d.RedeliveryOf,
+		Payload:        json.RawMessage(d.Payload),
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.Status == webhook.DeliveryPending {
+		next := d.NextAttemptAt.Format(time.RFC3339)
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+// toEventTypes - 요청의 이벤트 이름 변환
+func toEventTypes(events []string) []domain.UserEventType {
+	types := make([]domain.UserEventType, len(events))
+	for i, e := range events {
+		types[i] = domain.UserEventType(e)
+	}
+	return types
+}
+
+// respondWebhookError - 웹훅/도메인 에러를 HTTP 상태로 변환
+func respondWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrSubscriptionNotFound),
+		errors.Is(err, webhook.ErrDeliveryNotFound):
+		respondError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, webhook.ErrInvalidURL),
+		errors.Is(err, webhook.ErrForbiddenDestination),
+		errors.Is(err, webhook.ErrInvalidEvent),
+		errors.Is(err, domain.ErrTenantRequired):
+		respondError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, webhook.ErrSubscriptionDisabled):
+		respondError(w, r, http.StatusConflict, err)
+	case errors.Is(err, domain.ErrCrossTenant):
+		respondError(w, r, http.StatusForbidden, err)
+	default:
+		respondError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// CreateWebhook - 구독 생성 (POST /api/v1/webhooks)
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	sub, err := h.service.Subscribe(r.Context(), webhook.SubscriptionInput{
+		URL:    req.URL,
+		Events: toEventTypes(req.Events),
+		Secret: req.Secret,
+	})
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+
+	resp := toWebhookResponse(sub)
+	resp.Secret = sub.Secret
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// ListWebhooks - 구독 목록 (GET /api/v1/webhooks)
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.service.Subscriptions(r.Context())
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+
+	responses := make([]WebhookResponse, len(subs))
+	for i, sub := range subs {
+		responses[i] = toWebhookResponse(sub)
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// GetWebhook - 구독 조회 (GET /api/v1/webhooks/{id})
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	sub, err := h.service.Subscription(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toWebhookResponse(sub))
+}
+
+// UpdateWebhook - 구독 수정 (PATCH /api/v1/webhooks/{id})
+// {"active": true}로 자동 비활성화된 구독을 다시 켬
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req UpdateWebhookRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondDecodeError(w, r, err)
+		return
+	}
+
+	patch := webhook.SubscriptionPatch{URL: req.URL, Active: req.Active}
+	if req.Events != nil {
+		events := toEventTypes(*req.Events)
+		patch.Events = &events
+	}
+
+	sub, err := h.service.UpdateSubscription(r.Context(), chi.URLParam(r, "id"), patch)
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toWebhookResponse(sub))
+}
+
+// DeleteWebhook - 구독 삭제 (DELETE /api/v1/webhooks/{id})
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Unsubscribe(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries - 전송 기록 (GET /api/v1/webhooks/{id}/deliveries?status=pending|succeeded|failed)
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.service.Deliveries(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	responses := make([]DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		if status != "" && string(d.Status) != status {
+			continue
+		}
+		responses = append(responses, toDeliveryResponse(d))
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// Redeliver - 수동 재전송 (POST /api/v1/webhooks/{id}/deliveries/{deliveryID}/redeliver)
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	d, err := h.service.Redeliver(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		respondWebhookError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusAccepted, toDeliveryResponse(d))
+}
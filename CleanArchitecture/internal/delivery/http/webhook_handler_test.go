@@ -0,0 +1,52 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	deliveryhttp "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/milman2/go-api/clean-architecture/internal/webhook"
+)
+
+func TestWebhookAPIRequiresAdmin(t *testing.T) {
+	users := usecase.NewUserUseCase(memory.NewUserRepository())
+	service := webhook.NewService(webhook.NewMemoryStore(), webhook.Config{})
+	h := deliveryhttp.NewRouter(deliveryhttp.NewUserHandler(users),
+		deliveryhttp.WithTenantResolver(deliveryhttp.NewTenantResolver(deliveryhttp.TenantConfig{TrustHeader: true})),
+		deliveryhttp.WithWebhooks(deliveryhttp.NewWebhookHandler(service, adminToken)),
+	)
+	acme := map[string]string{"X-Tenant-ID": "acme"}
+	admin := map[string]string{"X-Tenant-ID": "acme", "Authorization": "Bearer " + adminToken}
+	body := `{"url":"https://hooks.example.com/users"}`
+
+	if rec := serve(h, http.MethodPost, "/api/v1/webhooks", body, acme); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("create without token: got %d", rec.Code)
+	}
+	if rec := serve(h, http.MethodGet, "/api/v1/webhooks", "", map[string]string{"X-Tenant-ID": "acme", "Authorization": "Bearer wrong"}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("list with wrong token: got %d", rec.Code)
+	}
+
+	rec := serve(h, http.MethodPost, "/api/v1/webhooks", body, admin)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", rec.Code, rec.Body)
+	}
+	var created deliveryhttp.WebhookResponse
+	json.NewDecoder(rec.Body).Decode(&created)
+	if rec := serve(h, http.MethodGet, "/api/v1/webhooks/"+created.ID, "", acme); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("get without token: got %d", rec.Code)
+	}
+
+	// 내부 주소는 400
+	rec = serve(h, http.MethodPost, "/api/v1/webhooks", `{"url":"http://169.254.169.254/latest/meta-data"}`, admin)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("internal destination: got %d", rec.Code)
+	}
+	var errResp deliveryhttp.ErrorResponse
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if errResp.Code != "webhook_destination_forbidden" {
+		t.Fatalf("internal destination: got %+v", errResp)
+	}
+}
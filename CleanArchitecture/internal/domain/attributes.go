@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Attributes - 사용자 정의 속성 (네임스페이스 → 필드 → JSON 값)
+// 제품 팀마다 자기 네임스페이스(예: "i18n", "hr")를 두고 등록한 JSON Schema로 검증
+// 값은 encoding/json이 any로 디코딩한 형태 (string, float64, bool, nil, []any, map[string]any)
+type Attributes map[string]map[string]any
+
+var (
+	namespacePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	fieldPattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+)
+
+// ValidNamespace - 속성 네임스페이스 이름 규칙 (소문자로 시작, 소문자/숫자/'_'/'-', 최대 63자)
+func ValidNamespace(namespace string) bool {
+	return namespacePattern.MatchString(namespace)
+}
+
+// ValidAttributeField - 필터에 쓸 수 있는 필드 이름 규칙 (식별자 형태, 최대 64자)
+func ValidAttributeField(field string) bool {
+	return fieldPattern.MatchString(field)
+}
+
+// Clone - 깊은 복사 (저장소가 복사본을 주고받을 때 맵을 공유하지 않도록)
+func (a Attributes) Clone() Attributes {
+	if a == nil {
+		return nil
+	}
+	c := make(Attributes, len(a))
+	for ns, values := range a {
+		c[ns] = cloneValue(values).(map[string]any)
+	}
+	return c
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for k, e := range v {
+			c[k] = cloneValue(e)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, e := range v {
+			c[i] = cloneValue(e)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// SetAttributes - 네임스페이스 속성 전체 교체 (검증은 유스케이스가 스키마로 수행)
+func (u *User) SetAttributes(namespace string, values map[string]any) error {
+	if !ValidNamespace(namespace) {
+		return ErrInvalidNamespace
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(Attributes)
+	}
+	u.Attributes[namespace] = cloneValue(values).(map[string]any)
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveAttributes - 네임스페이스 속성 삭제 (없으면 변경 없음)
+func (u *User) RemoveAttributes(namespace string) bool {
+	if _, ok := u.Attributes[namespace]; !ok {
+		return false
+	}
+	delete(u.Attributes, namespace)
+	if len(u.Attributes) == 0 {
+		u.Attributes = nil
+	}
+	u.UpdatedAt = time.Now()
+	return true
+}
+
+// AttributeFilter - 목록 조회 속성 조건 (네임스페이스.필드 = 값)
+// 값은 문자열로 받아 JSON 값의 종류에 맞게 비교하며, 배열이면 원소 중 하나만 같아도 일치
+type AttributeFilter struct {
+	Namespace string
+	Field     string
+	Value     string
+}
+
+// Validate - 네임스페이스/필드 이름 확인
+func (f AttributeFilter) Validate() error {
+	if !ValidNamespace(f.Namespace) || !ValidAttributeField(f.Field) {
+		return ErrInvalidFilter
+	}
+	return nil
+}
+
+// Match - 사용자가 조건을 만족하는지 확인
+func (f AttributeFilter) Match(u *User) bool {
+	v, ok := u.Attributes[f.Namespace][f.Field]
+	if !ok {
+		return false
+	}
+	if arr, ok := v.([]any); ok {
+		for _, e := range arr {
+			if scalarEquals(e, f.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return scalarEquals(v, f.Value)
+}
+
+// MatchAttributes - 모든 조건을 만족하는지 확인 (AND)
+func MatchAttributes(u *User, filters []AttributeFilter) bool {
+	for _, f := range filters {
+		if !f.Match(u) {
+			return false
+		}
+	}
+	return true
+}
+
+func scalarEquals(v any, s string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == s
+	case float64:
+		n, err := strconv.ParseFloat(s, 64)
+		return err == nil && n == v
+	case bool:
+		b, err := strconv.ParseBool(s)
+		return err == nil && b == v
+	case nil:
+		return s == "null"
+	default:
+		return false
+	}
+}
+
+// AttributeSchema - 네임스페이스에 등록된 JSON Schema (관리자가 관리)
+type AttributeSchema struct {
+	Namespace string
+	Schema    []byte // JSON Schema 문서 원문
+	Version   int    // 등록/교체할 때마다 1씩 증가
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "fmt"
+
+// 아바타 이미지 제한
+const (
+	MaxAvatarBytes      = 5 << 20 // 업로드 원본 최대 크기
+	MinAvatarSide       = 32      // 가로/세로 최소 픽셀
+	MaxAvatarSide       = 4096    // 가로/세로 최대 픽셀 (디코딩 전에 헤더로 확인)
+	AvatarThumbnailSide = 128     // 썸네일 한 변 (정사각형)
+)
+
+// AvatarContentTypes - 받는 이미지 형식 (내용으로 판별한 MIME 타입)
+var AvatarContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// AvatarVariant - 저장하는 아바타 종류
+type AvatarVariant string
+
+const (
+	AvatarOriginal  AvatarVariant = "original"
+	AvatarThumbnail AvatarVariant = "thumbnail"
+)
+
+// ParseAvatarVariant - 문자열을 아바타 종류로 변환 (빈 문자열은 원본)
+func ParseAvatarVariant(s string) (AvatarVariant, error) {
+	switch AvatarVariant(s) {
+	case "", AvatarOriginal:
+		return AvatarOriginal, nil
+	case AvatarThumbnail:
+		return AvatarThumbnail, nil
+	default:
+		return "", ErrInvalidAvatarVariant
+	}
+}
+
+// AvatarKey - 아바타 블롭 키 (avatars/<테넌트>/<사용자>/<종류>)
+func AvatarKey(tenantID, userID string, variant AvatarVariant) string {
+	return "avatars/" + tenantID + "/" + userID + "/" + string(variant)
+}
+
+// ValidAvatarSize - 이미지 크기 규칙 확인
+func ValidAvatarSize(width, height int) error {
+	if width < MinAvatarSide || height < MinAvatarSide || width > MaxAvatarSide || height > MaxAvatarSide {
+		return fmt.Errorf("%w: %dx%d, each side must be between %d and %d pixels",
+			ErrInvalidImage, width, height, MinAvatarSide, MaxAvatarSide)
+	}
+	return nil
+}
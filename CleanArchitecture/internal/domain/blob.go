@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// BlobInfo - 저장된 바이너리 객체(블롭)의 메타데이터
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ETag        string // 내용 해시 기반 (따옴표 포함)
+	ModTime     time.Time
+}
@@ -1,13 +1,100 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"sort"
+)
 
 // 도메인 에러 정의
+// 코드는 API 응답에 그대로 노출되어 클라이언트가 분기에 쓰므로 한 번 정하면 바꾸지 않음
+// 메시지는 영어 기본값이며, 다른 언어는 i18n 카탈로그에서 코드로 찾음
 var (
-	ErrUserNotFound  = errors.New("user not found")
-	ErrUserExists    = errors.New("user already exists")
-	ErrInvalidEmail  = errors.New("invalid email")
-	ErrInvalidName   = errors.New("invalid name")
-	ErrInvalidUserID = errors.New("invalid user id")
+	ErrUserNotFound       = NewError("user_not_found", "user not found")
+	ErrUserExists         = NewError("user_exists", "user already exists")
+	ErrInvalidEmail       = NewError("invalid_email", "invalid email")
+	ErrInvalidName        = NewError("invalid_name", "invalid name")
+	ErrInvalidUserID      = NewError("invalid_user_id", "invalid user id")
+	ErrInvalidQuery       = NewError("invalid_query", "invalid search query")
+	ErrUserModified       = NewError("user_modified", "user was modified by another request, retry")
+	ErrPreconditionFailed = NewError("precondition_failed", "user does not match the If-Match version")
+
+	ErrInvalidUserStatus       = NewError("invalid_user_status", "invalid user status")
+	ErrInvalidStatusTransition = NewError("invalid_status_transition", "user status cannot change this way")
+	ErrStatusReasonRequired    = NewError("status_reason_required", "a reason is required for this status change")
+	ErrStatusActorRequired     = NewError("status_actor_required", "the actor of a status change is required")
+	ErrUserSuspended           = NewError("user_suspended", "user is suspended")
+	ErrUserDeactivated         = NewError("user_deactivated", "user is deactivated")
+	ErrInvalidErasureMode      = NewError("invalid_erasure_mode", "invalid erasure mode")
+
+	ErrTenantRequired = NewError("tenant_required", "tenant is required")
+	ErrInvalidTenant  = NewError("invalid_tenant", "invalid tenant")
+	ErrCrossTenant    = NewError("cross_tenant", "cross-tenant access denied")
+
+	ErrInvalidNamespace  = NewError("invalid_attribute_namespace", "invalid attribute namespace")
+	ErrUnknownNamespace  = NewError("unknown_attribute_namespace", "attribute namespace has no registered schema")
+	ErrInvalidAttributes = NewError("invalid_attributes", "attributes do not match schema")
+	ErrInvalidFilter     = NewError("invalid_attribute_filter", "invalid attribute filter")
+	ErrSchemaNotFound    = NewError("attribute_schema_not_found", "attribute schema not found")
+	ErrInvalidSchema     = NewError("invalid_attribute_schema", "invalid attribute schema")
+
+	ErrAvatarNotFound       = NewError("avatar_not_found", "avatar not found")
+	ErrImageTooLarge        = NewError("image_too_large", "image too large")
+	ErrUnsupportedImage     = NewError("unsupported_image", "unsupported image type")
+	ErrInvalidImage         = NewError("invalid_image", "invalid image")
+	ErrInvalidAvatarVariant = NewError("invalid_avatar_variant", "invalid avatar variant")
+	ErrBlobNotFound         = NewError("blob_not_found", "blob not found")
+	ErrInvalidBlobKey       = NewError("invalid_blob_key", "invalid blob key")
 )
 
+// Error - 안정적인 코드가 붙은 에러
+// 패키지 수준 변수로만 만들고 errors.Is로 비교 (fmt.Errorf("%w")로 감싸도 코드 유지)
+type Error struct {
+	code    string
+	message string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Code - 기계가 읽는 에러 코드 (snake_case)
+func (e *Error) Code() string {
+	return e.code
+}
+
+// errorCodes - 등록된 코드 (같은 코드를 두 번 쓰면 시작할 때 panic)
+var errorCodes = make(map[string]*Error)
+
+// NewError - 코드가 붙은 에러 생성 및 등록
+// 다른 패키지(유스케이스, 어댑터, 전달 계층)의 에러도 이 함수로 만들어 같은 카탈로그를 씀
+func NewError(code, message string) error {
+	if _, dup := errorCodes[code]; dup {
+		panic("domain: duplicate error code " + code)
+	}
+	e := &Error{code: code, message: message}
+	errorCodes[code] = e
+	return e
+}
+
+// AsError - err 체인에서 코드가 붙은 에러를 찾음
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// ErrorCodes - 등록된 모든 코드 (정렬됨, 카탈로그 누락 검사용)
+func ErrorCodes() []string {
+	codes := make([]string, 0, len(errorCodes))
+	for code := range errorCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LookupError - 코드로 등록된 에러 찾기
+func LookupError(code string) (*Error, bool) {
+	e, ok := errorCodes[code]
+	return e, ok
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// UserEventType - 사용자 변경 이벤트 종류
+type UserEventType string
+
+const (
+	UserCreated UserEventType = "user.created"
+	UserUpdated UserEventType = "user.updated"
+	UserDeleted UserEventType = "user.deleted"
+
+	UserStatusChanged UserEventType = "user.status_changed"
+	UserErased        UserEventType = "user.erased" // 개인정보 삭제 (스냅샷에는 ID와 시각만 있음)
+)
+
+// UserEvents - 지원하는 모든 이벤트 종류
+var UserEvents = []UserEventType{UserCreated, UserUpdated, UserDeleted, UserStatusChanged, UserErased}
+
+// UserEvent - 사용자 변경 이벤트 (변경 직후 사용자 스냅샷 포함)
+type UserEvent struct {
+	ID         string
+	Type       UserEventType
+	TenantID   string
+	User       User
+	OccurredAt time.Time
+}
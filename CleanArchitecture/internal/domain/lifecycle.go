@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// UserStatus - 계정 상태
+type UserStatus string
+
+const (
+	UserPending     UserStatus = "pending"     // 만들어졌지만 아직 활성화 전 (초대 등)
+	UserActive      UserStatus = "active"      // 정상
+	UserSuspended   UserStatus = "suspended"   // 관리자가 일시 정지 (복구 가능)
+	UserDeactivated UserStatus = "deactivated" // 비활성화 (되돌릴 수 없음)
+)
+
+// UserStatuses - 모든 계정 상태
+var UserStatuses = []UserStatus{UserPending, UserActive, UserSuspended, UserDeactivated}
+
+// userTransitions - 상태별로 옮겨 갈 수 있는 상태
+//
+//	pending ──→ active ⇄ suspended
+//	   │          │          │
+//	   └──────────┴──────────┴──→ deactivated
+var userTransitions = map[UserStatus][]UserStatus{
+	UserPending:     {UserActive, UserDeactivated},
+	UserActive:      {UserSuspended, UserDeactivated},
+	UserSuspended:   {UserActive, UserDeactivated},
+	UserDeactivated: nil,
+}
+
+// Valid - 알려진 상태인지
+func (s UserStatus) Valid() bool {
+	_, ok := userTransitions[s]
+	return ok
+}
+
+// CanTransitionTo - to로 바꿀 수 있는지
+func (s UserStatus) CanTransitionTo(to UserStatus) bool {
+	return slices.Contains(userTransitions[s], to)
+}
+
+// ParseUserStatus - 문자열을 상태로 변환
+func ParseUserStatus(s string) (UserStatus, error) {
+	status := UserStatus(s)
+	if !status.Valid() {
+		return "", ErrInvalidUserStatus
+	}
+	return status, nil
+}
+
+// StatusChange - 상태 변경 기록
+type StatusChange struct {
+	From   UserStatus
+	To     UserStatus
+	Reason string // 변경 사유 (정지/비활성화는 필수)
+	Actor  string // 변경한 주체 (관리자 ID 등)
+	At     time.Time
+}
+
+// CurrentStatus - 계정 상태 (상태 도입 전에 저장된 사용자는 active)
+func (u *User) CurrentStatus() UserStatus {
+	if u.Status == "" {
+		return UserActive
+	}
+	return u.Status
+}
+
+// ChangeStatus - 허용된 전이만 적용하고 사유와 주체를 기록 (도메인 로직)
+func (u *User) ChangeStatus(to UserStatus, reason, actor string) error {
+	if !to.Valid() {
+		return ErrInvalidUserStatus
+	}
+	from := u.CurrentStatus()
+	if !from.CanTransitionTo(to) {
+		return ErrInvalidStatusTransition
+	}
+	if reason == "" && (to == UserSuspended || to == UserDeactivated) {
+		return ErrStatusReasonRequired
+	}
+	if actor == "" {
+		return ErrStatusActorRequired
+	}
+
+	now := time.Now()
+	u.Status = to
+	u.StatusHistory = append(u.StatusHistory, StatusChange{
+		From:   from,
+		To:     to,
+		Reason: reason,
+		Actor:  actor,
+		At:     now,
+	})
+	u.UpdatedAt = now
+	return nil
+}
+
+// CheckMutable - 프로필을 바꿀 수 있는 상태인지 (정지/비활성화된 계정은 수정 불가)
+func (u *User) CheckMutable() error {
+	switch u.CurrentStatus() {
+	case UserSuspended:
+		return ErrUserSuspended
+	case UserDeactivated:
+		return ErrUserDeactivated
+	default:
+		return nil
+	}
+}
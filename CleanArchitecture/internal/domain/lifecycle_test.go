@@ -0,0 +1,155 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// 모든 상태 쌍 (from → to)의 허용 여부
+func TestUserStatusTransitions(t *testing.T) {
+	allowed := map[[2]domain.UserStatus]bool{
+		{domain.UserPending, domain.UserActive}:        true,
+		{domain.UserPending, domain.UserDeactivated}:   true,
+		{domain.UserActive, domain.UserSuspended}:      true,
+		{domain.UserActive, domain.UserDeactivated}:    true,
+		{domain.UserSuspended, domain.UserActive}:      true,
+		{domain.UserSuspended, domain.UserDeactivated}: true,
+	}
+
+	for _, from := range domain.UserStatuses {
+		for _, to := range domain.UserStatuses {
+			want := allowed[[2]domain.UserStatus{from, to}]
+			t.Run(string(from)+"→"+string(to), func(t *testing.T) {
+				if got := from.CanTransitionTo(to); got != want {
+					t.Fatalf("CanTransitionTo: got %v, want %v", got, want)
+				}
+
+				user := &domain.User{ID: "u1", Status: from}
+				err := user.ChangeStatus(to, "reason", "admin")
+				if !want {
+					if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+						t.Fatalf("ChangeStatus: got %v, want ErrInvalidStatusTransition", err)
+					}
+					if user.Status != from || len(user.StatusHistory) != 0 {
+						t.Fatalf("rejected change modified user: status %q, history %v", user.Status, user.StatusHistory)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("ChangeStatus: %v", err)
+				}
+				if user.Status != to {
+					t.Fatalf("status: got %q, want %q", user.Status, to)
+				}
+			})
+		}
+	}
+}
+
+func TestChangeStatusChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   domain.UserStatus
+		to     domain.UserStatus
+		reason string
+		actor  string
+		want   error
+	}{
+		{"activate without reason", domain.UserPending, domain.UserActive, "", "admin", nil},
+		{"reactivate without reason", domain.UserSuspended, domain.UserActive, "", "admin", nil},
+		{"suspend without reason", domain.UserActive, domain.UserSuspended, "", "admin", domain.ErrStatusReasonRequired},
+		{"deactivate without reason", domain.UserActive, domain.UserDeactivated, "", "admin", domain.ErrStatusReasonRequired},
+		{"deactivate pending without reason", domain.UserPending, domain.UserDeactivated, "", "admin", domain.ErrStatusReasonRequired},
+		{"suspend with reason", domain.UserActive, domain.UserSuspended, "spam", "admin", nil},
+		{"activate without actor", domain.UserPending, domain.UserActive, "", "", domain.ErrStatusActorRequired},
+		{"suspend without actor", domain.UserActive, domain.UserSuspended, "spam", "", domain.ErrStatusActorRequired},
+		{"unknown status", domain.UserActive, domain.UserStatus("banned"), "spam", "admin", domain.ErrInvalidUserStatus},
+		{"legacy user counts as active", "", domain.UserSuspended, "spam", "admin", nil},
+		{"legacy user cannot activate", "", domain.UserActive, "", "admin", domain.ErrInvalidStatusTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &domain.User{ID: "u1", Status: tt.from}
+			err := user.ChangeStatus(tt.to, tt.reason, tt.actor)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ChangeStatus: got %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && (user.Status != tt.from || len(user.StatusHistory) != 0) {
+				t.Fatalf("rejected change modified user: status %q, history %v", user.Status, user.StatusHistory)
+			}
+		})
+	}
+}
+
+// 변경마다 이전/다음 상태, 사유, 주체가 순서대로 기록되고 UpdatedAt이 변경 시각으로 바뀜
+func TestChangeStatusHistory(t *testing.T) {
+	user := &domain.User{ID: "u1", Status: domain.UserPending}
+	steps := []domain.StatusChange{
+		{From: domain.UserPending, To: domain.UserActive, Actor: "system"},
+		{From: domain.UserActive, To: domain.UserSuspended, Reason: "spam", Actor: "admin-1"},
+		{From: domain.UserSuspended, To: domain.UserActive, Reason: "appeal accepted", Actor: "admin-2"},
+		{From: domain.UserActive, To: domain.UserDeactivated, Reason: "account closed", Actor: "admin-1"},
+	}
+
+	for i, step := range steps {
+		if err := user.ChangeStatus(step.To, step.Reason, step.Actor); err != nil {
+			t.Fatalf("step %d: ChangeStatus(%s): %v", i, step.To, err)
+		}
+		if len(user.StatusHistory) != i+1 {
+			t.Fatalf("step %d: history length %d, want %d", i, len(user.StatusHistory), i+1)
+		}
+		got := user.StatusHistory[i]
+		if got.From != step.From || got.To != step.To || got.Reason != step.Reason || got.Actor != step.Actor {
+			t.Fatalf("step %d: got %+v, want %+v", i, got, step)
+		}
+		if got.At.IsZero() || !user.UpdatedAt.Equal(got.At) {
+			t.Fatalf("step %d: At %v, UpdatedAt %v", i, got.At, user.UpdatedAt)
+		}
+		if i > 0 && got.At.Before(user.StatusHistory[i-1].At) {
+			t.Fatalf("step %d: At %v before previous %v", i, got.At, user.StatusHistory[i-1].At)
+		}
+	}
+
+	// 비활성화 이후에는 어떤 변경도 기록되지 않음
+	if err := user.ChangeStatus(domain.UserActive, "", "admin-1"); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("ChangeStatus after deactivation: got %v, want ErrInvalidStatusTransition", err)
+	}
+	if len(user.StatusHistory) != len(steps) {
+		t.Fatalf("history length after rejected change: %d, want %d", len(user.StatusHistory), len(steps))
+	}
+}
+
+func TestCheckMutable(t *testing.T) {
+	tests := []struct {
+		status domain.UserStatus
+		want   error
+	}{
+		{"", nil},
+		{domain.UserPending, nil},
+		{domain.UserActive, nil},
+		{domain.UserSuspended, domain.ErrUserSuspended},
+		{domain.UserDeactivated, domain.ErrUserDeactivated},
+	}
+	for _, tt := range tests {
+		user := &domain.User{ID: "u1", Status: tt.status}
+		if err := user.CheckMutable(); !errors.Is(err, tt.want) {
+			t.Errorf("CheckMutable(%q): got %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
+
+func TestParseUserStatus(t *testing.T) {
+	for _, status := range domain.UserStatuses {
+		got, err := domain.ParseUserStatus(string(status))
+		if err != nil || got != status {
+			t.Errorf("ParseUserStatus(%q): got %q, %v", status, got, err)
+		}
+	}
+	for _, s := range []string{"", "Active", "banned"} {
+		if _, err := domain.ParseUserStatus(s); !errors.Is(err, domain.ErrInvalidUserStatus) {
+			t.Errorf("ParseUserStatus(%q): got %v, want ErrInvalidUserStatus", s, err)
+		}
+	}
+}
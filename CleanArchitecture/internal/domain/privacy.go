@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// ErasureMode - 개인정보 삭제 방식
+type ErasureMode string
+
+const (
+	ErasureDelete    ErasureMode = "delete"    // 사용자 행까지 삭제
+	ErasureAnonymize ErasureMode = "anonymize" // 행은 남기고 개인정보만 지움 (감사 기록 보존)
+)
+
+// ErasureModes - 모든 삭제 방식
+var ErasureModes = []ErasureMode{ErasureDelete, ErasureAnonymize}
+
+// Valid - 알려진 방식인지
+func (m ErasureMode) Valid() bool {
+	return m == ErasureDelete || m == ErasureAnonymize
+}
+
+const (
+	// ErasedName - 익명화된 사용자 이름
+	ErasedName = "Erased User"
+	// ErasureReason - 익명화로 비활성화할 때 남기는 상태 변경 사유
+	ErasureReason = "erasure"
+)
+
+// ErasedEmail - 익명화된 사용자 이메일 (테넌트 안에서 고유하도록 ID 사용, .invalid는 배달되지 않는 도메인)
+func ErasedEmail(id string) string {
+	return "erased-" + id + "@erased.invalid"
+}
+
+// Anonymize - 개인정보를 지우고 계정을 비활성화 (도메인 로직)
+// 이름/이메일/속성과 상태 변경 사유는 지우고, 상태 전이의 주체와 시각은 감사용으로 남김
+func (u *User) Anonymize(actor string) error {
+	for i := range u.StatusHistory {
+		u.StatusHistory[i].Reason = ""
+	}
+	if u.CurrentStatus() != UserDeactivated {
+		if err := u.ChangeStatus(UserDeactivated, ErasureReason, actor); err != nil {
+			return err
+		}
+	}
+
+	u.Email = ErasedEmail(u.ID)
+	u.Name = ErasedName
+	u.Attributes = nil
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// Anonymized - 익명화된 사용자인지
+func (u *User) Anonymized() bool {
+	return u.Email == ErasedEmail(u.ID)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"time"
 )
 
@@ -8,10 +9,16 @@ import (
 // 비즈니스 로직의 핵심, 외부 의존성이 전혀 없음
 type User struct {
 	ID        string
+	TenantID  string // 소속 조직 (이메일은 조직 안에서만 고유)
 	Email     string
 	Name      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	Attributes Attributes // 네임스페이스별 사용자 정의 속성 (없으면 nil)
+
+	Status        UserStatus     // 계정 상태 (빈 값은 active로 취급, CurrentStatus 참고)
+	StatusHistory []StatusChange // 상태 변경 기록 (오래된 순)
 }
 
 // NewUser - User 생성 팩토리 함수 (비즈니스 규칙 적용)
@@ -29,6 +36,7 @@ func NewUser(email, name string) (*User, error) {
 		Name:      name,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Status:    UserActive,
 	}, nil
 }
 
@@ -53,3 +61,10 @@ func (u *User) UpdateName(name string) error {
 	return nil
 }
 
+// Clone - 속성 맵까지 복사한 사본 (저장소가 불변성을 지키기 위해 사용)
+func (u *User) Clone() *User {
+	c := *u
+	c.Attributes = u.Attributes.Clone()
+	c.StatusHistory = slices.Clone(u.StatusHistory)
+	return &c
+}
@@ -0,0 +1,155 @@
+// Package fieldcrypt - 저장소 어댑터용 필드 단위 봉투 암호화와 블라인드 인덱스
+//
+// 값마다 무작위 데이터 키(DEK)로 AES-256-GCM 암호화하고, DEK는 키링의 키 암호화 키(KEK)로 감싼다.
+// 봉투에는 KEK ID가 들어 있어 키를 교체해도 이전 데이터를 읽을 수 있고,
+// 이전 키로 암호화된 값은 Open이 stale로 알려 주므로 저장소가 다음에 쓸 때 새 키로 다시 암호화한다.
+// 암호문은 무작위라 같은 값끼리 비교할 수 없으므로, 조회와 고유성 검사에는
+// 별도 키로 계산한 HMAC-SHA256 블라인드 인덱스를 함께 저장한다.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// envelopeVersion - 봉투 형식 버전 ("v1.<KEK ID>.<감싼 DEK>.<암호문>", 이진 부분은 base64url)
+const envelopeVersion = "v1"
+
+// ErrMalformedEnvelope - 봉투 형식이 잘못되었거나 인증에 실패함 (변조 또는 다른 필드/행의 값)
+var ErrMalformedEnvelope = errors.New("fieldcrypt: malformed or tampered envelope")
+
+// ErrCipherRequired - 암호화된 레코드를 Cipher 없이 읽으려 함
+var ErrCipherRequired = errors.New("fieldcrypt: encrypted record requires a keyring")
+
+var b64 = base64.RawURLEncoding
+
+// Cipher - 키링을 사용하는 봉투 암호화기 (여러 고루틴에서 사용 가능)
+type Cipher struct {
+	keys Keyring
+}
+
+// New - Cipher 생성자
+func New(keys Keyring) *Cipher {
+	return &Cipher{keys: keys}
+}
+
+// Seal - 평문을 Primary 키로 봉투 암호화
+// aad는 값이 속한 위치 (예: "users/<테넌트>/<ID>/email")로, 다른 행이나 필드로 옮긴 암호문은 열리지 않음
+func (c *Cipher) Seal(plaintext []byte, aad string) (string, error) {
+	kek, err := c.keys.Primary()
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := randomBytes(KeySize)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := gcmSeal(dek, plaintext, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := gcmSeal(kek.Secret, dek, []byte(kek.ID))
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{envelopeVersion, kek.ID, b64.EncodeToString(wrapped), b64.EncodeToString(ciphertext)}, "."), nil
+}
+
+// Open - 봉투를 열어 평문 반환
+// stale은 Primary가 아닌 키로 암호화되어 다시 암호화해야 하는지
+func (c *Cipher) Open(envelope, aad string) (plaintext []byte, stale bool, err error) {
+	parts := strings.Split(envelope, ".")
+	if len(parts) != 4 || parts[0] != envelopeVersion {
+		return nil, false, ErrMalformedEnvelope
+	}
+	wrapped, err := b64.DecodeString(parts[2])
+	if err != nil {
+		return nil, false, ErrMalformedEnvelope
+	}
+	ciphertext, err := b64.DecodeString(parts[3])
+	if err != nil {
+		return nil, false, ErrMalformedEnvelope
+	}
+
+	kek, err := c.keys.Key(parts[1])
+	if err != nil {
+		return nil, false, err
+	}
+	dek, err := gcmOpen(kek.Secret, wrapped, []byte(kek.ID))
+	if err != nil {
+		return nil, false, err
+	}
+	if plaintext, err = gcmOpen(dek, ciphertext, []byte(aad)); err != nil {
+		return nil, false, err
+	}
+
+	primary, err := c.keys.Primary()
+	if err != nil {
+		return nil, false, err
+	}
+	return plaintext, kek.ID != primary.ID, nil
+}
+
+// IsSealed - 값이 봉투 형식인지 (암호화를 켜기 전에 쓴 평문 컬럼과 구분할 때 사용)
+// 이메일은 항상 '@'를 포함하고 base64url 문자에는 '@'가 없으므로 이메일 컬럼에서는 혼동하지 않음
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, envelopeVersion+".") && !strings.Contains(value, "@")
+}
+
+// BlindIndex - 값의 블라인드 인덱스 (HMAC-SHA256, 16진수 64자)
+// scope는 인덱스 범위 (예: 테넌트와 필드)로, 같은 값이라도 범위가 다르면 인덱스가 다름
+func (c *Cipher) BlindIndex(scope, value string) (string, error) {
+	key, err := c.keys.IndexKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// gcmSeal - AES-256-GCM 암호화 (nonce를 앞에 붙임)
+func gcmSeal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func gcmOpen(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedEnvelope
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrMalformedEnvelope
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
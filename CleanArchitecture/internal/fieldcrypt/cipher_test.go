@@ -0,0 +1,107 @@
+package fieldcrypt_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+)
+
+func testKey(id string, fill byte) fieldcrypt.Key {
+	return fieldcrypt.Key{ID: id, Secret: bytes.Repeat([]byte{fill}, fieldcrypt.KeySize)}
+}
+
+func newCipher(t *testing.T, keys ...fieldcrypt.Key) *fieldcrypt.Cipher {
+	t.Helper()
+	ring, err := fieldcrypt.NewStaticKeyring(bytes.Repeat([]byte{0xff}, fieldcrypt.KeySize), keys...)
+	if err != nil {
+		t.Fatalf("NewStaticKeyring: %v", err)
+	}
+	return fieldcrypt.New(ring)
+}
+
+func TestSealOpen(t *testing.T) {
+	c := newCipher(t, testKey("k1", 1))
+
+	a, err := c.Seal([]byte("alice@example.com"), "users/acme/1/email")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	b, _ := c.Seal([]byte("alice@example.com"), "users/acme/1/email")
+	if a == b || strings.Contains(a, "alice") || !fieldcrypt.IsSealed(a) {
+		t.Fatalf("envelopes must be random and opaque: %q, %q", a, b)
+	}
+
+	plaintext, stale, err := c.Open(a, "users/acme/1/email")
+	if err != nil || stale || string(plaintext) != "alice@example.com" {
+		t.Fatalf("Open = %q, %v, %v", plaintext, stale, err)
+	}
+
+	// 다른 행/필드로 옮기거나 변조한 봉투는 열리지 않음
+	if _, _, err := c.Open(a, "users/acme/2/email"); !errors.Is(err, fieldcrypt.ErrMalformedEnvelope) {
+		t.Errorf("Open with other aad: got %v, want ErrMalformedEnvelope", err)
+	}
+	tampered := a[:len(a)-2] + "AA"
+	if _, _, err := c.Open(tampered, "users/acme/1/email"); !errors.Is(err, fieldcrypt.ErrMalformedEnvelope) {
+		t.Errorf("Open tampered: got %v, want ErrMalformedEnvelope", err)
+	}
+	if _, _, err := c.Open("alice@example.com", "users/acme/1/email"); !errors.Is(err, fieldcrypt.ErrMalformedEnvelope) {
+		t.Errorf("Open plaintext: got %v, want ErrMalformedEnvelope", err)
+	}
+}
+
+func TestOpenAfterRotation(t *testing.T) {
+	old := newCipher(t, testKey("k1", 1))
+	envelope, err := old.Seal([]byte("secret"), "aad")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	rotated := newCipher(t, testKey("k1", 1), testKey("k2", 2))
+	plaintext, stale, err := rotated.Open(envelope, "aad")
+	if err != nil || !stale || string(plaintext) != "secret" {
+		t.Fatalf("Open old envelope = %q, stale %v, %v", plaintext, stale, err)
+	}
+	resealed, _ := rotated.Seal(plaintext, "aad")
+	if _, stale, _ := rotated.Open(resealed, "aad"); stale {
+		t.Error("envelope sealed with primary key reported stale")
+	}
+
+	// 이전 키를 지우면 복호화할 수 없음
+	if _, _, err := newCipher(t, testKey("k2", 2)).Open(envelope, "aad"); !errors.Is(err, fieldcrypt.ErrUnknownKey) {
+		t.Errorf("Open without old key: got %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestSealUser(t *testing.T) {
+	c := newCipher(t, testKey("k1", 1))
+	user := &domain.User{ID: "1", TenantID: "acme", Email: "alice@example.com", Name: "Alice"}
+
+	sealed, err := c.SealUser(user)
+	if err != nil {
+		t.Fatalf("SealUser: %v", err)
+	}
+	index, _ := c.EmailIndex("acme", "alice@example.com")
+	if sealed.EmailIndex != index || len(index) != 64 {
+		t.Fatalf("EmailIndex = %q, want deterministic %q", sealed.EmailIndex, index)
+	}
+	if other, _ := c.EmailIndex("globex", "alice@example.com"); other == index {
+		t.Error("blind index must differ between tenants")
+	}
+
+	opened := &domain.User{ID: "1", TenantID: "acme"}
+	if stale, err := c.OpenUser(opened, sealed); err != nil || stale {
+		t.Fatalf("OpenUser: stale %v, %v", stale, err)
+	}
+	if opened.Email != user.Email || opened.Name != user.Name {
+		t.Fatalf("OpenUser = %+v", opened)
+	}
+
+	// 다른 사용자 행에 복사한 암호문은 열리지 않음
+	if _, err := c.OpenUser(&domain.User{ID: "2", TenantID: "acme"}, sealed); !errors.Is(err, fieldcrypt.ErrMalformedEnvelope) {
+		t.Errorf("OpenUser on other row: got %v, want ErrMalformedEnvelope", err)
+	}
+}
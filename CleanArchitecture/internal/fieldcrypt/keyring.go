@@ -0,0 +1,290 @@
+package fieldcrypt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// KeySize - 키 암호화 키와 인덱스 키 길이 (AES-256, HMAC-SHA256)
+const KeySize = 32
+
+var (
+	// ErrUnknownKey - 봉투에 적힌 키가 키링에 없음 (지운 키로 암호화된 데이터는 복호화할 수 없음)
+	ErrUnknownKey = errors.New("fieldcrypt: unknown key")
+	// ErrInvalidKey - 키 ID 형식이나 길이가 잘못됨
+	ErrInvalidKey = errors.New("fieldcrypt: invalid key")
+)
+
+// keyIDPattern - 키 ID 규칙 (봉투 구분자 '.'를 쓸 수 없음)
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Key - 키 암호화 키 (KEK)
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+func (k Key) validate() error {
+	if !keyIDPattern.MatchString(k.ID) || len(k.Secret) != KeySize {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, k.ID)
+	}
+	return nil
+}
+
+// Keyring - 키 보관소 (포트)
+// 새 데이터는 Primary로 암호화하고, 이전 키는 복호화를 위해 남겨 둠 (키 교체)
+// 블라인드 인덱스 키는 교체하면 모든 인덱스를 다시 만들어야 하므로 따로 관리
+// 운영에서는 KMS 어댑터를, 개발에서는 FileKeyring을 사용
+type Keyring interface {
+	// Primary - 새 데이터를 암호화할 키
+	Primary() (Key, error)
+	// Key - ID로 키 조회 (없으면 ErrUnknownKey)
+	Key(id string) (Key, error)
+	// IndexKey - 블라인드 인덱스 HMAC 키
+	IndexKey() ([]byte, error)
+}
+
+// StaticKeyring - 메모리에 고정된 키링 (테스트용)
+type StaticKeyring struct {
+	primary Key
+	keys    map[string]Key
+	index   []byte
+}
+
+// NewStaticKeyring - StaticKeyring 생성자 (마지막 키가 Primary)
+func NewStaticKeyring(indexKey []byte, keys ...Key) (*StaticKeyring, error) {
+	if len(keys) == 0 || len(indexKey) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	k := &StaticKeyring{keys: make(map[string]Key, len(keys)), index: indexKey}
+	for _, key := range keys {
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		k.keys[key.ID] = key
+	}
+	k.primary = keys[len(keys)-1]
+	return k, nil
+}
+
+func (k *StaticKeyring) Primary() (Key, error) {
+	return k.primary, nil
+}
+
+func (k *StaticKeyring) Key(id string) (Key, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	return key, nil
+}
+
+func (k *StaticKeyring) IndexKey() ([]byte, error) {
+	return k.index, nil
+}
+
+// FileKeyring - JSON 파일 키링 (개발용, 키가 평문으로 저장되므로 운영에서는 쓰지 않음)
+// 파일이 없으면 새 키를 만들어 0600 권한으로 저장하고, Rotate는 새 키를 Primary로 추가
+// 다른 프로세스가 교체한 키는 다시 열어야 보임 (서버는 재시작 필요)
+type FileKeyring struct {
+	mu   sync.RWMutex
+	path string
+	file keyringFile
+	keys map[string]Key
+}
+
+// keyringFile - 키링 파일 형식
+type keyringFile struct {
+	Primary  string          `json:"primary"`
+	IndexKey string          `json:"index_key"` // base64
+	Keys     []keyringRecord `json:"keys"`
+}
+
+type keyringRecord struct {
+	ID        string    `json:"id"`
+	Secret    string    `json:"secret"` // base64
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KeyInfo - 키 목록 항목 (비밀 값 제외)
+type KeyInfo struct {
+	ID        string
+	CreatedAt time.Time
+	Primary   bool
+}
+
+// OpenFileKeyring - 키링 파일 열기 (없으면 새로 만듦)
+func OpenFileKeyring(path string) (*FileKeyring, error) {
+	k := &FileKeyring{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		index, err := randomBytes(KeySize)
+		if err != nil {
+			return nil, err
+		}
+		k.file.IndexKey = base64.StdEncoding.EncodeToString(index)
+		if _, err := k.rotate(); err != nil {
+			return nil, err
+		}
+		return k, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &k.file); err != nil {
+		return nil, fmt.Errorf("keyring %s: %w", path, err)
+	}
+	if err := k.index(); err != nil {
+		return nil, fmt.Errorf("keyring %s: %w", path, err)
+	}
+	return k, nil
+}
+
+// index - 파일 내용 검증 후 ID → 키 맵 구성
+func (k *FileKeyring) index() error {
+	if index, err := base64.StdEncoding.DecodeString(k.file.IndexKey); err != nil || len(index) != KeySize {
+		return fmt.Errorf("%w: index_key", ErrInvalidKey)
+	}
+	keys := make(map[string]Key, len(k.file.Keys))
+	for _, rec := range k.file.Keys {
+		secret, err := base64.StdEncoding.DecodeString(rec.Secret)
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, rec.ID)
+		}
+		key := Key{ID: rec.ID, Secret: secret}
+		if err := key.validate(); err != nil {
+			return err
+		}
+		keys[rec.ID] = key
+	}
+	if _, ok := keys[k.file.Primary]; !ok {
+		return fmt.Errorf("%w: primary %q", ErrUnknownKey, k.file.Primary)
+	}
+	k.keys = keys
+	return nil
+}
+
+func (k *FileKeyring) Primary() (Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.keys[k.file.Primary], nil
+}
+
+func (k *FileKeyring) Key(id string) (Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[id]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	return key, nil
+}
+
+func (k *FileKeyring) IndexKey() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return base64.StdEncoding.DecodeString(k.file.IndexKey)
+}
+
+// Keys - 키 목록 (오래된 순)
+func (k *FileKeyring) Keys() []KeyInfo {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	infos := make([]KeyInfo, len(k.file.Keys))
+	for i, rec := range k.file.Keys {
+		infos[i] = KeyInfo{ID: rec.ID, CreatedAt: rec.CreatedAt, Primary: rec.ID == k.file.Primary}
+	}
+	return infos
+}
+
+// Rotate - 새 키를 만들어 Primary로 지정하고 파일에 저장
+// 이전 키는 남겨 두며, 기존 데이터는 저장소가 다음에 쓸 때 새 키로 다시 암호화함
+func (k *FileKeyring) Rotate() (KeyInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.rotate()
+}
+
+// rotate - 호출자가 쓰기 잠금 보유 (또는 초기화 중)
+func (k *FileKeyring) rotate() (KeyInfo, error) {
+	secret, err := randomBytes(KeySize)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	suffix, err := randomBytes(4)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	now := time.Now().UTC()
+	rec := keyringRecord{
+		ID:        now.Format("20060102") + "-" + hex.EncodeToString(suffix),
+		Secret:    base64.StdEncoding.EncodeToString(secret),
+		CreatedAt: now,
+	}
+
+	prev := k.file
+	k.file.Keys = append(append([]keyringRecord(nil), prev.Keys...), rec)
+	k.file.Primary = rec.ID
+	if err := k.index(); err != nil {
+		k.file = prev
+		return KeyInfo{}, err
+	}
+	if err := k.save(); err != nil {
+		k.file = prev
+		k.index()
+		return KeyInfo{}, err
+	}
+	return KeyInfo{ID: rec.ID, CreatedAt: rec.CreatedAt, Primary: true}, nil
+}
+
+// save - 임시 파일에 쓴 뒤 rename (소유자만 읽을 수 있게 0600)
+func (k *FileKeyring) save() error {
+	data, err := json.MarshalIndent(k.file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(k.path), filepath.Base(k.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), k.path)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
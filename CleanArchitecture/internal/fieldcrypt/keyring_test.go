@@ -0,0 +1,71 @@
+package fieldcrypt_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+)
+
+func TestFileKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	keys, err := fieldcrypt.OpenFileKeyring(path)
+	if err != nil {
+		t.Fatalf("OpenFileKeyring (create): %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("keyring file not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keyring file mode = %o, want 600", perm)
+	}
+	first, _ := keys.Primary()
+	envelope, err := fieldcrypt.New(keys).Seal([]byte("secret"), "aad")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	rotated, err := keys.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if rotated.ID == first.ID || !rotated.Primary {
+		t.Fatalf("Rotate = %+v (previous primary %s)", rotated, first.ID)
+	}
+
+	// 다시 열면 교체한 키가 Primary이고 이전 키와 인덱스 키는 유지됨
+	reopened, err := fieldcrypt.OpenFileKeyring(path)
+	if err != nil {
+		t.Fatalf("OpenFileKeyring (reopen): %v", err)
+	}
+	if primary, _ := reopened.Primary(); primary.ID != rotated.ID {
+		t.Errorf("reopened primary = %s, want %s", primary.ID, rotated.ID)
+	}
+	if got := reopened.Keys(); len(got) != 2 || got[0].ID != first.ID || got[0].Primary {
+		t.Errorf("reopened keys = %+v", got)
+	}
+	a, _ := keys.IndexKey()
+	b, _ := reopened.IndexKey()
+	if !bytes.Equal(a, b) {
+		t.Error("index key changed across rotation")
+	}
+
+	plaintext, stale, err := fieldcrypt.New(reopened).Open(envelope, "aad")
+	if err != nil || !stale || string(plaintext) != "secret" {
+		t.Fatalf("Open after rotation = %q, stale %v, %v", plaintext, stale, err)
+	}
+}
+
+func TestFileKeyringInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	if err := os.WriteFile(path, []byte(`{"primary":"missing","index_key":"","keys":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fieldcrypt.OpenFileKeyring(path); err == nil {
+		t.Fatal("OpenFileKeyring accepted an invalid keyring")
+	}
+}
@@ -0,0 +1,60 @@
+package fieldcrypt
+
+import (
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// 암호화하는 사용자 필드 (봉투 aad와 인덱스 범위에 들어가므로 바꾸면 기존 데이터를 읽을 수 없음)
+const (
+	fieldEmail = "email"
+	fieldName  = "name"
+)
+
+// SealedUser - 저장소에 쓰는 사용자 PII 암호문
+// 속성과 상태 변경 기록은 조회 조건에 쓰이므로 암호화하지 않음
+type SealedUser struct {
+	Email      string // 이메일 봉투
+	EmailIndex string // 이메일 블라인드 인덱스 (GetByEmail과 테넌트 안 고유성 검사용)
+	Name       string // 이름 봉투
+}
+
+// SealUser - 사용자의 이메일과 이름 암호화
+func (c *Cipher) SealUser(user *domain.User) (SealedUser, error) {
+	email, err := c.Seal([]byte(user.Email), userAAD(user, fieldEmail))
+	if err != nil {
+		return SealedUser{}, err
+	}
+	name, err := c.Seal([]byte(user.Name), userAAD(user, fieldName))
+	if err != nil {
+		return SealedUser{}, err
+	}
+	index, err := c.EmailIndex(user.TenantID, user.Email)
+	if err != nil {
+		return SealedUser{}, err
+	}
+	return SealedUser{Email: email, EmailIndex: index, Name: name}, nil
+}
+
+// OpenUser - 암호문을 풀어 user의 이메일과 이름을 채움 (ID와 테넌트는 미리 채워져 있어야 함)
+// stale은 이전 키로 암호화되어 다시 써야 하는지
+func (c *Cipher) OpenUser(user *domain.User, sealed SealedUser) (stale bool, err error) {
+	email, staleEmail, err := c.Open(sealed.Email, userAAD(user, fieldEmail))
+	if err != nil {
+		return false, err
+	}
+	name, staleName, err := c.Open(sealed.Name, userAAD(user, fieldName))
+	if err != nil {
+		return false, err
+	}
+	user.Email, user.Name = string(email), string(name)
+	return staleEmail || staleName, nil
+}
+
+// EmailIndex - 테넌트 안 이메일 블라인드 인덱스 (테넌트마다 값이 달라 테넌트 간 같은 이메일을 연결할 수 없음)
+func (c *Cipher) EmailIndex(tenantID, email string) (string, error) {
+	return c.BlindIndex("users/"+tenantID+"/"+fieldEmail, email)
+}
+
+func userAAD(user *domain.User, field string) string {
+	return "users/" + user.TenantID + "/" + user.ID + "/" + field
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+// catalogs - 언어별 코드 → 메시지 (영어는 에러 정의의 기본 메시지를 쓰므로 없음)
+var catalogs = map[language.Tag]map[string]string{
+	language.Korean: ko,
+}
+
+// ko - 한국어 메시지 (새 에러 코드를 추가하면 여기에도 추가; 누락은 테스트가 잡음)
+var ko = map[string]string{
+	// 사용자
+	"user_not_found":      "사용자를 찾을 수 없습니다",
+	"user_exists":         "이미 존재하는 사용자입니다",
+	"invalid_email":       "이메일 형식이 올바르지 않습니다",
+	"invalid_name":        "이름이 올바르지 않습니다",
+	"invalid_user_id":     "사용자 ID가 올바르지 않습니다",
+	"invalid_query":       "검색어가 올바르지 않습니다",
+	"user_modified":       "다른 요청이 사용자를 먼저 변경했습니다. 다시 시도하세요",
+	"precondition_failed": "사용자가 If-Match로 지정한 버전과 다릅니다",
+
+	// 계정 상태
+	"invalid_user_status":       "계정 상태가 올바르지 않습니다",
+	"invalid_status_transition": "현재 상태에서 그 상태로 바꿀 수 없습니다",
+	"status_reason_required":    "상태를 바꾸는 사유를 입력해야 합니다",
+	"status_actor_required":     "상태를 바꾼 주체가 필요합니다",
+	"user_suspended":            "정지된 계정입니다",
+	"user_deactivated":          "비활성화된 계정입니다",
+	"invalid_erasure_mode":      "개인정보 삭제 방식이 올바르지 않습니다",
+
+	// 테넌트
+	"tenant_required": "테넌트를 지정해야 합니다",
+	"invalid_tenant":  "테넌트가 올바르지 않습니다",
+	"cross_tenant":    "다른 테넌트의 데이터에는 접근할 수 없습니다",
+
+	// 사용자 정의 속성
+	"invalid_attribute_namespace": "속성 네임스페이스 이름이 올바르지 않습니다",
+	"unknown_attribute_namespace": "스키마가 등록되지 않은 속성 네임스페이스입니다",
+	"invalid_attributes":          "속성이 스키마와 맞지 않습니다",
+	"invalid_attribute_filter":    "속성 조건이 올바르지 않습니다",
+	"attribute_schema_not_found":  "속성 스키마를 찾을 수 없습니다",
+	"invalid_attribute_schema":    "속성 스키마가 올바르지 않습니다",
+
+	// 아바타
+	"avatar_not_found":       "아바타가 없습니다",
+	"image_too_large":        "이미지 파일이 너무 큽니다",
+	"unsupported_image":      "지원하지 않는 이미지 형식입니다",
+	"invalid_image":          "이미지가 올바르지 않습니다",
+	"invalid_avatar_variant": "아바타 종류가 올바르지 않습니다",
+	"avatar_unavailable":     "아바타 저장소가 설정되지 않았습니다",
+	"avatar_field_missing":   "avatar 필드가 없습니다",
+	"multipart_required":     "multipart/form-data 형식으로 보내야 합니다",
+	"blob_not_found":         "파일을 찾을 수 없습니다",
+	"invalid_blob_key":       "파일 키가 올바르지 않습니다",
+
+	// 일괄 처리
+	"invalid_batch_size":       "한 번에 1개에서 100개까지 처리할 수 있습니다",
+	"duplicate_batch_item":     "같은 요청에 중복된 항목이 있습니다",
+	"batch_aborted":            "다른 항목이 실패해 반영되지 않았습니다",
+	"transactions_unsupported": "현재 저장소는 원자적 일괄 처리를 지원하지 않습니다",
+
+	// 검색
+	"search_unavailable": "사용자 검색을 사용할 수 없습니다",
+	"invalid_limit":      "limit 값이 올바르지 않습니다",
+
+	// 웹훅
+	"webhook_not_found":             "웹훅 구독을 찾을 수 없습니다",
+	"webhook_delivery_not_found":    "웹훅 전송 기록을 찾을 수 없습니다",
+	"invalid_webhook_url":           "웹훅 URL이 올바르지 않습니다",
+	"webhook_destination_forbidden": "웹훅 URL이 루프백/링크 로컬/사설 네트워크 주소를 가리킵니다",
+	"invalid_webhook_event":         "웹훅 이벤트 종류가 올바르지 않습니다",
+	"webhook_disabled":              "비활성화된 웹훅 구독입니다",
+	"missing_webhook_signature":     "웹훅 서명이 없습니다",
+	"invalid_webhook_signature":     "웹훅 서명이 올바르지 않습니다",
+	"stale_webhook_timestamp":       "웹훅 타임스탬프가 허용 범위를 벗어났습니다",
+
+	// 개인정보 요청
+	"privacy_request_not_found":   "개인정보 요청을 찾을 수 없습니다",
+	"privacy_request_in_progress": "이 사용자에 대해 같은 종류의 개인정보 요청을 처리하고 있습니다",
+	"invalid_archive_format":      "내보내기 파일 형식이 올바르지 않습니다",
+	"archive_not_ready":           "내보내기 파일이 아직 준비되지 않았습니다",
+	"archive_expired":             "내보내기 파일 보관 기간이 지났습니다",
+	"export_unavailable":          "내보내기 파일 저장소가 설정되지 않았습니다",
+
+	// 멱등성 키
+	"invalid_idempotency_key": "멱등성 키가 올바르지 않습니다",
+	"idempotency_in_progress": "같은 멱등성 키의 요청을 처리하고 있습니다",
+	"idempotency_key_reused":  "멱등성 키가 다른 요청에 다시 사용되었습니다",
+
+	// 일괄 가져오기
+	"unsupported_format":       "지원하지 않는 형식입니다",
+	"unsupported_content_type": "Content-Type은 text/csv 또는 application/x-ndjson이어야 합니다",
+	"missing_columns":          "CSV 헤더에 email과 name 열이 있어야 합니다",
+	"malformed_row":            "행 형식이 올바르지 않습니다",
+	"invalid_dry_run":          "dry_run 값이 올바르지 않습니다",
+
+	// GraphQL
+	"invalid_cursor": "커서가 올바르지 않습니다",
+	"invalid_first":  "first는 0 이상 100 이하여야 합니다",
+
+	// 주기 작업
+	"job_not_found":  "작업을 찾을 수 없습니다",
+	"job_running":    "작업이 이미 실행 중입니다",
+	"job_lease_held": "다른 인스턴스에서 작업을 실행하고 있습니다",
+
+	// 관리자
+	"admin_token_required": "관리자 토큰이 필요합니다",
+	"schema_too_large":     "스키마 문서가 너무 큽니다",
+
+	// 요청 일반 (HTTP 상태별)
+	"invalid_request_body":   "요청 본문이 올바르지 않습니다",
+	"invalid_request":        "요청이 API 명세와 맞지 않습니다",
+	"json_required":          "Content-Type은 application/json이어야 합니다",
+	"empty_body":             "요청 본문이 비어 있습니다",
+	"malformed_json":         "JSON 형식이 올바르지 않습니다",
+	"invalid_field_type":     "필드 값의 타입이 올바르지 않습니다",
+	"unknown_field":          "알 수 없는 필드입니다",
+	"trailing_json":          "요청 본문에는 JSON 값이 하나만 있어야 합니다",
+	"invalid_json_object":    "요청 본문은 JSON 객체여야 합니다",
+	"request_too_large":      "요청 본문이 너무 큽니다",
+	"rate_limited":           "요청이 너무 많습니다. 잠시 후 다시 시도하세요",
+	"bad_request":            "잘못된 요청입니다",
+	"unauthorized":           "인증이 필요합니다",
+	"forbidden":              "권한이 없습니다",
+	"not_found":              "찾을 수 없습니다",
+	"conflict":               "요청이 현재 상태와 충돌합니다",
+	"unsupported_media_type": "지원하지 않는 미디어 형식입니다",
+	"unprocessable_entity":   "요청을 처리할 수 없습니다",
+	"not_implemented":        "지원하지 않는 기능입니다",
+	"unavailable":            "서비스를 사용할 수 없습니다",
+	"internal":               "서버 내부 오류가 발생했습니다",
+}
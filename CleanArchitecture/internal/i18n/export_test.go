@@ -0,0 +1,12 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+// CatalogCodes - 테스트용: 언어 카탈로그에 있는 코드 목록
+func CatalogCodes(tag language.Tag) []string {
+	codes := make([]string, 0, len(catalogs[tag]))
+	for code := range catalogs[tag] {
+		codes = append(codes, code)
+	}
+	return codes
+}
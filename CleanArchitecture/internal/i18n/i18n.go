@@ -0,0 +1,92 @@
+// Package i18n - 에러 메시지 지역화 (Accept-Language 협상 + 코드별 메시지 카탈로그)
+//
+// 에러 코드는 언어와 무관하게 그대로 두고, 사람이 읽는 메시지만 요청 언어로 바꾼다.
+// 영어 메시지는 에러를 정의한 곳(domain.NewError)의 기본값을 쓰고, 다른 언어는 이 패키지의 카탈로그에 둔다.
+package i18n
+
+import (
+	"context"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"golang.org/x/text/language"
+)
+
+// 지원 언어 (첫 번째가 기본값)
+var supported = []language.Tag{language.English, language.Korean}
+
+var matcher = language.NewMatcher(supported)
+
+// Default - Accept-Language가 없거나 지원 언어와 맞지 않을 때 쓰는 언어
+var Default = language.English
+
+// Supported - 지원 언어 목록
+func Supported() []language.Tag {
+	return append([]language.Tag(nil), supported...)
+}
+
+// Negotiate - Accept-Language 헤더 값으로 응답 언어 선택 (q 값 순, "ko-KR"은 한국어)
+func Negotiate(acceptLanguage string) language.Tag {
+	if acceptLanguage == "" {
+		return Default
+	}
+	prefs, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(prefs) == 0 {
+		return Default
+	}
+	_, index, confidence := matcher.Match(prefs...)
+	if confidence == language.No {
+		return Default
+	}
+	return supported[index]
+}
+
+type contextKey struct{}
+
+// WithLanguage - 컨텍스트에 응답 언어 저장
+func WithLanguage(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, tag)
+}
+
+// FromContext - 컨텍스트의 응답 언어 (없으면 Default)
+func FromContext(ctx context.Context) language.Tag {
+	if tag, ok := ctx.Value(contextKey{}).(language.Tag); ok {
+		return tag
+	}
+	return Default
+}
+
+// Message - 코드의 지역화 메시지 (카탈로그에 없으면 에러에 정의된 영어 메시지)
+func Message(tag language.Tag, code string) (string, bool) {
+	if tag != language.English {
+		if msg, ok := catalogs[tag][code]; ok {
+			return msg, true
+		}
+	}
+	if e, ok := domain.LookupError(code); ok {
+		return e.Error(), true
+	}
+	return "", false
+}
+
+// Localized - 지역화한 에러 정보
+type Localized struct {
+	Code    string // 언어와 무관한 코드 (코드가 없는 에러면 빈 문자열)
+	Message string // 요청 언어 메시지
+	Detail  string // 감싼 에러가 덧붙인 설명 (원문 그대로, 없으면 빈 문자열)
+}
+
+// Localize - 에러를 요청 언어로 변환
+// 코드가 없는 에러는 메시지로 err.Error()를 그대로 씀
+func Localize(tag language.Tag, err error) Localized {
+	e, ok := domain.AsError(err)
+	if !ok {
+		return Localized{Message: err.Error()}
+	}
+
+	l := Localized{Code: e.Code()}
+	l.Message, _ = Message(tag, e.Code())
+	if full := err.Error(); full != e.Error() {
+		l.Detail = full
+	}
+	return l
+}
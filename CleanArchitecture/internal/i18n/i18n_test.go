@@ -0,0 +1,80 @@
+package i18n_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+	"golang.org/x/text/language"
+
+	// 에러 코드를 정의하는 패키지를 모두 불러와 레지스트리를 채움
+	_ "github.com/milman2/go-api/clean-architecture/internal/delivery/bulk"
+	_ "github.com/milman2/go-api/clean-architecture/internal/delivery/graphql"
+	_ "github.com/milman2/go-api/clean-architecture/internal/delivery/http"
+	_ "github.com/milman2/go-api/clean-architecture/internal/idempotency"
+	_ "github.com/milman2/go-api/clean-architecture/internal/privacy"
+	_ "github.com/milman2/go-api/clean-architecture/internal/scheduler"
+	_ "github.com/milman2/go-api/clean-architecture/internal/usecase"
+	_ "github.com/milman2/go-api/clean-architecture/internal/webhook"
+)
+
+// 모든 에러 코드에 한국어 메시지가 있어야 함 (영어로 새어 나가지 않도록)
+func TestKoreanCatalogComplete(t *testing.T) {
+	for _, code := range domain.ErrorCodes() {
+		e, _ := domain.LookupError(code)
+		msg, ok := i18n.Message(language.Korean, code)
+		if !ok || msg == e.Error() {
+			t.Errorf("missing Korean message for %q", code)
+		}
+	}
+}
+
+// 카탈로그에 있는 코드는 실제로 정의된 코드여야 함 (삭제된 코드가 남지 않도록)
+func TestKoreanCatalogNoStaleCodes(t *testing.T) {
+	for _, code := range i18n.CatalogCodes(language.Korean) {
+		if _, ok := domain.LookupError(code); !ok {
+			t.Errorf("catalog has unknown code %q", code)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   language.Tag
+	}{
+		{"", language.English},
+		{"ko", language.Korean},
+		{"ko-KR,ko;q=0.9,en;q=0.8", language.Korean},
+		{"en-US", language.English},
+		{"fr", language.English},
+		{"fr;q=1, ko;q=0.5", language.Korean},
+		{"en;q=0.5, ko;q=0.9", language.Korean},
+		{"not a tag;;", language.English},
+	}
+	for _, tt := range tests {
+		if got := i18n.Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	l := i18n.Localize(language.Korean, domain.ErrUserNotFound)
+	if l.Code != "user_not_found" || l.Message != "사용자를 찾을 수 없습니다" || l.Detail != "" {
+		t.Fatalf("unexpected %+v", l)
+	}
+
+	// 감싼 에러는 코드를 유지하고 원문을 Detail로
+	wrapped := fmt.Errorf("%w: hr: department is required", domain.ErrInvalidAttributes)
+	l = i18n.Localize(language.Korean, wrapped)
+	if l.Code != "invalid_attributes" || l.Detail != wrapped.Error() {
+		t.Fatalf("unexpected %+v", l)
+	}
+
+	l = i18n.Localize(language.English, domain.ErrUserNotFound)
+	if l.Message != domain.ErrUserNotFound.Error() {
+		t.Fatalf("English message = %q", l.Message)
+	}
+}
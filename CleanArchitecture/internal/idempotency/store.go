@@ -0,0 +1,138 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+var (
+	// ErrInProgress - 같은 키의 요청이 아직 처리 중
+	ErrInProgress = domain.NewError("idempotency_in_progress", "request with the same idempotency key is in progress")
+	// ErrFingerprintMismatch - 같은 키를 다른 요청 내용으로 재사용
+	ErrFingerprintMismatch = domain.NewError("idempotency_key_reused", "idempotency key reused with a different request")
+)
+
+// Response - 저장된 첫 번째 응답
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Store - 멱등성 키 저장소 (포트)
+type Store interface {
+	// Begin - 키를 처리 중 상태로 예약
+	// 이미 완료된 키면 저장된 응답을 반환하고,
+	// 처리 중이면 ErrInProgress, 요청 지문이 다르면 ErrFingerprintMismatch 반환
+	Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Response, error)
+	// Complete - 응답 저장 (TTL 동안 재생)
+	Complete(ctx context.Context, key string, resp Response) error
+	// Release - 예약 해제 (응답을 저장하지 않고 재시도 허용)
+	Release(ctx context.Context, key string) error
+}
+
+// Purger - 만료된 키를 한꺼번에 지울 수 있는 저장소 (선택, 주기 작업이 사용)
+// TTL 정책 등으로 저장소가 알아서 지우면 구현하지 않아도 됨
+type Purger interface {
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// entry - 메모리 저장 항목
+type entry struct {
+	fingerprint string
+	response    *Response // nil이면 처리 중
+	expiresAt   time.Time
+}
+
+// MemoryStore - 메모리 기반 저장소 (단일 인스턴스용)
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+// sweepInterval - 만료된 키 정리 주기
+const sweepInterval = time.Minute
+
+// NewMemoryStore - MemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:   make(map[string]*entry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Begin - 키 예약 또는 저장된 응답 조회
+func (s *MemoryStore) Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		if e.fingerprint != fingerprint {
+			return nil, ErrFingerprintMismatch
+		}
+		if e.response == nil {
+			return nil, ErrInProgress
+		}
+		resp := *e.response
+		return &resp, nil
+	}
+
+	s.entries[key] = &entry{
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(ttl),
+	}
+	return nil, nil
+}
+
+// Complete - 처리 중인 키에 응답 저장
+func (s *MemoryStore) Complete(ctx context.Context, key string, resp Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		resp.Header = resp.Header.Clone()
+		e.response = &resp
+	}
+	return nil
+}
+
+// Release - 예약 해제
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.response == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// PurgeExpired - 만료된 키를 모두 제거하고 제거한 수 반환 (주기 작업용)
+func (s *MemoryStore) PurgeExpired(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.entries)
+	s.sweep(time.Now())
+	return before - len(s.entries), nil
+}
+
+// sweep - 만료된 키 제거 (호출자가 잠금 보유)
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
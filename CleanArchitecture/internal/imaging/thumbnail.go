@@ -0,0 +1,51 @@
+// Package imaging - 표준 라이브러리만으로 하는 이미지 처리 (썸네일 생성)
+package imaging
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail - 가운데를 정사각형으로 잘라 side×side로 줄인 이미지
+// 줄일 때는 대상 픽셀이 덮는 원본 픽셀을 평균 내고(박스 필터), 키울 때는 가장 가까운 픽셀을 씀
+// 알파는 미리 곱한 값으로 평균 내므로 투명한 가장자리가 어두워지지 않음
+func Thumbnail(src image.Image, side int) *image.RGBA {
+	b := src.Bounds()
+	crop := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-crop)/2
+	y0 := b.Min.Y + (b.Dy()-crop)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	for dy := 0; dy < side; dy++ {
+		sy0, sy1 := span(dy, side, crop)
+		for dx := 0; dx < side; dx++ {
+			sx0, sx1 := span(dx, side, crop)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(x0+sx, y0+sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// span - 대상 좌표 i가 덮는 원본 범위 [lo, hi) (최소 한 픽셀)
+func span(i, dstLen, srcLen int) (lo, hi int) {
+	lo = i * srcLen / dstLen
+	hi = (i + 1) * srcLen / dstLen
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
@@ -0,0 +1,54 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThumbnailCropsCenter(t *testing.T) {
+	// 가로 300: 왼쪽 100 빨강, 가운데 100 초록, 오른쪽 100 파랑 → 가운데 정사각형만 남음
+	src := image.NewRGBA(image.Rect(0, 0, 300, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 300; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			switch {
+			case x >= 200:
+				c = color.RGBA{B: 255, A: 255}
+			case x >= 100:
+				c = color.RGBA{G: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	thumb := Thumbnail(src, 10)
+	if b := thumb.Bounds(); b.Dx() != 10 || b.Dy() != 10 {
+		t.Fatalf("bounds = %v", b)
+	}
+	for _, p := range []image.Point{{0, 0}, {9, 9}, {5, 5}} {
+		if got := thumb.RGBAAt(p.X, p.Y); got != (color.RGBA{G: 255, A: 255}) {
+			t.Fatalf("pixel %v = %v, want green", p, got)
+		}
+	}
+}
+
+func TestThumbnailAveragesAndUpscales(t *testing.T) {
+	// 2x2 체크무늬를 1x1로 줄이면 평균, 4x4로 키우면 가장 가까운 픽셀
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.SetRGBA(0, 0, color.RGBA{R: 200, A: 255})
+	src.SetRGBA(1, 1, color.RGBA{R: 200, A: 255})
+	src.SetRGBA(1, 0, color.RGBA{A: 255})
+	src.SetRGBA(0, 1, color.RGBA{A: 255})
+
+	if got := Thumbnail(src, 1).RGBAAt(0, 0); got != (color.RGBA{R: 100, A: 255}) {
+		t.Fatalf("downscaled = %v", got)
+	}
+	up := Thumbnail(src, 4)
+	if got := up.RGBAAt(1, 1); got != (color.RGBA{R: 200, A: 255}) {
+		t.Fatalf("upscaled (1,1) = %v", got)
+	}
+	if got := up.RGBAAt(2, 1); got != (color.RGBA{A: 255}) {
+		t.Fatalf("upscaled (2,1) = %v", got)
+	}
+}
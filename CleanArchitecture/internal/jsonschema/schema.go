@@ -0,0 +1,528 @@
+// Package jsonschema - 사용자 속성 검증용 JSON Schema (draft 2020-12 부분 집합)
+//
+// 지원 키워드
+//   - 공통: type, enum, const
+//   - 객체: properties, required, additionalProperties, minProperties, maxProperties
+//   - 배열: items, minItems, maxItems, uniqueItems
+//   - 문자열: minLength, maxLength, pattern, format (email, date, date-time, uri, uuid, ipv4, ipv6)
+//   - 숫자: minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//
+// title, description 등 주석 키워드는 무시하고, 그 밖의 키워드($ref, allOf 등)는
+// 검증 없이 통과시키는 대신 Compile에서 거부 (관리자가 기대한 검증이 조용히 빠지지 않도록)
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 무시하는 주석 키워드
+var annotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true,
+	"title": true, "description": true, "default": true, "examples": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Schema - 컴파일된 스키마
+type Schema struct {
+	always *bool // true/false 스키마
+
+	types    []string
+	enum     []any
+	constVal any
+	hasConst bool
+
+	properties    map[string]*Schema
+	required      []string
+	additional    *Schema // nil이면 추가 속성 허용
+	minProperties *int
+	maxProperties *int
+
+	items       *Schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+}
+
+// Compile - JSON Schema 문서 컴파일
+func Compile(raw []byte) (*Schema, error) {
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	return compile(doc, "")
+}
+
+// Types - 최상위 type 키워드 값 (없으면 nil)
+func (s *Schema) Types() []string {
+	return s.types
+}
+
+func compile(node any, path string) (*Schema, error) {
+	if b, ok := node.(bool); ok {
+		return &Schema{always: &b}, nil
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", pointer(path))
+	}
+
+	s := &Schema{}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // 에러 메시지를 결정적으로
+
+	for _, k := range keys {
+		v := m[k]
+		at := path + "/" + k
+		var err error
+		switch k {
+		case "type":
+			s.types, err = compileTypes(v, at)
+		case "enum":
+			arr, ok := v.([]any)
+			if !ok || len(arr) == 0 {
+				err = fmt.Errorf("%s: must be a non-empty array", pointer(at))
+			}
+			s.enum = arr
+		case "const":
+			s.constVal, s.hasConst = v, true
+		case "properties":
+			props, ok := v.(map[string]any)
+			if !ok {
+				err = fmt.Errorf("%s: must be an object", pointer(at))
+				break
+			}
+			s.properties = make(map[string]*Schema, len(props))
+			for name, sub := range props {
+				if s.properties[name], err = compile(sub, at+"/"+name); err != nil {
+					break
+				}
+			}
+		case "required":
+			s.required, err = stringArray(v, at)
+		case "additionalProperties":
+			s.additional, err = compile(v, at)
+		case "items":
+			s.items, err = compile(v, at)
+		case "uniqueItems":
+			b, ok := v.(bool)
+			if !ok {
+				err = fmt.Errorf("%s: must be a boolean", pointer(at))
+			}
+			s.uniqueItems = b
+		case "pattern":
+			str, ok := v.(string)
+			if !ok {
+				err = fmt.Errorf("%s: must be a string", pointer(at))
+				break
+			}
+			if s.pattern, err = regexp.Compile(str); err != nil {
+				err = fmt.Errorf("%s: %w", pointer(at), err)
+			}
+		case "format":
+			str, ok := v.(string)
+			if !ok {
+				err = fmt.Errorf("%s: must be a string", pointer(at))
+			}
+			s.format = str
+		case "minProperties":
+			s.minProperties, err = count(v, at)
+		case "maxProperties":
+			s.maxProperties, err = count(v, at)
+		case "minItems":
+			s.minItems, err = count(v, at)
+		case "maxItems":
+			s.maxItems, err = count(v, at)
+		case "minLength":
+			s.minLength, err = count(v, at)
+		case "maxLength":
+			s.maxLength, err = count(v, at)
+		case "minimum":
+			s.minimum, err = number(v, at)
+		case "maximum":
+			s.maximum, err = number(v, at)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = number(v, at)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = number(v, at)
+		case "multipleOf":
+			s.multipleOf, err = number(v, at)
+			if err == nil && *s.multipleOf <= 0 {
+				err = fmt.Errorf("%s: must be greater than 0", pointer(at))
+			}
+		default:
+			if !annotations[k] {
+				err = fmt.Errorf("%s: unsupported keyword %q", pointer(at), k)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func compileTypes(v any, at string) ([]string, error) {
+	var types []string
+	switch v := v.(type) {
+	case string:
+		types = []string{v}
+	case []any:
+		var err error
+		if types, err = stringArray(v, at); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s: must be a string or array of strings", pointer(at))
+	}
+	for _, t := range types {
+		switch t {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return nil, fmt.Errorf("%s: unknown type %q", pointer(at), t)
+		}
+	}
+	return types, nil
+}
+
+func stringArray(v any, at string) ([]string, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array of strings", pointer(at))
+	}
+	out := make([]string, len(arr))
+	for i, e := range arr {
+		if out[i], ok = e.(string); !ok {
+			return nil, fmt.Errorf("%s: must be an array of strings", pointer(at))
+		}
+	}
+	return out, nil
+}
+
+func count(v any, at string) (*int, error) {
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", pointer(at))
+	}
+	n := int(f)
+	return &n, nil
+}
+
+func number(v any, at string) (*float64, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a number", pointer(at))
+	}
+	return &f, nil
+}
+
+// FieldError - 검증 실패 위치와 이유
+type FieldError struct {
+	Path    string // JSON Pointer (최상위는 "")
+	Message string
+}
+
+// ValidationError - 검증 실패 목록
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = pointer(fe.Path) + ": " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate - 값 검증 (encoding/json이 any로 디코딩한 값 기준)
+// 실패하면 *ValidationError
+func (s *Schema) Validate(v any) error {
+	var errs []FieldError
+	s.validate(normalize(v), "", &errs)
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// AsValidationError - err가 *ValidationError면 반환
+func AsValidationError(err error) (*ValidationError, bool) {
+	var ve *ValidationError
+	ok := errors.As(err, &ve)
+	return ve, ok
+}
+
+func (s *Schema) validate(v any, path string, errs *[]FieldError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("value is not allowed")
+		}
+		return
+	}
+
+	if len(s.types) > 0 && !matchesType(v, s.types) {
+		fail("must be %s", strings.Join(s.types, " or "))
+		return
+	}
+	if s.hasConst && !reflect.DeepEqual(v, normalize(s.constVal)) {
+		fail("must be %s", jsonString(s.constVal))
+	}
+	if s.enum != nil && !containsValue(s.enum, v) {
+		fail("must be one of %s", jsonString(s.enum))
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		s.validateObject(v, path, errs, fail)
+	case []any:
+		s.validateArray(v, path, errs, fail)
+	case string:
+		s.validateString(v, fail)
+	case float64:
+		s.validateNumber(v, fail)
+	}
+}
+
+func (s *Schema) validateObject(obj map[string]any, path string, errs *[]FieldError, fail func(string, ...any)) {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, FieldError{Path: path + "/" + name, Message: "is required"})
+		}
+	}
+	if s.minProperties != nil && len(obj) < *s.minProperties {
+		fail("must have at least %d properties", *s.minProperties)
+	}
+	if s.maxProperties != nil && len(obj) > *s.maxProperties {
+		fail("must have at most %d properties", *s.maxProperties)
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		at := path + "/" + name
+		if sub, ok := s.properties[name]; ok {
+			sub.validate(obj[name], at, errs)
+		} else if s.additional != nil {
+			if s.additional.always != nil && !*s.additional.always {
+				*errs = append(*errs, FieldError{Path: at, Message: "is not allowed"})
+				continue
+			}
+			s.additional.validate(obj[name], at, errs)
+		}
+	}
+}
+
+func (s *Schema) validateArray(arr []any, path string, errs *[]FieldError, fail func(string, ...any)) {
+	if s.minItems != nil && len(arr) < *s.minItems {
+		fail("must have at least %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(arr) > *s.maxItems {
+		fail("must have at most %d items", *s.maxItems)
+	}
+	if s.uniqueItems {
+		for i := range arr {
+			if containsValue(arr[:i], arr[i]) {
+				fail("items must be unique")
+				break
+			}
+		}
+	}
+	if s.items != nil {
+		for i, e := range arr {
+			s.items.validate(e, path+"/"+strconv.Itoa(i), errs)
+		}
+	}
+}
+
+func (s *Schema) validateString(str string, fail func(string, ...any)) {
+	n := utf8.RuneCountInString(str)
+	if s.minLength != nil && n < *s.minLength {
+		fail("must be at least %d characters", *s.minLength)
+	}
+	if s.maxLength != nil && n > *s.maxLength {
+		fail("must be at most %d characters", *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		fail("must match pattern %q", s.pattern.String())
+	}
+	if s.format != "" && !validFormat(s.format, str) {
+		fail("must be a valid %s", s.format)
+	}
+}
+
+func (s *Schema) validateNumber(f float64, fail func(string, ...any)) {
+	if s.minimum != nil && f < *s.minimum {
+		fail("must be >= %v", *s.minimum)
+	}
+	if s.maximum != nil && f > *s.maximum {
+		fail("must be <= %v", *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && f <= *s.exclusiveMinimum {
+		fail("must be > %v", *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && f >= *s.exclusiveMaximum {
+		fail("must be < %v", *s.exclusiveMaximum)
+	}
+	if s.multipleOf != nil {
+		if q := f / *s.multipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("must be a multiple of %v", *s.multipleOf)
+		}
+	}
+}
+
+func matchesType(v any, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "object":
+			if _, ok := v.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := v.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := v.(float64); ok && f == math.Trunc(f) && !math.IsInf(f, 0) {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "null":
+			if v == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validFormat - 알려진 format만 검사 (모르는 format은 주석으로 취급)
+func validFormat(format, s string) bool {
+	switch format {
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	default:
+		return true
+	}
+}
+
+// normalize - 숫자 타입을 float64로 맞춤 (Go 코드에서 만든 값도 같은 규칙으로 비교)
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = normalize(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = normalize(e)
+		}
+		return out
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return f
+	default:
+		return v
+	}
+}
+
+func containsValue(list []any, v any) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(normalize(e), v) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// pointer - 에러 메시지용 경로 표시 (최상위는 "/")
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
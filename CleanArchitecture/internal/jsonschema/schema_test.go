@@ -0,0 +1,103 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const profileSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "profile",
+	"type": "object",
+	"required": ["locale"],
+	"additionalProperties": false,
+	"properties": {
+		"locale":   {"type": "string", "pattern": "^[a-z]{2}-[A-Z]{2}$"},
+		"age":      {"type": "integer", "minimum": 0, "maximum": 150},
+		"email":    {"type": "string", "format": "email"},
+		"tags":     {"type": "array", "items": {"type": "string", "maxLength": 3}, "uniqueItems": true, "maxItems": 3},
+		"plan":     {"enum": ["free", "pro"]},
+		"score":    {"type": "number", "multipleOf": 0.5, "exclusiveMinimum": 0},
+		"nickname": {"type": ["string", "null"], "minLength": 2}
+	}
+}`
+
+func decode(t *testing.T, doc string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatalf("decode %s: %v", doc, err)
+	}
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(profileSchema))
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		want []FieldError
+	}{
+		{"valid", `{"locale":"ko-KR","age":30,"email":"a@example.com","tags":["go"],"plan":"pro","score":1.5,"nickname":null}`, nil},
+		{"integer accepts 30.0", `{"locale":"ko-KR","age":30.0}`, nil},
+		{"missing required", `{}`, []FieldError{{"/locale", "is required"}}},
+		{"wrong type", `{"locale":"ko-KR","age":"30"}`, []FieldError{{"/age", "must be integer"}}},
+		{"not integer", `{"locale":"ko-KR","age":30.5}`, []FieldError{{"/age", "must be integer"}}},
+		{"range", `{"locale":"ko-KR","age":200}`, []FieldError{{"/age", "must be <= 150"}}},
+		{"pattern", `{"locale":"korean"}`, []FieldError{{"/locale", `must match pattern "^[a-z]{2}-[A-Z]{2}$"`}}},
+		{"format", `{"locale":"ko-KR","email":"nope"}`, []FieldError{{"/email", "must be a valid email"}}},
+		{"additional property", `{"locale":"ko-KR","extra":1}`, []FieldError{{"/extra", "is not allowed"}}},
+		{"enum", `{"locale":"ko-KR","plan":"gold"}`, []FieldError{{"/plan", `must be one of ["free","pro"]`}}},
+		{"array items", `{"locale":"ko-KR","tags":["go","rust","go"]}`, []FieldError{
+			{"/tags", "items must be unique"},
+			{"/tags/1", "must be at most 3 characters"},
+		}},
+		{"multipleOf and exclusive", `{"locale":"ko-KR","score":0}`, []FieldError{{"/score", "must be > 0"}}},
+		{"nullable union", `{"locale":"ko-KR","nickname":"a"}`, []FieldError{{"/nickname", "must be at least 2 characters"}}},
+		{"not an object", `[]`, []FieldError{{"", "must be object"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(decode(t, tt.doc))
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			ve, ok := AsValidationError(err)
+			if !ok {
+				t.Fatalf("Validate: got %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(ve.Errors, tt.want) {
+				t.Fatalf("Validate errors\n got: %+v\nwant: %+v", ve.Errors, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"not json", `{`},
+		{"unsupported keyword", `{"type":"object","oneOf":[]}`},
+		{"unknown type", `{"type":"date"}`},
+		{"bad pattern", `{"type":"string","pattern":"("}`},
+		{"negative count", `{"type":"string","minLength":-1}`},
+		{"nested unsupported", `{"type":"object","properties":{"a":{"$ref":"#/x"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile([]byte(tt.doc)); err == nil {
+				t.Fatalf("Compile(%s) succeeded, want error", tt.doc)
+			}
+		})
+	}
+}
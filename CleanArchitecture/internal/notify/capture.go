@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// captureKeep - CaptureNotifier가 메모리에 보관하는 최근 메시지 수
+const captureKeep = 1000
+
+// CaptureNotifier - 메시지를 보내지 않고 보관 (개발, 테스트용 Notifier 구현)
+// dir을 지정하면 메시지마다 .eml 파일도 써서 메일 클라이언트로 열어 볼 수 있음
+type CaptureNotifier struct {
+	from string
+	dir  string
+	now  func() time.Time
+
+	mu       sync.Mutex
+	messages []*Message
+}
+
+// NewCaptureNotifier - CaptureNotifier 생성자 (dir이 비어 있으면 메모리에만 보관)
+func NewCaptureNotifier(from, dir string) (*CaptureNotifier, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("보내는 주소 오류: %w", err)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("메일 보관 디렉토리 생성 실패: %w", err)
+		}
+	}
+	return &CaptureNotifier{
+		from: from,
+		dir:  dir,
+		now:  time.Now,
+	}, nil
+}
+
+// Send - 메시지 보관 (받는 주소가 잘못되면 ErrRejected)
+func (n *CaptureNotifier) Send(ctx context.Context, msg *Message) error {
+	data, err := encodeMessage(n.from, msg, n.now())
+	if err != nil {
+		return err
+	}
+	if n.dir != "" {
+		name := fmt.Sprintf("%d-%s.eml", n.now().UnixNano(), msg.ID)
+		if err := os.WriteFile(filepath.Join(n.dir, name), data, 0o644); err != nil {
+			return fmt.Errorf("메일 파일 저장 실패: %w", err)
+		}
+	}
+
+	c := *msg
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, &c)
+	if len(n.messages) > captureKeep {
+		n.messages = n.messages[len(n.messages)-captureKeep:]
+	}
+	return nil
+}
+
+// Messages - 보관한 메시지 (보낸 순서)
+func (n *CaptureNotifier) Messages() []*Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*Message(nil), n.messages...)
+}
@@ -0,0 +1,45 @@
+// Package notify - 사용자 알림 메일 (가입 환영, 계정 변경 안내)
+//
+// 사용자 이벤트를 받아 언어별 템플릿으로 메시지를 만들고, 대기열에 넣어 Notifier로 보낸다.
+// 전송은 Service.Run 워커가 하므로 사용자 요청을 지연시키지 않으며, 실패하면 백오프로 재시도한다.
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/text/language"
+)
+
+// ErrRejected - 수신 서버가 메시지를 영구적으로 거부함 (재시도하지 않음)
+var ErrRejected = errors.New("message rejected")
+
+// Kind - 알림 종류 (템플릿 이름)
+type Kind string
+
+const (
+	KindWelcome        Kind = "welcome"
+	KindAccountUpdated Kind = "account_updated"
+	KindAccountDeleted Kind = "account_deleted"
+)
+
+// Kinds - 지원하는 모든 알림 종류
+var Kinds = []Kind{KindWelcome, KindAccountUpdated, KindAccountDeleted}
+
+// Message - 보낼 메일 한 통 (본문은 텍스트와 HTML 두 가지)
+type Message struct {
+	ID       string // 재시도해도 바뀌지 않는 식별자 (Message-ID 헤더에 사용)
+	Kind     Kind
+	TenantID string
+	To       string
+	Language language.Tag
+	Subject  string
+	Text     string
+	HTML     string
+}
+
+// Notifier - 메시지 전송 포트 (SMTP, 캡처 등)
+// 다시 보내도 소용없는 실패는 ErrRejected로 감싸서 반환
+type Notifier interface {
+	Send(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,240 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestRenderLocalized(t *testing.T) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := Data{Product: "Acme", Name: "<b>홍길동</b>", Email: "hong@example.com"}
+
+	tests := []struct {
+		tag      language.Tag
+		wantLang language.Tag
+		subject  string
+	}{
+		{language.Korean, language.Korean, "Acme에 오신 것을 환영합니다"},
+		{language.English, language.English, "Welcome to Acme"},
+		{language.Japanese, i18n.Default, "Welcome to Acme"},
+	}
+	for _, tt := range tests {
+		msg, err := templates.Render(KindWelcome, tt.tag, data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tag, err)
+		}
+		if msg.Subject != tt.subject || msg.Language != tt.wantLang {
+			t.Errorf("%s: got %q (%s)", tt.tag, msg.Subject, msg.Language)
+		}
+		// 텍스트 본문은 그대로, HTML 본문은 이스케이프
+		if !strings.Contains(msg.Text, "<b>홍길동</b>") {
+			t.Errorf("%s: text body %q", tt.tag, msg.Text)
+		}
+		if strings.Contains(msg.HTML, "<b>홍길동") || !strings.Contains(msg.HTML, "&lt;b&gt;홍길동") {
+			t.Errorf("%s: html body not escaped: %q", tt.tag, msg.HTML)
+		}
+		if !strings.Contains(msg.HTML, `lang="`+tt.wantLang.String()+`"`) {
+			t.Errorf("%s: html lang missing: %q", tt.tag, msg.HTML)
+		}
+	}
+
+	for _, kind := range Kinds {
+		if _, err := templates.Render(kind, language.Korean, data); err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+	}
+}
+
+// flakyNotifier - errs 순서대로 실패한 뒤 성공
+type flakyNotifier struct {
+	mu    sync.Mutex
+	errs  []error
+	calls int
+	sent  []*Message
+}
+
+func (n *flakyNotifier) Send(ctx context.Context, msg *Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls++
+	if len(n.errs) > 0 {
+		err := n.errs[0]
+		n.errs = n.errs[1:]
+		return err
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func newTestService(t *testing.T, notifier Notifier, cfg Config) (*Service, *time.Time) {
+	t.Helper()
+	svc, err := NewService(notifier, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	svc.jitter = func(d time.Duration) time.Duration { return d }
+	return svc, &now
+}
+
+func userEvent(eventType domain.UserEventType, attrs domain.Attributes) domain.UserEvent {
+	return domain.UserEvent{
+		ID:       "evt-1",
+		Type:     eventType,
+		TenantID: "acme",
+		User:     domain.User{ID: "u1", TenantID: "acme", Email: "hong@example.com", Name: "홍길동", Attributes: attrs},
+	}
+}
+
+func TestPublishChoosesLanguage(t *testing.T) {
+	n := &flakyNotifier{}
+	svc, _ := newTestService(t, n, Config{Product: "Acme"})
+
+	// 요청 언어
+	ctx := i18n.WithLanguage(context.Background(), language.Korean)
+	svc.Publish(ctx, userEvent(domain.UserCreated, nil))
+	// 사용자 속성의 언어가 요청 언어보다 우선
+	svc.Publish(ctx, userEvent(domain.UserUpdated, domain.Attributes{"i18n": {"locale": "en-US"}}))
+	svc.Publish(ctx, userEvent(domain.UserDeleted, nil))
+
+	if got := svc.ProcessDue(context.Background()); got != 3 {
+		t.Fatalf("processed %d, want 3", got)
+	}
+	want := []struct {
+		kind    Kind
+		subject string
+	}{
+		{KindWelcome, "Acme에 오신 것을 환영합니다"},
+		{KindAccountUpdated, "Your Acme account was updated"},
+		{KindAccountDeleted, "Acme 계정이 삭제되었습니다"},
+	}
+	if len(n.sent) != len(want) {
+		t.Fatalf("sent %d messages", len(n.sent))
+	}
+	for i, w := range want {
+		m := n.sent[i]
+		if m.Kind != w.kind || m.Subject != w.subject || m.To != "hong@example.com" || m.TenantID != "acme" || m.ID == "" {
+			t.Errorf("message %d: got %+v", i, m)
+		}
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	n := &flakyNotifier{errs: []error{errors.New("connection refused"), errors.New("timeout")}}
+	svc, now := newTestService(t, n, Config{BaseBackoff: time.Minute})
+	svc.Publish(context.Background(), userEvent(domain.UserCreated, nil))
+
+	svc.ProcessDue(context.Background()) // 1차 실패 → 1분 뒤
+	if svc.Pending() != 1 {
+		t.Fatalf("pending %d, want 1", svc.Pending())
+	}
+	*now = now.Add(59 * time.Second)
+	if got := svc.ProcessDue(context.Background()); got != 0 {
+		t.Fatalf("retried before backoff: %d", got)
+	}
+	*now = now.Add(time.Second)
+	svc.ProcessDue(context.Background()) // 2차 실패 → 2분 뒤
+	*now = now.Add(2 * time.Minute)
+	svc.ProcessDue(context.Background())
+
+	if n.calls != 3 || len(n.sent) != 1 || svc.Pending() != 0 {
+		t.Fatalf("calls %d, sent %d, pending %d", n.calls, len(n.sent), svc.Pending())
+	}
+}
+
+func TestGiveUp(t *testing.T) {
+	tests := []struct {
+		name  string
+		errs  []error
+		calls int
+	}{
+		{"rejected", []error{ErrRejected}, 1},
+		{"max attempts", []error{errors.New("a"), errors.New("b"), errors.New("c")}, 2},
+	}
+	for _, tt := range tests {
+		n := &flakyNotifier{errs: tt.errs}
+		svc, now := newTestService(t, n, Config{MaxAttempts: 2})
+		svc.Publish(context.Background(), userEvent(domain.UserCreated, nil))
+		for i := 0; i < 5; i++ {
+			svc.ProcessDue(context.Background())
+			*now = now.Add(time.Hour)
+		}
+		if n.calls != tt.calls || svc.Pending() != 0 {
+			t.Errorf("%s: calls %d, pending %d", tt.name, n.calls, svc.Pending())
+		}
+	}
+}
+
+func TestCaptureWritesEML(t *testing.T) {
+	dir := t.TempDir()
+	capture, err := NewCaptureNotifier("Acme <no-reply@acme.example>", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, _ := newTestService(t, capture, Config{Product: "Acme"})
+	ctx := i18n.WithLanguage(context.Background(), language.Korean)
+	svc.Publish(ctx, userEvent(domain.UserCreated, nil))
+	svc.ProcessDue(ctx)
+
+	if got := capture.Messages(); len(got) != 1 || got[0].Kind != KindWelcome {
+		t.Fatalf("captured %+v", got)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("got %d .eml files", len(files))
+	}
+	f, _ := os.Open(files[0])
+	defer f.Close()
+	m, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if subject != "Acme에 오신 것을 환영합니다" || m.Header.Get("To") != "<hong@example.com>" || m.Header.Get("Content-Language") != "ko" {
+		t.Fatalf("headers %v (subject %q)", m.Header, subject)
+	}
+
+	_, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	mr := multipart.NewReader(m.Body, params["boundary"])
+	var types []string
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(p) // quoted-printable는 multipart.Reader가 풀어 줌
+		if !strings.Contains(string(body), "hong@example.com") {
+			t.Errorf("%s part: %q", p.Header.Get("Content-Type"), body)
+		}
+		types = append(types, p.Header.Get("Content-Type"))
+	}
+	if strings.Join(types, ",") != "text/plain; charset=utf-8,text/html; charset=utf-8" {
+		t.Fatalf("parts %v", types)
+	}
+
+	if err := capture.Send(ctx, &Message{ID: "x", To: "not an address"}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("bad address: got %v", err)
+	}
+}
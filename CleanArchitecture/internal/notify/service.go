@@ -0,0 +1,250 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log"
+	mathrand "math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+	"golang.org/x/text/language"
+)
+
+// Config - 알림 설정 (0 값은 기본값 사용)
+type Config struct {
+	Product      string        // 템플릿에 쓰는 서비스 이름 (기본 "Clean Architecture")
+	Timeout      time.Duration // 전송당 제한 시간 (기본 30초)
+	MaxAttempts  int           // 메시지당 최대 시도 수 (기본 6)
+	BaseBackoff  time.Duration // 첫 재시도 대기 (기본 30초, 시도마다 2배)
+	MaxBackoff   time.Duration // 재시도 대기 상한 (기본 30분)
+	QueueSize    int           // 대기열 최대 길이 (기본 1000, 가득 차면 새 메시지를 버림)
+	PollInterval time.Duration // 재시도 시각 확인 주기 (기본 1초)
+}
+
+// pending - 대기열의 메시지
+type pending struct {
+	msg      *Message
+	attempts int
+	next     time.Time
+}
+
+// Service - 사용자 이벤트를 알림 메일로 보냄 (usecase.EventPublisher 구현)
+// 대기열은 프로세스 메모리에 있으므로 재시작하면 보내지 못한 메시지는 사라짐
+type Service struct {
+	notifier  Notifier
+	templates *Templates
+	cfg       Config
+
+	now    func() time.Time
+	jitter func(d time.Duration) time.Duration
+
+	mu         sync.Mutex
+	queue      []*pending
+	wake       chan struct{}
+	processing sync.Mutex
+}
+
+// NewService - Service 생성자
+func NewService(notifier Notifier, cfg Config) (*Service, error) {
+	if cfg.Product == "" {
+		cfg.Product = "Clean Architecture"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 6
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Minute
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+
+	templates, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		notifier:  notifier,
+		templates: templates,
+		cfg:       cfg,
+		now:       time.Now,
+		jitter:    equalJitter,
+		wake:      make(chan struct{}, 1),
+	}, nil
+}
+
+// eventKinds - 이벤트별 알림 종류
+var eventKinds = map[domain.UserEventType]Kind{
+	domain.UserCreated: KindWelcome,
+	domain.UserUpdated: KindAccountUpdated,
+	domain.UserDeleted: KindAccountDeleted,
+}
+
+// Publish - 이벤트에 맞는 메시지를 만들어 대기열에 추가 (usecase.EventPublisher 구현)
+// 실제 전송은 Run 워커가 수행하므로 사용자 요청을 지연시키지 않음
+func (s *Service) Publish(ctx context.Context, event domain.UserEvent) {
+	kind, ok := eventKinds[event.Type]
+	if !ok || event.User.Email == "" {
+		return
+	}
+
+	msg, err := s.templates.Render(kind, languageFor(ctx, &event.User), Data{
+		Product: s.cfg.Product,
+		Name:    event.User.Name,
+		Email:   event.User.Email,
+	})
+	if err != nil {
+		log.Printf("알림 메시지 생성 실패 (이벤트 %s): %v", event.ID, err)
+		return
+	}
+	msg.ID = uuid.New().String()
+	msg.TenantID = event.TenantID
+	msg.To = event.User.Email
+
+	s.mu.Lock()
+	if len(s.queue) >= s.cfg.QueueSize {
+		s.mu.Unlock()
+		log.Printf("알림 대기열이 가득 차 메시지를 버림 (이벤트 %s, %s)", event.ID, kind)
+		return
+	}
+	s.queue = append(s.queue, &pending{msg: msg, next: s.now()})
+	s.mu.Unlock()
+	s.notify()
+}
+
+// languageFor - 사용자 속성 i18n.locale이 있으면 그 언어, 없으면 요청 언어
+func languageFor(ctx context.Context, user *domain.User) language.Tag {
+	if locale, ok := user.Attributes["i18n"]["locale"].(string); ok && locale != "" {
+		return i18n.Negotiate(locale)
+	}
+	return i18n.FromContext(ctx)
+}
+
+// Pending - 아직 보내지 못한 메시지 수 (재시도 대기 포함)
+func (s *Service) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Run - 대기열을 처리하는 워커 (ctx가 취소될 때까지 실행)
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.ProcessDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessDue - 보낼 시각이 된 메시지를 한 번씩 보내고 시도 수 반환
+// 동시에 여러 번 호출되면 순서대로 실행 (같은 메시지를 두 번 보내지 않음)
+func (s *Service) ProcessDue(ctx context.Context) int {
+	s.processing.Lock()
+	defer s.processing.Unlock()
+
+	now := s.now()
+	s.mu.Lock()
+	var due []*pending
+	rest := s.queue[:0]
+	for _, p := range s.queue {
+		if p.next.After(now) {
+			rest = append(rest, p)
+		} else {
+			due = append(due, p)
+		}
+	}
+	s.queue = rest
+	s.mu.Unlock()
+
+	var retry []*pending
+	for _, p := range due {
+		if ctx.Err() != nil {
+			retry = append(retry, p)
+			continue
+		}
+		if s.deliver(ctx, p) {
+			retry = append(retry, p)
+		}
+	}
+
+	if len(retry) > 0 {
+		s.mu.Lock()
+		s.queue = append(s.queue, retry...)
+		s.mu.Unlock()
+	}
+	return len(due)
+}
+
+// deliver - 한 번 보내고, 다시 시도해야 하면 다음 시각을 정하고 true 반환
+func (s *Service) deliver(ctx context.Context, p *pending) bool {
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	err := s.notifier.Send(sendCtx, p.msg)
+	cancel()
+	if err == nil {
+		return false
+	}
+
+	p.attempts++
+	switch {
+	case errors.Is(err, ErrRejected):
+		log.Printf("알림 메일 거부됨 (%s, 메시지 %s): %v", p.msg.Kind, p.msg.ID, err)
+		return false
+	case p.attempts >= s.cfg.MaxAttempts:
+		log.Printf("알림 메일 전송 포기 (%s, 메시지 %s, %d회 시도): %v", p.msg.Kind, p.msg.ID, p.attempts, err)
+		return false
+	default:
+		p.next = s.now().Add(s.backoff(p.attempts))
+		return true
+	}
+}
+
+// backoff - n번째 실패 후 대기 시간 (지수 증가 + 상한 + 지터)
+func (s *Service) backoff(n int) time.Duration {
+	d := s.cfg.BaseBackoff
+	for i := 1; i < n && d < s.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	return s.jitter(d)
+}
+
+// notify - 워커 깨우기 (이미 신호가 있으면 생략)
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// equalJitter - [d/2, d) 구간 난수 (동시 재시도가 한꺼번에 몰리지 않도록)
+func equalJitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + mathrand.N(half)
+}
@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// SMTPConfig - SMTP 서버 설정
+type SMTPConfig struct {
+	Addr        string // host:port
+	Username    string // 비어 있으면 인증 안 함
+	Password    string
+	From        string      // 보내는 주소 (예: "Clean Architecture <no-reply@example.com>")
+	ImplicitTLS bool        // 465 포트처럼 연결부터 TLS (기본은 서버가 지원하면 STARTTLS)
+	TLSConfig   *tls.Config // nil이면 Addr의 호스트 이름으로 인증서 검증
+}
+
+// SMTPNotifier - SMTP로 메시지 전송 (Notifier 구현)
+// 메시지마다 연결을 새로 맺음 (전송량이 많지 않은 알림 메일용)
+type SMTPNotifier struct {
+	cfg  SMTPConfig
+	host string
+	from string // 봉투 발신 주소
+	now  func() time.Time
+}
+
+// NewSMTPNotifier - SMTPNotifier 생성자
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("SMTP 주소 오류: %w", err)
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("보내는 주소 오류: %w", err)
+	}
+	if cfg.TLSConfig == nil {
+		cfg.TLSConfig = &tls.Config{ServerName: host}
+	}
+	return &SMTPNotifier{
+		cfg:  cfg,
+		host: host,
+		from: from.Address,
+		now:  time.Now,
+	}, nil
+}
+
+// Send - 메시지 한 통 전송 (ctx가 끝나면 연결을 끊음)
+// 5xx 응답은 ErrRejected로 감쌈
+func (n *SMTPNotifier) Send(ctx context.Context, msg *Message) error {
+	data, err := encodeMessage(n.cfg.From, msg, n.now())
+	if err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(msg.To) // encodeMessage에서 검증함
+
+	if err := n.send(ctx, to.Address, data); err != nil {
+		var tpErr *textproto.Error
+		if errors.As(err, &tpErr) && tpErr.Code >= 500 {
+			return fmt.Errorf("%w: %v", ErrRejected, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, to string, data []byte) error {
+	conn, err := n.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// net/smtp는 컨텍스트를 받지 않으므로 연결 기한으로 대신함
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if !n.cfg.ImplicitTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(n.cfg.TLSConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(n.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (n *SMTPNotifier) dial(ctx context.Context) (net.Conn, error) {
+	if n.cfg.ImplicitTLS {
+		d := &tls.Dialer{Config: n.cfg.TLSConfig}
+		return d.DialContext(ctx, "tcp", n.cfg.Addr)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", n.cfg.Addr)
+}
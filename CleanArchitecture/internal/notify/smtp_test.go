@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// fakeSMTP - 명령 하나씩 응답하는 최소 SMTP 서버 (STARTTLS/AUTH 없음)
+// rcptReply가 있으면 RCPT에 그 응답을 보냄
+type fakeSMTP struct {
+	addr      string
+	rcptReply string
+	received  chan string
+}
+
+func newFakeSMTP(t *testing.T, rcptReply string) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTP{addr: ln.Addr().String(), rcptReply: rcptReply, received: make(chan string, 1)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.Fields(line)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 fake")
+		case "RCPT":
+			if s.rcptReply != "" {
+				tp.PrintfLine("%s", s.rcptReply)
+				continue
+			}
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			body, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.received <- string(body)
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifierSend(t *testing.T) {
+	srv := newFakeSMTP(t, "")
+	n, err := NewSMTPNotifier(SMTPConfig{Addr: srv.addr, From: "Acme <no-reply@acme.example>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = n.Send(context.Background(), &Message{
+		ID: "m1", To: "hong@example.com", Language: language.Korean,
+		Subject: "환영합니다", Text: "안녕하세요\n", HTML: "<p>안녕하세요</p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := <-srv.received
+	for _, want := range []string{"Message-ID: <m1@acme.example>", "To: <hong@example.com>", "Subject: =?utf-8?q?", "multipart/alternative"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("message missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestSMTPNotifierErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		rejected bool
+	}{
+		{"permanent", "550 no such user", true},
+		{"temporary", "451 try again later", false},
+	}
+	for _, tt := range tests {
+		srv := newFakeSMTP(t, tt.reply)
+		n, _ := NewSMTPNotifier(SMTPConfig{Addr: srv.addr, From: "no-reply@acme.example"})
+		err := n.Send(context.Background(), &Message{ID: "m1", To: "nobody@example.com"})
+		if err == nil || errors.Is(err, ErrRejected) != tt.rejected {
+			t.Errorf("%s: got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/milman2/go-api/clean-architecture/internal/i18n"
+	"golang.org/x/text/language"
+)
+
+// 템플릿 파일: templates/<종류>.<언어>.tmpl
+// 파일마다 "subject", "text", "content"(HTML 본문) 세 템플릿을 정의하고,
+// HTML은 layout.html.tmpl이 "content"를 감싼다.
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Data - 템플릿에 넘기는 값
+type Data struct {
+	Product  string // 서비스 이름
+	Name     string
+	Email    string
+	Language string // BCP 47 태그 (HTML lang 속성)
+}
+
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Templates - 알림 종류 × 지원 언어별 템플릿
+type Templates struct {
+	sets map[Kind]map[language.Tag]*templateSet
+}
+
+// LoadTemplates - 내장 템플릿 파싱 (종류마다 모든 지원 언어의 템플릿이 있어야 함)
+func LoadTemplates() (*Templates, error) {
+	t := &Templates{sets: make(map[Kind]map[language.Tag]*templateSet)}
+	for _, kind := range Kinds {
+		t.sets[kind] = make(map[language.Tag]*templateSet)
+		for _, tag := range i18n.Supported() {
+			file := fmt.Sprintf("templates/%s.%s.tmpl", kind, tag)
+			text, err := texttemplate.New(string(kind)).ParseFS(templateFS, file)
+			if err != nil {
+				return nil, fmt.Errorf("%s 템플릿 파싱 실패: %w", file, err)
+			}
+			html, err := htmltemplate.New(string(kind)).ParseFS(templateFS, "templates/layout.html.tmpl", file)
+			if err != nil {
+				return nil, fmt.Errorf("%s 템플릿 파싱 실패: %w", file, err)
+			}
+			t.sets[kind][tag] = &templateSet{text: text, html: html}
+		}
+	}
+	return t, nil
+}
+
+// Render - 메시지 제목과 본문 생성 (지원하지 않는 언어는 i18n.Default)
+func (t *Templates) Render(kind Kind, tag language.Tag, data Data) (*Message, error) {
+	sets, ok := t.sets[kind]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 알림 종류: %s", kind)
+	}
+	set, ok := sets[tag]
+	if !ok {
+		tag = i18n.Default
+		set = sets[tag]
+	}
+	data.Language = tag.String()
+
+	var subject, text, html bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := set.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, err
+	}
+	if err := set.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Kind:     kind,
+		Language: tag,
+		// 제목은 헤더 한 줄이어야 함
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
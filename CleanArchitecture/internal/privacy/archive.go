@@ -0,0 +1,195 @@
+package privacy
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// manifestName - 내보내기 파일 목록 항목 이름
+const manifestName = "manifest.json"
+
+// Archive - 소스가 내보낼 데이터를 쓰는 곳
+// 이름은 '/'로 구분한 상대 경로 (예: profile.json, avatar/original.png)이며 한 파일 안에서 고유해야 함
+type Archive interface {
+	// AddJSON - 값을 JSON 항목으로 추가
+	AddJSON(name string, v any) error
+	// AddFile - 바이너리 항목 추가 (이미지 등)
+	AddFile(name, contentType string, r io.Reader) error
+}
+
+// Manifest - 내보내기 파일 목록 (ZIP은 manifest.json, JSON은 "manifest" 필드)
+type Manifest struct {
+	RequestID   string          `json:"request_id"`
+	TenantID    string          `json:"tenant_id"`
+	UserID      string          `json:"user_id"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Format      Format          `json:"format"`
+	Files       []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry - 항목 정보 (받은 사람이 내용을 검증할 수 있도록 SHA-256 포함)
+type ManifestEntry struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+// archiveWriter - 형식별 Archive 구현 (Close가 목록을 쓰고 마무리)
+type archiveWriter interface {
+	Archive
+	Close() error
+	Entries() int
+}
+
+func newArchiveWriter(format Format, w io.Writer, manifest Manifest) archiveWriter {
+	manifest.Format = format
+	if format == FormatJSON {
+		return &jsonArchive{w: w, manifest: manifest, files: make(map[string]any)}
+	}
+	return &zipArchive{zw: zip.NewWriter(w), manifest: manifest}
+}
+
+// add - 크기와 해시를 구해 목록에 항목 추가 (이름 검증 포함)
+func (m *Manifest) add(name, contentType string, data []byte) error {
+	if name == manifestName || !validEntryName(name) {
+		return fmt.Errorf("잘못된 항목 이름: %q", name)
+	}
+	if slices.ContainsFunc(m.Files, func(e ManifestEntry) bool { return e.Name == name }) {
+		return fmt.Errorf("항목 이름 중복: %q", name)
+	}
+	sum := sha256.Sum256(data)
+	m.Files = append(m.Files, ManifestEntry{
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+	})
+	return nil
+}
+
+// validEntryName - 상위 경로나 절대 경로가 아닌 정리된 상대 경로인지 (압축을 풀 때 밖으로 나가지 않도록)
+func validEntryName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, "/") && path.Clean(name) == name &&
+		name != ".." && !strings.HasPrefix(name, "../")
+}
+
+// zipArchive - 항목마다 ZIP 파일 하나
+type zipArchive struct {
+	zw       *zip.Writer
+	manifest Manifest
+}
+
+func (a *zipArchive) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return a.add(name, "application/json", data)
+}
+
+func (a *zipArchive) AddFile(name, contentType string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return a.add(name, contentType, data)
+}
+
+func (a *zipArchive) add(name, contentType string, data []byte) error {
+	if err := a.manifest.add(name, contentType, data); err != nil {
+		return err
+	}
+	w, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: a.manifest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (a *zipArchive) Entries() int {
+	return len(a.manifest.Files)
+}
+
+func (a *zipArchive) Close() error {
+	data, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     manifestName,
+		Method:   zip.Deflate,
+		Modified: a.manifest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
+
+// jsonArchive - {"manifest": ..., "files": {이름: 내용}} 문서 하나
+// JSON 항목은 그대로, 바이너리 항목은 {"content_type", "base64"} 객체로 담음
+type jsonArchive struct {
+	w        io.Writer
+	manifest Manifest
+	files    map[string]any
+}
+
+// jsonBinary - JSON 형식의 바이너리 항목
+type jsonBinary struct {
+	ContentType string `json:"content_type"`
+	Base64      []byte `json:"base64"`
+}
+
+func (a *jsonArchive) AddJSON(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := a.manifest.add(name, "application/json", data); err != nil {
+		return err
+	}
+	a.files[name] = json.RawMessage(data)
+	return nil
+}
+
+func (a *jsonArchive) AddFile(name, contentType string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := a.manifest.add(name, contentType, data); err != nil {
+		return err
+	}
+	a.files[name] = jsonBinary{ContentType: contentType, Base64: data}
+	return nil
+}
+
+func (a *jsonArchive) Entries() int {
+	return len(a.manifest.Files)
+}
+
+func (a *jsonArchive) Close() error {
+	enc := json.NewEncoder(a.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Manifest Manifest       `json:"manifest"`
+		Files    map[string]any `json:"files"`
+	}{a.manifest, a.files})
+}
@@ -0,0 +1,124 @@
+// Package privacy - 개인정보 내보내기(열람권)와 삭제(잊힐 권리) 요청 처리
+//
+// 요청은 Store에 기록되고 Run 워커가 비동기로 처리한다.
+// 내보내기는 사용자에게 딸린 데이터를 소스별로 모아 ZIP 또는 JSON 파일로 블롭 저장소에 쓰고,
+// 삭제는 보존 규칙에 따라 행을 지우거나 제자리에서 익명화한 뒤 서명된 완료 기록(Receipt)을 남긴다.
+package privacy
+
+import (
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+var (
+	ErrRequestNotFound      = domain.NewError("privacy_request_not_found", "privacy request not found")
+	ErrRequestInProgress    = domain.NewError("privacy_request_in_progress", "a privacy request of this kind is already in progress for the user")
+	ErrInvalidArchiveFormat = domain.NewError("invalid_archive_format", "invalid archive format")
+	ErrArchiveNotReady      = domain.NewError("archive_not_ready", "export archive is not ready")
+	ErrArchiveExpired       = domain.NewError("archive_expired", "export archive has expired")
+	ErrExportUnavailable    = domain.NewError("export_unavailable", "export storage is not configured")
+)
+
+// Kind - 요청 종류
+type Kind string
+
+const (
+	KindExport  Kind = "export"  // 데이터 내보내기
+	KindErasure Kind = "erasure" // 개인정보 삭제
+)
+
+// Status - 요청 처리 상태
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 처리 대기
+	StatusRunning   Status = "running"   // 처리 중
+	StatusCompleted Status = "completed" // 완료 (내보내기는 파일을 받을 수 있음)
+	StatusFailed    Status = "failed"    // 실패 (Error에 이유, 같은 요청을 다시 만들 수 있음)
+	StatusExpired   Status = "expired"   // 내보내기 파일 보관 기간이 지나 삭제됨
+)
+
+// Done - 더 이상 처리하지 않는 상태인지
+func (s Status) Done() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusExpired
+}
+
+// Format - 내보내기 파일 형식
+type Format string
+
+const (
+	FormatZIP  Format = "zip"  // 소스별 파일 + manifest.json (이미지는 원본 그대로)
+	FormatJSON Format = "json" // JSON 문서 하나 (이미지는 base64)
+)
+
+// Valid - 알려진 형식인지
+func (f Format) Valid() bool {
+	return f == FormatZIP || f == FormatJSON
+}
+
+// ContentType - 파일 MIME 타입
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json"
+	}
+	return "application/zip"
+}
+
+// Request - 내보내기/삭제 요청 (처리가 끝난 뒤에도 기록으로 보관)
+type Request struct {
+	ID       string
+	TenantID string
+	UserID   string
+	Kind     Kind
+	Status   Status
+	Actor    string // 요청한 주체 (관리자 ID 등)
+	Error    string // 실패 이유
+
+	Format  Format             // 내보내기 형식
+	Mode    domain.ErasureMode // 요청한 삭제 방식 (비어 있으면 보존 규칙의 기본값)
+	Archive *ArchiveInfo       // 완료된 내보내기 파일
+	Receipt *Receipt           // 완료된 삭제의 완료 기록
+
+	RequestedAt time.Time
+	StartedAt   time.Time // 처리를 시작하지 않았으면 0
+	CompletedAt time.Time // 끝나지 않았으면 0
+}
+
+// ArchiveInfo - 내보내기 파일 정보
+type ArchiveInfo struct {
+	Key       string // 블롭 저장소 키
+	Size      int64
+	SHA256    string // 파일 전체의 SHA-256 (16진수)
+	Files     int    // 담긴 항목 수 (manifest 제외)
+	ExpiresAt time.Time
+}
+
+// Step - 삭제 단계별 결과 (완료 기록에 남음)
+type Step struct {
+	Source string // 데이터 소스 이름 (profile, avatar 등)
+	Action string // deleted | anonymized | redacted | skipped
+	Items  int    // 처리한 항목 수
+}
+
+// 삭제 단계 동작
+const (
+	ActionDeleted    = "deleted"
+	ActionAnonymized = "anonymized"
+	ActionRedacted   = "redacted"
+	ActionSkipped    = "skipped" // 해당 데이터가 없거나 기능이 꺼져 있음
+)
+
+func copyRequest(req *Request) *Request {
+	c := *req
+	if req.Archive != nil {
+		a := *req.Archive
+		c.Archive = &a
+	}
+	if req.Receipt != nil {
+		r := *req.Receipt
+		r.Steps = append([]Step(nil), req.Receipt.Steps...)
+		c.Receipt = &r
+	}
+	return &c
+}
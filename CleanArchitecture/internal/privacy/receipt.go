@@ -0,0 +1,93 @@
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// Receipt - 삭제 완료 기록 (개인정보 없이 무엇을 어떻게 지웠는지만 남김)
+// Digest는 서명 필드를 뺀 나머지의 정규 JSON에 대한 SHA-256이고,
+// Signature는 서버 키로 Digest에 대해 계산한 HMAC-SHA256이라 기록이 바뀌지 않았음을 확인할 수 있음
+type Receipt struct {
+	RequestID     string
+	TenantID      string
+	UserID        string
+	RequestedMode domain.ErasureMode // 요청한 방식 (비어 있으면 기본값을 씀)
+	Mode          domain.ErasureMode // 실제 적용한 방식
+	RetentionRule string             // 방식을 정한 보존 규칙 (요청대로면 빈 문자열)
+	Steps         []Step
+	CompletedAt   time.Time
+
+	Digest    string // SHA-256 (16진수)
+	Signature string // HMAC-SHA256 (16진수, 서명 키가 없으면 빈 문자열)
+}
+
+// receiptBody - 다이제스트 계산용 정규 형식 (필드 순서와 이름을 바꾸면 기존 기록을 검증할 수 없음)
+type receiptBody struct {
+	RequestID     string        `json:"request_id"`
+	TenantID      string        `json:"tenant_id"`
+	UserID        string        `json:"user_id"`
+	RequestedMode string        `json:"requested_mode"`
+	Mode          string        `json:"mode"`
+	RetentionRule string        `json:"retention_rule"`
+	Steps         []receiptStep `json:"steps"`
+	CompletedAt   string        `json:"completed_at"` // RFC 3339 (UTC, 나노초)
+}
+
+type receiptStep struct {
+	Source string `json:"source"`
+	Action string `json:"action"`
+	Items  int    `json:"items"`
+}
+
+// digest - 서명 필드를 뺀 정규 JSON의 SHA-256
+func (r *Receipt) digest() string {
+	body := receiptBody{
+		RequestID:     r.RequestID,
+		TenantID:      r.TenantID,
+		UserID:        r.UserID,
+		RequestedMode: string(r.RequestedMode),
+		Mode:          string(r.Mode),
+		RetentionRule: r.RetentionRule,
+		CompletedAt:   r.CompletedAt.UTC().Format(time.RFC3339Nano),
+	}
+	body.Steps = make([]receiptStep, len(r.Steps))
+	for i, s := range r.Steps {
+		body.Steps[i] = receiptStep(s)
+	}
+
+	data, _ := json.Marshal(body) // 문자열과 정수뿐이라 실패하지 않음
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// seal - 다이제스트와 서명 채우기
+func (r *Receipt) seal(key []byte) {
+	r.Digest = r.digest()
+	r.Signature = ""
+	if len(key) > 0 {
+		r.Signature = sign(key, r.Digest)
+	}
+}
+
+// verify - 다이제스트가 내용과 맞고, 키가 있으면 서명도 맞는지
+func (r *Receipt) verify(key []byte) bool {
+	if r.Digest != r.digest() {
+		return false
+	}
+	if len(key) == 0 {
+		return r.Signature == ""
+	}
+	return hmac.Equal([]byte(r.Signature), []byte(sign(key, r.Digest)))
+}
+
+func sign(key []byte, digest string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(digest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
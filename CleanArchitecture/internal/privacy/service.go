@@ -0,0 +1,442 @@
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// Config - 처리 설정 (0 값은 기본값 사용)
+type Config struct {
+	ArchiveTTL   time.Duration // 내보내기 파일 보관 기간 (기본 7일, 지나면 PurgeExpired가 삭제)
+	Timeout      time.Duration // 요청당 처리 제한 시간 (기본 10분)
+	PollInterval time.Duration // 대기 요청 확인 주기 (기본 1초)
+	ReceiptKey   []byte        // 삭제 완료 기록 서명 키 (비어 있으면 다이제스트만 남김)
+	Retention    Retention
+}
+
+// Retention - 삭제 방식을 정하는 보존 규칙
+// delete 요청이라도 규칙에 걸리면 행을 남기고 익명화하며, 어느 규칙 때문인지 완료 기록에 남김
+type Retention struct {
+	DefaultMode domain.ErasureMode // 요청에 방식이 없을 때 (기본 delete)
+	// ModerationPeriod - 이 기간 안에 정지/비활성화된 적이 있으면 익명화 (분쟁 대응용 감사 기록 보존, 0이면 규칙 없음)
+	ModerationPeriod time.Duration
+	// LegalHold - "네임스페이스.필드" 속성이 true인 사용자는 익명화 (소송 보존 등, 비어 있으면 규칙 없음)
+	LegalHold string
+}
+
+// 보존 규칙 이름 (완료 기록의 RetentionRule)
+const (
+	RuleLegalHold        = "legal_hold"
+	RuleModerationRecord = "moderation_record"
+)
+
+// decide - 적용할 삭제 방식과 그렇게 정한 규칙
+func (r Retention) decide(user *domain.User, requested domain.ErasureMode, now time.Time) (domain.ErasureMode, string) {
+	mode := requested
+	if mode == "" {
+		mode = r.DefaultMode
+	}
+	if mode != domain.ErasureDelete {
+		return mode, ""
+	}
+
+	if namespace, field, ok := strings.Cut(r.LegalHold, "."); ok {
+		if hold, _ := user.Attributes[namespace][field].(bool); hold {
+			return domain.ErasureAnonymize, RuleLegalHold
+		}
+	}
+	if r.ModerationPeriod > 0 {
+		since := now.Add(-r.ModerationPeriod)
+		for _, c := range user.StatusHistory {
+			moderated := c.To == domain.UserSuspended || (c.To == domain.UserDeactivated && c.Reason != domain.ErasureReason)
+			if moderated && c.At.After(since) {
+				return domain.ErasureAnonymize, RuleModerationRecord
+			}
+		}
+	}
+	return mode, ""
+}
+
+// Service - 개인정보 내보내기/삭제 요청 접수와 처리
+// 요청은 Store에 쌓이고 Run 워커가 하나씩 처리 (사용자 요청을 지연시키지 않음)
+type Service struct {
+	store   Store
+	users   *usecase.UserUseCase
+	blobs   usecase.BlobStore // nil이면 내보내기 사용 안 함 (삭제는 가능)
+	cfg     Config
+	sources []Source
+
+	now func() time.Time
+
+	mu         sync.Mutex // 진행 중 요청 확인과 저장을 한 번에
+	wake       chan struct{}
+	processing sync.Mutex
+}
+
+// NewService - Service 생성자
+// 프로필, 아바타, 이전 요청 기록은 기본으로 포함하고, 추가 소스(WebhookSource 등)는 extra로 등록
+func NewService(store Store, users *usecase.UserUseCase, blobs usecase.BlobStore, cfg Config, extra ...Source) *Service {
+	if cfg.ArchiveTTL <= 0 {
+		cfg.ArchiveTTL = 7 * 24 * time.Hour
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Minute
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.Retention.DefaultMode == "" {
+		cfg.Retention.DefaultMode = domain.ErasureDelete
+	}
+
+	s := &Service{
+		store: store,
+		users: users,
+		blobs: blobs,
+		cfg:   cfg,
+		now:   time.Now,
+		wake:  make(chan struct{}, 1),
+	}
+	s.sources = append([]Source{profileSource{users}, avatarSource{users}, requestSource{s}}, extra...)
+	return s
+}
+
+// RequestExport - 컨텍스트 테넌트 사용자의 데이터 내보내기 요청 (형식이 비어 있으면 zip)
+func (s *Service) RequestExport(ctx context.Context, userID string, format Format, actor string) (*Request, error) {
+	if s.blobs == nil {
+		return nil, ErrExportUnavailable
+	}
+	if format == "" {
+		format = FormatZIP
+	}
+	if !format.Valid() {
+		return nil, ErrInvalidArchiveFormat
+	}
+	return s.submit(ctx, &Request{UserID: userID, Kind: KindExport, Format: format, Actor: actor})
+}
+
+// RequestErasure - 컨텍스트 테넌트 사용자의 개인정보 삭제 요청 (방식이 비어 있으면 보존 규칙의 기본값)
+func (s *Service) RequestErasure(ctx context.Context, userID string, mode domain.ErasureMode, actor string) (*Request, error) {
+	if mode != "" && !mode.Valid() {
+		return nil, domain.ErrInvalidErasureMode
+	}
+	return s.submit(ctx, &Request{UserID: userID, Kind: KindErasure, Mode: mode, Actor: actor})
+}
+
+// submit - 사용자 확인 후 같은 종류의 진행 중 요청이 없으면 저장하고 워커를 깨움
+func (s *Service) submit(ctx context.Context, req *Request) (*Request, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.users.GetUser(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.store.ListByUser(ctx, tenantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.Kind == req.Kind && !e.Status.Done() {
+			return nil, ErrRequestInProgress
+		}
+	}
+
+	req.ID = uuid.New().String()
+	req.TenantID = tenantID
+	req.Status = StatusPending
+	req.RequestedAt = s.now()
+	if err := s.store.Save(ctx, req); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return req, nil
+}
+
+// Request - 요청 조회
+func (s *Service) Request(ctx context.Context, id string) (*Request, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.Get(ctx, tenantID, id)
+}
+
+// Requests - 사용자의 요청 목록 (오래된 순, 삭제된 사용자도 조회 가능)
+func (s *Service) Requests(ctx context.Context, userID string) ([]*Request, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListByUser(ctx, tenantID, userID)
+}
+
+// OpenArchive - 완료된 내보내기 파일 열기 (호출자가 닫아야 함)
+func (s *Service) OpenArchive(ctx context.Context, id string) (io.ReadCloser, *Request, error) {
+	req, err := s.Request(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.Kind != KindExport {
+		return nil, nil, ErrRequestNotFound
+	}
+	switch {
+	case req.Status == StatusExpired,
+		req.Status == StatusCompleted && !s.now().Before(req.Archive.ExpiresAt):
+		return nil, nil, ErrArchiveExpired
+	case req.Status != StatusCompleted:
+		return nil, nil, ErrArchiveNotReady
+	}
+	if s.blobs == nil {
+		return nil, nil, ErrExportUnavailable
+	}
+
+	rc, _, err := s.blobs.Get(ctx, req.Archive.Key)
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return nil, nil, ErrArchiveExpired
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return rc, req, nil
+}
+
+// VerifyReceipt - 완료 기록이 이 서버가 만든 그대로인지 (다이제스트, 서명 키가 있으면 서명까지)
+func (s *Service) VerifyReceipt(r *Receipt) bool {
+	return r.verify(s.cfg.ReceiptKey)
+}
+
+// Run - 대기 중 요청을 처리하는 워커 (ctx가 취소될 때까지 실행)
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.ProcessPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessPending - 대기 중 요청을 오래된 순으로 처리하고 처리한 수 반환
+// 동시에 여러 번 호출되면 순서대로 실행 (같은 요청을 두 번 처리하지 않음)
+func (s *Service) ProcessPending(ctx context.Context) int {
+	s.processing.Lock()
+	defer s.processing.Unlock()
+
+	processed := 0
+	for ctx.Err() == nil {
+		reqs, err := s.store.Pending(ctx, 16)
+		if err != nil {
+			log.Printf("개인정보 요청 조회 실패: %v", err)
+			return processed
+		}
+		if len(reqs) == 0 {
+			return processed
+		}
+		for _, req := range reqs {
+			if ctx.Err() != nil {
+				return processed
+			}
+			s.process(ctx, req)
+			processed++
+		}
+	}
+	return processed
+}
+
+// process - 요청 하나 처리 (결과는 요청 기록에 남김)
+func (s *Service) process(ctx context.Context, req *Request) {
+	req.Status = StatusRunning
+	req.StartedAt = s.now()
+	if err := s.store.Save(ctx, req); err != nil {
+		log.Printf("개인정보 요청 %s 상태 저장 실패: %v", req.ID, err)
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(tenant.WithID(ctx, req.TenantID), s.cfg.Timeout)
+	var err error
+	switch req.Kind {
+	case KindExport:
+		err = s.export(runCtx, req)
+	case KindErasure:
+		err = s.erase(runCtx, req)
+	default:
+		err = fmt.Errorf("알 수 없는 요청 종류: %s", req.Kind)
+	}
+	cancel()
+
+	req.Status = StatusCompleted
+	if err != nil {
+		req.Status = StatusFailed
+		req.Error = err.Error()
+		log.Printf("개인정보 요청 %s (%s, 사용자 %s) 실패: %v", req.ID, req.Kind, req.UserID, err)
+	}
+	req.CompletedAt = s.now()
+	// 처리 중 ctx가 취소돼도 결과는 남김
+	if err := s.store.Save(context.WithoutCancel(ctx), req); err != nil {
+		log.Printf("개인정보 요청 %s 결과 저장 실패: %v", req.ID, err)
+	}
+}
+
+// export - 소스별 데이터를 파일 하나로 묶어 블롭 저장소에 씀
+// 파일은 만들면서 바로 저장소로 흘려보내고, 같이 SHA-256을 계산
+func (s *Service) export(ctx context.Context, req *Request) error {
+	user, err := s.users.GetUser(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	key := fmt.Sprintf("privacy-exports/%s/%s/%s.%s", req.TenantID, req.UserID, req.ID, req.Format)
+	pr, pw := io.Pipe()
+	written := make(chan int, 1)
+	go func() {
+		a := newArchiveWriter(req.Format, pw, Manifest{
+			RequestID:   req.ID,
+			TenantID:    req.TenantID,
+			UserID:      req.UserID,
+			GeneratedAt: now.UTC(),
+		})
+		err := s.writeArchive(ctx, user, a)
+		if err == nil {
+			err = a.Close()
+		}
+		pw.CloseWithError(err)
+		written <- a.Entries()
+	}()
+
+	sum := sha256.New()
+	info, err := s.blobs.Put(ctx, key, req.Format.ContentType(), io.TeeReader(pr, sum))
+	pr.CloseWithError(errArchiveAborted) // 저장이 먼저 실패하면 쓰는 쪽을 멈춤
+	files := <-written
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return err
+	}
+
+	req.Archive = &ArchiveInfo{
+		Key:       key,
+		Size:      info.Size,
+		SHA256:    hexSum(sum),
+		Files:     files,
+		ExpiresAt: now.Add(s.cfg.ArchiveTTL),
+	}
+	return nil
+}
+
+// errArchiveAborted - 저장소가 파일을 다 읽기 전에 실패해 쓰기를 멈춤
+var errArchiveAborted = errors.New("archive upload aborted")
+
+// writeArchive - 등록 순서대로 소스의 데이터를 추가
+func (s *Service) writeArchive(ctx context.Context, user *domain.User, a Archive) error {
+	for _, src := range s.sources {
+		if err := src.Export(ctx, user, a); err != nil {
+			return fmt.Errorf("%s: %w", src.Name(), err)
+		}
+	}
+	return nil
+}
+
+// erase - 보존 규칙으로 방식을 정하고 소스를 역순으로 지운 뒤 완료 기록을 남김
+func (s *Service) erase(ctx context.Context, req *Request) error {
+	user, err := s.users.GetUser(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	mode, rule := s.cfg.Retention.decide(user, req.Mode, s.now())
+	steps := make([]Step, 0, len(s.sources))
+	for i := len(s.sources) - 1; i >= 0; i-- {
+		src := s.sources[i]
+		step, err := src.Erase(ctx, user, mode, req.Actor)
+		if err != nil {
+			return fmt.Errorf("%s: %w", src.Name(), err)
+		}
+		step.Source = src.Name()
+		steps = append(steps, step)
+	}
+
+	receipt := &Receipt{
+		RequestID:     req.ID,
+		TenantID:      req.TenantID,
+		UserID:        req.UserID,
+		RequestedMode: req.Mode,
+		Mode:          mode,
+		RetentionRule: rule,
+		Steps:         steps,
+		CompletedAt:   s.now().UTC(),
+	}
+	receipt.seal(s.cfg.ReceiptKey)
+	req.Receipt = receipt
+	return nil
+}
+
+// PurgeExpired - 보관 기간이 지난 내보내기 파일을 지우고 지운 수 반환 (주기 작업용, 모든 테넌트)
+func (s *Service) PurgeExpired(ctx context.Context) (int, error) {
+	reqs, err := s.store.ExpiredArchives(ctx, s.now())
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, req := range reqs {
+		if err := s.expireArchive(ctx, req); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// expireArchive - 내보내기 파일을 지우고 요청을 만료로 표시
+func (s *Service) expireArchive(ctx context.Context, req *Request) error {
+	if s.blobs != nil {
+		if err := s.blobs.Delete(ctx, req.Archive.Key); err != nil {
+			return err
+		}
+	}
+	req.Status = StatusExpired
+	return s.store.Save(ctx, req)
+}
+
+// deleteBlob - 실패한 내보내기의 남은 파일 정리 (실패는 기록만)
+func (s *Service) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+		log.Printf("내보내기 파일 %s 정리 실패: %v", key, err)
+	}
+}
+
+// notify - 워커 깨우기 (이미 신호가 있으면 생략)
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func hexSum(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
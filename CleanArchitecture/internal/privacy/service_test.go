@@ -0,0 +1,415 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// clock - 테스트에서 직접 움직이는 시계
+type clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *clock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// newTestService - 메모리 사용자 저장소, 임시 디렉터리 블롭 저장소, 고정 시계를 쓰는 Service
+func newTestService(t *testing.T, cfg Config) (*Service, *usecase.UserUseCase, *file.BlobStore, *clock) {
+	t.Helper()
+
+	blobs, err := file.NewBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewBlobStore: %v", err)
+	}
+	schemas := usecase.NewAttributeSchemaUseCase(memory.NewAttributeSchemaRepository())
+	if _, err := schemas.RegisterSchema(testContext(), "privacy", []byte(`{"type":"object","properties":{"legal_hold":{"type":"boolean"}}}`)); err != nil {
+		t.Fatalf("RegisterSchema: %v", err)
+	}
+	users := usecase.NewUserUseCase(memory.NewUserRepository(), usecase.WithBlobStore(blobs), usecase.WithAttributeSchemas(schemas))
+
+	clk := &clock{t: time.Now()}
+	svc := NewService(NewMemoryStore(), users, blobs, cfg)
+	svc.now = clk.now
+	return svc, users, blobs, clk
+}
+
+func testContext() context.Context {
+	return tenant.WithID(context.Background(), "acme")
+}
+
+func pngImage(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// completed - 대기 요청을 처리하고 다시 조회 (완료가 아니면 실패)
+func completed(t *testing.T, svc *Service, id string) *Request {
+	t.Helper()
+	svc.ProcessPending(context.Background())
+	req, err := svc.Request(testContext(), id)
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if req.Status != StatusCompleted {
+		t.Fatalf("status: got %s (%s), want completed", req.Status, req.Error)
+	}
+	return req
+}
+
+func readArchive(t *testing.T, svc *Service, id string) []byte {
+	t.Helper()
+	rc, _, err := svc.OpenArchive(testContext(), id)
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	return data
+}
+
+func TestExportZIP(t *testing.T) {
+	svc, users, _, _ := newTestService(t, Config{})
+	ctx := testContext()
+
+	user, err := users.CreateUser(ctx, "kim@example.com", "Kim")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	avatar := pngImage(t)
+	if _, err := users.SetAvatar(ctx, user.ID, avatar); err != nil {
+		t.Fatalf("SetAvatar: %v", err)
+	}
+
+	req, err := svc.RequestExport(ctx, user.ID, "", "admin")
+	if err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if req.Format != FormatZIP || req.Status != StatusPending {
+		t.Fatalf("request: got %+v", req)
+	}
+	if _, _, err := svc.OpenArchive(ctx, req.ID); !errors.Is(err, ErrArchiveNotReady) {
+		t.Fatalf("open before processing: got %v, want ErrArchiveNotReady", err)
+	}
+
+	req = completed(t, svc, req.ID)
+	data := readArchive(t, svc, req.ID)
+	sum := sha256.Sum256(data)
+	if req.Archive.SHA256 != hex.EncodeToString(sum[:]) || req.Archive.Size != int64(len(data)) {
+		t.Fatalf("archive info does not match content: %+v", req.Archive)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	var profile profileExport
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil {
+		t.Fatalf("profile.json: %v", err)
+	}
+	if profile.ID != user.ID || profile.Email != "kim@example.com" || profile.Status != domain.UserActive {
+		t.Fatalf("profile: got %+v", profile)
+	}
+	if !bytes.Equal(files["avatar/original.png"], avatar) {
+		t.Fatal("avatar/original.png does not match uploaded image")
+	}
+	if _, ok := files["avatar/thumbnail.png"]; !ok {
+		t.Fatal("missing avatar/thumbnail.png")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(files[manifestName], &manifest); err != nil {
+		t.Fatalf("manifest.json: %v", err)
+	}
+	if manifest.UserID != user.ID || len(manifest.Files) != req.Archive.Files || len(manifest.Files) != len(files)-1 {
+		t.Fatalf("manifest: got %+v (archive has %d files)", manifest, len(files))
+	}
+	for _, e := range manifest.Files {
+		sum := sha256.Sum256(files[e.Name])
+		if e.SHA256 != hex.EncodeToString(sum[:]) {
+			t.Fatalf("manifest hash mismatch for %s", e.Name)
+		}
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	svc, users, _, _ := newTestService(t, Config{})
+	ctx := testContext()
+
+	user, _ := users.CreateUser(ctx, "lee@example.com", "Lee")
+	req, err := svc.RequestExport(ctx, user.ID, FormatJSON, "admin")
+	if err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	completed(t, svc, req.ID)
+
+	var doc struct {
+		Manifest Manifest                   `json:"manifest"`
+		Files    map[string]json.RawMessage `json:"files"`
+	}
+	if err := json.Unmarshal(readArchive(t, svc, req.ID), &doc); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+	var profile profileExport
+	if err := json.Unmarshal(doc.Files["profile.json"], &profile); err != nil || profile.Email != "lee@example.com" {
+		t.Fatalf("profile.json: got %+v, %v", profile, err)
+	}
+	if doc.Manifest.Format != FormatJSON || len(doc.Manifest.Files) != len(doc.Files) {
+		t.Fatalf("manifest: got %+v", doc.Manifest)
+	}
+}
+
+func TestRequestValidation(t *testing.T) {
+	svc, users, _, _ := newTestService(t, Config{})
+	ctx := testContext()
+	user, _ := users.CreateUser(ctx, "park@example.com", "Park")
+
+	if _, err := svc.RequestExport(ctx, user.ID, "tar", "admin"); !errors.Is(err, ErrInvalidArchiveFormat) {
+		t.Fatalf("invalid format: got %v", err)
+	}
+	if _, err := svc.RequestErasure(ctx, user.ID, "shred", "admin"); !errors.Is(err, domain.ErrInvalidErasureMode) {
+		t.Fatalf("invalid mode: got %v", err)
+	}
+	if _, err := svc.RequestExport(ctx, "missing", "", "admin"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("unknown user: got %v", err)
+	}
+
+	if _, err := svc.RequestExport(ctx, user.ID, "", "admin"); err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if _, err := svc.RequestExport(ctx, user.ID, FormatJSON, "admin"); !errors.Is(err, ErrRequestInProgress) {
+		t.Fatalf("second export while pending: got %v, want ErrRequestInProgress", err)
+	}
+
+	other := tenant.WithID(context.Background(), "globex")
+	reqs, _ := svc.Requests(ctx, user.ID)
+	if _, err := svc.Request(other, reqs[0].ID); !errors.Is(err, domain.ErrCrossTenant) {
+		t.Fatalf("cross-tenant lookup: got %v", err)
+	}
+
+	noBlobs := NewService(NewMemoryStore(), users, nil, Config{})
+	if _, err := noBlobs.RequestExport(ctx, user.ID, "", "admin"); !errors.Is(err, ErrExportUnavailable) {
+		t.Fatalf("export without blob store: got %v", err)
+	}
+}
+
+func TestErasureDelete(t *testing.T) {
+	key := []byte("receipt-key")
+	svc, users, blobs, _ := newTestService(t, Config{ReceiptKey: key})
+	ctx := testContext()
+
+	user, _ := users.CreateUser(ctx, "choi@example.com", "Choi")
+	if _, err := users.SetAvatar(ctx, user.ID, pngImage(t)); err != nil {
+		t.Fatalf("SetAvatar: %v", err)
+	}
+	export, _ := svc.RequestExport(ctx, user.ID, "", "admin")
+	export = completed(t, svc, export.ID)
+
+	req, err := svc.RequestErasure(ctx, user.ID, "", "admin")
+	if err != nil {
+		t.Fatalf("RequestErasure: %v", err)
+	}
+	req = completed(t, svc, req.ID)
+
+	if _, err := users.GetUser(ctx, user.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("user after erasure: got %v, want ErrUserNotFound", err)
+	}
+	for _, variant := range avatarVariants {
+		if _, _, err := blobs.Get(ctx, domain.AvatarKey("acme", user.ID, variant)); !errors.Is(err, domain.ErrBlobNotFound) {
+			t.Fatalf("%s avatar after erasure: %v", variant, err)
+		}
+	}
+	if _, _, err := blobs.Get(ctx, export.Archive.Key); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Fatalf("export archive after erasure: %v", err)
+	}
+	if _, _, err := svc.OpenArchive(ctx, export.ID); !errors.Is(err, ErrArchiveExpired) {
+		t.Fatalf("open export after erasure: got %v, want ErrArchiveExpired", err)
+	}
+
+	r := req.Receipt
+	if r == nil || r.Mode != domain.ErasureDelete || r.RetentionRule != "" || r.Signature == "" {
+		t.Fatalf("receipt: got %+v", r)
+	}
+	want := map[string]Step{
+		"profile":          {Source: "profile", Action: ActionDeleted, Items: 1},
+		"avatar":           {Source: "avatar", Action: ActionDeleted, Items: 2},
+		"privacy_requests": {Source: "privacy_requests", Action: ActionDeleted, Items: 1},
+	}
+	for _, step := range r.Steps {
+		if w, ok := want[step.Source]; ok && step != w {
+			t.Fatalf("step %s: got %+v, want %+v", step.Source, step, w)
+		}
+	}
+	if r.Steps[len(r.Steps)-1].Source != "profile" {
+		t.Fatalf("profile must be erased last: %+v", r.Steps)
+	}
+
+	if !svc.VerifyReceipt(r) {
+		t.Fatal("receipt does not verify")
+	}
+	tampered := *r
+	tampered.Mode = domain.ErasureAnonymize
+	if svc.VerifyReceipt(&tampered) {
+		t.Fatal("tampered receipt verifies")
+	}
+	forged := *r
+	forged.Mode = domain.ErasureAnonymize
+	forged.seal([]byte("other-key"))
+	if svc.VerifyReceipt(&forged) {
+		t.Fatal("receipt signed with another key verifies")
+	}
+}
+
+func TestErasureRetentionRules(t *testing.T) {
+	svc, users, _, clk := newTestService(t, Config{Retention: Retention{
+		ModerationPeriod: 30 * 24 * time.Hour,
+		LegalHold:        "privacy.legal_hold",
+	}})
+	ctx := testContext()
+
+	erase := func(t *testing.T, id string, mode domain.ErasureMode) *Receipt {
+		t.Helper()
+		req, err := svc.RequestErasure(ctx, id, mode, "admin")
+		if err != nil {
+			t.Fatalf("RequestErasure: %v", err)
+		}
+		return completed(t, svc, req.ID).Receipt
+	}
+	assertAnonymized := func(t *testing.T, id string) {
+		t.Helper()
+		u, err := users.GetUser(ctx, id)
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if !u.Anonymized() || u.Name != domain.ErasedName || u.Attributes != nil || u.CurrentStatus() != domain.UserDeactivated {
+			t.Fatalf("user not anonymized: %+v", u)
+		}
+	}
+
+	t.Run("legal hold", func(t *testing.T) {
+		u, err := users.CreateUserWithAttributes(ctx, "held@example.com", "Held", domain.Attributes{
+			"privacy": {"legal_hold": true},
+		})
+		if err != nil {
+			t.Fatalf("CreateUserWithAttributes: %v", err)
+		}
+		r := erase(t, u.ID, domain.ErasureDelete)
+		if r.Mode != domain.ErasureAnonymize || r.RetentionRule != RuleLegalHold || r.RequestedMode != domain.ErasureDelete {
+			t.Fatalf("receipt: got %+v", r)
+		}
+		assertAnonymized(t, u.ID)
+	})
+
+	t.Run("recent moderation", func(t *testing.T) {
+		u, _ := users.CreateUser(ctx, "banned@example.com", "Banned")
+		if _, err := users.SuspendUser(ctx, u.ID, "spam", "moderator"); err != nil {
+			t.Fatalf("SuspendUser: %v", err)
+		}
+		r := erase(t, u.ID, "")
+		if r.Mode != domain.ErasureAnonymize || r.RetentionRule != RuleModerationRecord {
+			t.Fatalf("receipt: got %+v", r)
+		}
+		assertAnonymized(t, u.ID)
+		stored, _ := users.GetUser(ctx, u.ID)
+		for _, c := range stored.StatusHistory {
+			if c.Reason == "spam" {
+				t.Fatalf("status reason kept after anonymization: %+v", stored.StatusHistory)
+			}
+		}
+	})
+
+	t.Run("moderation outside period", func(t *testing.T) {
+		u, _ := users.CreateUser(ctx, "old@example.com", "Old")
+		if _, err := users.SuspendUser(ctx, u.ID, "spam", "moderator"); err != nil {
+			t.Fatalf("SuspendUser: %v", err)
+		}
+		clk.advance(31 * 24 * time.Hour)
+		defer clk.advance(-31 * 24 * time.Hour)
+
+		r := erase(t, u.ID, "")
+		if r.Mode != domain.ErasureDelete || r.RetentionRule != "" {
+			t.Fatalf("receipt: got %+v", r)
+		}
+	})
+
+	t.Run("requested anonymize", func(t *testing.T) {
+		u, _ := users.CreateUser(ctx, "anon@example.com", "Anon")
+		r := erase(t, u.ID, domain.ErasureAnonymize)
+		if r.Mode != domain.ErasureAnonymize || r.RetentionRule != "" {
+			t.Fatalf("receipt: got %+v", r)
+		}
+		assertAnonymized(t, u.ID)
+	})
+}
+
+func TestPurgeExpired(t *testing.T) {
+	svc, users, blobs, clk := newTestService(t, Config{ArchiveTTL: time.Hour})
+	ctx := testContext()
+
+	user, _ := users.CreateUser(ctx, "han@example.com", "Han")
+	req, _ := svc.RequestExport(ctx, user.ID, "", "admin")
+	req = completed(t, svc, req.ID)
+
+	if n, err := svc.PurgeExpired(context.Background()); err != nil || n != 0 {
+		t.Fatalf("purge before expiry: got %d, %v", n, err)
+	}
+
+	clk.advance(time.Hour)
+	if _, _, err := svc.OpenArchive(ctx, req.ID); !errors.Is(err, ErrArchiveExpired) {
+		t.Fatalf("open after TTL: got %v, want ErrArchiveExpired", err)
+	}
+	if n, err := svc.PurgeExpired(context.Background()); err != nil || n != 1 {
+		t.Fatalf("purge: got %d, %v", n, err)
+	}
+	if _, _, err := blobs.Get(ctx, req.Archive.Key); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Fatalf("archive after purge: %v", err)
+	}
+	got, _ := svc.Request(ctx, req.ID)
+	if got.Status != StatusExpired {
+		t.Fatalf("status after purge: got %s", got.Status)
+	}
+}
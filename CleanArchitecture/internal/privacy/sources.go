@@ -0,0 +1,276 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"github.com/milman2/go-api/clean-architecture/internal/webhook"
+)
+
+// Source - 사용자에게 딸린 데이터 한 종류 (내보내기와 삭제를 함께 구현)
+// ctx에는 사용자의 테넌트가 들어 있음
+// 삭제는 등록의 역순으로 실행되므로, 다른 소스가 사용자 조회에 의존하는 동안 프로필은 마지막에 지워짐
+type Source interface {
+	Name() string
+	// Export - 데이터를 Archive에 추가 (없으면 아무것도 추가하지 않아도 됨)
+	Export(ctx context.Context, user *domain.User, a Archive) error
+	// Erase - 데이터를 삭제 방식에 맞게 지우고 결과 반환 (Step.Source는 Name으로 채워짐, 다시 호출해도 안전해야 함)
+	Erase(ctx context.Context, user *domain.User, mode domain.ErasureMode, actor string) (Step, error)
+}
+
+// profileSource - 프로필, 사용자 정의 속성, 상태 변경 기록
+type profileSource struct {
+	users *usecase.UserUseCase
+}
+
+// profileExport - profile.json 형식
+type profileExport struct {
+	ID            string                    `json:"id"`
+	TenantID      string                    `json:"tenant_id"`
+	Email         string                    `json:"email"`
+	Name          string                    `json:"name"`
+	Status        domain.UserStatus         `json:"status"`
+	StatusHistory []statusChangeExport      `json:"status_history"`
+	Attributes    map[string]map[string]any `json:"attributes"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+type statusChangeExport struct {
+	From   domain.UserStatus `json:"from"`
+	To     domain.UserStatus `json:"to"`
+	Reason string            `json:"reason,omitempty"`
+	Actor  string            `json:"actor"`
+	At     time.Time         `json:"at"`
+}
+
+func (profileSource) Name() string {
+	return "profile"
+}
+
+func (profileSource) Export(ctx context.Context, user *domain.User, a Archive) error {
+	p := profileExport{
+		ID:            user.ID,
+		TenantID:      user.TenantID,
+		Email:         user.Email,
+		Name:          user.Name,
+		Status:        user.CurrentStatus(),
+		StatusHistory: make([]statusChangeExport, len(user.StatusHistory)),
+		Attributes:    user.Attributes,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+	for i, c := range user.StatusHistory {
+		p.StatusHistory[i] = statusChangeExport(c)
+	}
+	return a.AddJSON("profile.json", p)
+}
+
+func (s profileSource) Erase(ctx context.Context, user *domain.User, mode domain.ErasureMode, actor string) (Step, error) {
+	if err := s.users.EraseUser(ctx, user.ID, mode, actor); err != nil {
+		return Step{}, err
+	}
+	action := ActionDeleted
+	if mode == domain.ErasureAnonymize {
+		action = ActionAnonymized
+	}
+	return Step{Action: action, Items: 1}, nil
+}
+
+// avatarSource - 아바타 원본과 썸네일
+type avatarSource struct {
+	users *usecase.UserUseCase
+}
+
+var avatarVariants = []domain.AvatarVariant{domain.AvatarOriginal, domain.AvatarThumbnail}
+
+// avatarExtensions - 내용 형식별 확장자 (domain.AvatarContentTypes와 같은 목록)
+var avatarExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+func (avatarSource) Name() string {
+	return "avatar"
+}
+
+func (s avatarSource) Export(ctx context.Context, user *domain.User, a Archive) error {
+	for _, variant := range avatarVariants {
+		rc, info, err := s.users.GetAvatar(ctx, user.ID, variant)
+		if errors.Is(err, domain.ErrAvatarNotFound) || errors.Is(err, usecase.ErrAvatarUnavailable) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = a.AddFile("avatar/"+string(variant)+avatarExtensions[info.ContentType], info.ContentType, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s avatarSource) Erase(ctx context.Context, user *domain.User, mode domain.ErasureMode, actor string) (Step, error) {
+	// 지운 개수를 기록하기 위해 먼저 있는지 확인
+	present := 0
+	for _, variant := range avatarVariants {
+		rc, _, err := s.users.GetAvatar(ctx, user.ID, variant)
+		if errors.Is(err, usecase.ErrAvatarUnavailable) {
+			return Step{Action: ActionSkipped}, nil
+		}
+		if err == nil {
+			rc.Close()
+			present++
+		}
+	}
+	if present == 0 {
+		return Step{Action: ActionSkipped}, nil
+	}
+	if err := s.users.DeleteAvatar(ctx, user.ID); err != nil {
+		return Step{}, err
+	}
+	return Step{Action: ActionDeleted, Items: present}, nil
+}
+
+// requestSource - 이 사용자의 이전 개인정보 요청과 내보내기 파일
+type requestSource struct {
+	s *Service
+}
+
+// requestExport - privacy_requests.json 항목
+type requestExport struct {
+	ID          string     `json:"id"`
+	Kind        Kind       `json:"kind"`
+	Status      Status     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (requestSource) Name() string {
+	return "privacy_requests"
+}
+
+func (r requestSource) Export(ctx context.Context, user *domain.User, a Archive) error {
+	reqs, err := r.s.store.ListByUser(ctx, user.TenantID, user.ID)
+	if err != nil {
+		return err
+	}
+	out := make([]requestExport, len(reqs))
+	for i, req := range reqs {
+		out[i] = requestExport{ID: req.ID, Kind: req.Kind, Status: req.Status, RequestedAt: req.RequestedAt}
+		if !req.CompletedAt.IsZero() {
+			out[i].CompletedAt = &req.CompletedAt
+		}
+	}
+	return a.AddJSON("privacy_requests.json", out)
+}
+
+// Erase - 남아 있는 내보내기 파일을 지우고, 아직 처리하지 않은 내보내기는 실패로 닫음
+// 요청 기록 자체는 개인정보 없이 ID만 있으므로 처리 증빙으로 남김
+func (r requestSource) Erase(ctx context.Context, user *domain.User, mode domain.ErasureMode, actor string) (Step, error) {
+	reqs, err := r.s.store.ListByUser(ctx, user.TenantID, user.ID)
+	if err != nil {
+		return Step{}, err
+	}
+
+	deleted := 0
+	for _, req := range reqs {
+		if req.Kind != KindExport {
+			continue
+		}
+		switch {
+		case req.Status == StatusCompleted && req.Archive != nil:
+			if err := r.s.expireArchive(ctx, req); err != nil {
+				return Step{}, err
+			}
+			deleted++
+		case req.Status == StatusPending:
+			req.Status = StatusFailed
+			req.Error = "canceled by erasure"
+			req.CompletedAt = r.s.now()
+			if err := r.s.store.Save(ctx, req); err != nil {
+				return Step{}, err
+			}
+		}
+	}
+	if deleted == 0 {
+		return Step{Action: ActionSkipped}, nil
+	}
+	return Step{Action: ActionDeleted, Items: deleted}, nil
+}
+
+// webhookSource - 사용자 이벤트를 외부로 보낸 웹훅 전송 기록 (누구에게 무엇을 보냈는지)
+type webhookSource struct {
+	webhooks *webhook.Service
+}
+
+// WebhookSource - 웹훅 전송 기록 소스 (내보내기: 받은 곳과 본문, 삭제: 본문의 개인정보 제거)
+func WebhookSource(webhooks *webhook.Service) Source {
+	return webhookSource{webhooks: webhooks}
+}
+
+// deliveryExport - webhook_deliveries.json 항목
+type deliveryExport struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	URL            string          `json:"url,omitempty"` // 구독이 삭제됐으면 빈 문자열
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+func (webhookSource) Name() string {
+	return "webhook_deliveries"
+}
+
+func (w webhookSource) Export(ctx context.Context, user *domain.User, a Archive) error {
+	deliveries, err := w.webhooks.UserDeliveries(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	urls := make(map[string]string)
+	out := make([]deliveryExport, len(deliveries))
+	for i, d := range deliveries {
+		url, ok := urls[d.SubscriptionID]
+		if !ok {
+			sub, err := w.webhooks.Subscription(ctx, d.SubscriptionID)
+			switch {
+			case err == nil:
+				url = sub.URL
+			case !errors.Is(err, webhook.ErrSubscriptionNotFound):
+				return err
+			}
+			urls[d.SubscriptionID] = url
+		}
+		out[i] = deliveryExport{
+			ID:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			URL:            url,
+			EventType:      string(d.EventType),
+			Status:         string(d.Status),
+			CreatedAt:      d.CreatedAt,
+			Payload:        d.Payload,
+		}
+	}
+	return a.AddJSON("webhook_deliveries.json", out)
+}
+
+func (w webhookSource) Erase(ctx context.Context, user *domain.User, mode domain.ErasureMode, actor string) (Step, error) {
+	n, err := w.webhooks.RedactUserDeliveries(ctx, user.ID)
+	if err != nil {
+		return Step{}, err
+	}
+	if n == 0 {
+		return Step{Action: ActionSkipped}, nil
+	}
+	return Step{Action: ActionRedacted, Items: n}, nil
+}
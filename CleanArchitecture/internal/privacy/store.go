@@ -0,0 +1,102 @@
+package privacy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// Store - 요청 기록 저장소 (포트)
+// 다른 테넌트의 요청을 조회하면 domain.ErrCrossTenant
+type Store interface {
+	// Save - 요청 생성 또는 갱신
+	Save(ctx context.Context, req *Request) error
+	Get(ctx context.Context, tenantID, id string) (*Request, error)
+	// ListByUser - 사용자의 요청 (오래된 순)
+	ListByUser(ctx context.Context, tenantID, userID string) ([]*Request, error)
+	// Pending - 처리 대기 중인 요청 (모든 테넌트, 오래된 순, 최대 limit개)
+	Pending(ctx context.Context, limit int) ([]*Request, error)
+	// ExpiredArchives - 보관 기간이 지난 내보내기 파일이 남아 있는 요청 (모든 테넌트)
+	ExpiredArchives(ctx context.Context, now time.Time) ([]*Request, error)
+}
+
+// MemoryStore - 메모리 기반 저장소 (단일 인스턴스용, 재시작하면 기록이 사라짐)
+type MemoryStore struct {
+	mu       sync.Mutex
+	requests map[string]*Request
+}
+
+// NewMemoryStore - MemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		requests: make(map[string]*Request),
+	}
+}
+
+// Save - 요청 저장
+func (s *MemoryStore) Save(ctx context.Context, req *Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[req.ID] = copyRequest(req)
+	return nil
+}
+
+// Get - 요청 조회
+func (s *MemoryStore) Get(ctx context.Context, tenantID, id string) (*Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, ok := s.requests[id]
+	if !ok {
+		return nil, ErrRequestNotFound
+	}
+	if req.TenantID != tenantID {
+		return nil, domain.ErrCrossTenant
+	}
+	return copyRequest(req), nil
+}
+
+// ListByUser - 사용자의 요청 (오래된 순)
+func (s *MemoryStore) ListByUser(ctx context.Context, tenantID, userID string) ([]*Request, error) {
+	return s.filter(0, func(req *Request) bool {
+		return req.TenantID == tenantID && req.UserID == userID
+	}), nil
+}
+
+// Pending - 처리 대기 중인 요청 (오래된 순)
+func (s *MemoryStore) Pending(ctx context.Context, limit int) ([]*Request, error) {
+	return s.filter(limit, func(req *Request) bool {
+		return req.Status == StatusPending
+	}), nil
+}
+
+// ExpiredArchives - 보관 기간이 지난 내보내기 요청
+func (s *MemoryStore) ExpiredArchives(ctx context.Context, now time.Time) ([]*Request, error) {
+	return s.filter(0, func(req *Request) bool {
+		return req.Status == StatusCompleted && req.Archive != nil && !req.Archive.ExpiresAt.After(now)
+	}), nil
+}
+
+// filter - 조건에 맞는 요청 사본 (요청 시각순, limit이 0이면 모두)
+func (s *MemoryStore) filter(limit int, match func(*Request) bool) []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Request, 0)
+	for _, req := range s.requests {
+		if match(req) {
+			out = append(out, copyRequest(req))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].RequestedAt.Before(out[j].RequestedAt)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
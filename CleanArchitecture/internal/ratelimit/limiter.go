@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidLimit - 요청 간격을 계산할 수 없는 제한 (Rate/Period가 0 이하 등)
+var ErrInvalidLimit = errors.New("ratelimit: invalid limit")
+
+// Limit - 속도 제한 정책
+// Period 동안 Rate번 요청을 허용하고, 최대 Burst번까지 연속 요청 허용
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// PerSecond - 초당 n번 (버스트 n)
+func PerSecond(n int) Limit {
+	return Limit{Rate: n, Period: time.Second, Burst: n}
+}
+
+// PerMinute - 분당 n번 (버스트 n)
+func PerMinute(n int) Limit {
+	return Limit{Rate: n, Period: time.Minute, Burst: n}
+}
+
+// Validate - 제한 값 확인 (Rate, Period는 양수이고 요청 하나당 간격이 0보다 커야 함, Burst는 0 이상)
+func (l Limit) Validate() error {
+	if l.Rate <= 0 || l.Period <= 0 || l.Burst < 0 || l.emissionInterval() <= 0 {
+		return fmt.Errorf("%w: rate %d, period %s, burst %d", ErrInvalidLimit, l.Rate, l.Period, l.Burst)
+	}
+	return nil
+}
+
+// emissionInterval - 요청 하나당 소모 시간 (GCRA의 T)
+func (l Limit) emissionInterval() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return l.Period / time.Duration(l.Rate)
+}
+
+// burst - 버스트 허용량 (0이면 Rate)
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// Result - 속도 제한 판정 결과
+type Result struct {
+	Allowed    bool
+	Limit      int           // 버스트 허용량
+	Remaining  int           // 남은 요청 수
+	RetryAfter time.Duration // 거부된 경우 다시 시도할 수 있을 때까지
+	ResetAfter time.Duration // 허용량이 모두 회복될 때까지
+}
+
+// Limiter - GCRA(Generic Cell Rate Algorithm) 기반 속도 제한기
+// 키별로 TAT(theoretical arrival time) 하나만 저장하면 되므로
+// 토큰 버킷과 같은 동작을 저장소 한 번 갱신으로 구현 가능
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewLimiter - Limiter 생성자
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow - 요청 하나를 허용할지 판정 (잘못된 제한이면 ErrInvalidLimit)
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.Validate(); err != nil {
+		return Result{}, err
+	}
+	interval := limit.emissionInterval()
+	burst := limit.burst()
+	burstOffset := interval * time.Duration(burst)
+
+	var res Result
+	err := l.store.Update(ctx, key, func(tat time.Time, exists bool) (time.Time, bool) {
+		now := l.now()
+		if !exists || tat.Before(now) {
+			tat = now
+		}
+
+		newTAT := tat.Add(interval)
+		allowAt := newTAT.Add(-burstOffset)
+
+		res = Result{Limit: burst}
+		if now.Before(allowAt) {
+			// 거부: TAT는 그대로 유지
+			res.RetryAfter = allowAt.Sub(now)
+			res.ResetAfter = tat.Sub(now)
+			return time.Time{}, false
+		}
+
+		res.Allowed = true
+		res.Remaining = int(now.Sub(allowAt) / interval)
+		res.ResetAfter = newTAT.Sub(now)
+		return newTAT, true
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimitValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Limit
+		ok    bool
+	}{
+		{"per minute", PerMinute(10), true},
+		{"burst defaults to rate", Limit{Rate: 10, Period: time.Minute}, true},
+		{"zero rate", Limit{Rate: 0, Period: time.Minute, Burst: 5}, false},
+		{"negative rate", Limit{Rate: -1, Period: time.Minute}, false},
+		{"zero period", Limit{Rate: 10}, false},
+		{"negative burst", Limit{Rate: 10, Period: time.Minute, Burst: -1}, false},
+		{"interval rounds to zero", Limit{Rate: 10, Period: time.Nanosecond}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limit.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidLimit) {
+				t.Fatalf("Validate() = %v, want ErrInvalidLimit", err)
+			}
+		})
+	}
+}
+
+// 잘못된 제한은 나눗셈 전에 에러 (Rate=0이면 간격 계산에서 패닉)
+func TestAllowRejectsInvalidLimit(t *testing.T) {
+	l := NewLimiter(NewMemoryStore())
+	if _, err := l.Allow(context.Background(), "k", Limit{Period: time.Minute}); !errors.Is(err, ErrInvalidLimit) {
+		t.Fatalf("Allow() = %v, want ErrInvalidLimit", err)
+	}
+}
+
+func TestAllowBurstThenRefill(t *testing.T) {
+	now := time.Now()
+	l := NewLimiter(NewMemoryStore())
+	l.now = func() time.Time { return now }
+	limit := Limit{Rate: 60, Period: time.Minute, Burst: 2}
+	ctx := context.Background()
+
+	for i, want := range []bool{true, true, false} {
+		res, err := l.Allow(ctx, "k", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allowed != want {
+			t.Fatalf("request %d: allowed = %v, want %v", i, res.Allowed, want)
+		}
+	}
+
+	now = now.Add(time.Second)
+	if res, err := l.Allow(ctx, "k", limit); err != nil || !res.Allowed {
+		t.Fatalf("after refill: %+v, %v", res, err)
+	}
+	// 다른 키는 따로 셈
+	if res, err := l.Allow(ctx, "other", limit); err != nil || !res.Allowed {
+		t.Fatalf("other key: %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UpdateFunc - 저장된 TAT를 받아 새 TAT와 저장 여부를 반환
+// 저장소 구현에 따라 재시도될 수 있으므로 부수 효과가 없어야 함
+type UpdateFunc func(tat time.Time, exists bool) (next time.Time, store bool)
+
+// Store - 속도 제한 상태 저장소 (포트)
+// Update는 키 하나에 대해 원자적으로 읽고-계산하고-쓰기를 수행해야 함
+// 저장된 TAT가 지나면 상태가 없는 것과 같으므로 그 이후 만료시켜도 됨
+type Store interface {
+	Update(ctx context.Context, key string, fn UpdateFunc) error
+}
+
+// MemoryStore - 메모리 기반 저장소 (단일 인스턴스용)
+type MemoryStore struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+// sweepInterval - 만료된 키 정리 주기
+const sweepInterval = time.Minute
+
+// NewMemoryStore - MemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tats:      make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Update - 키의 TAT 원자적 갱신
+func (s *MemoryStore) Update(ctx context.Context, key string, fn UpdateFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+
+	tat, exists := s.tats[key]
+	next, store := fn(tat, exists)
+	if store {
+		s.tats[key] = next
+	}
+
+	return nil
+}
+
+// sweep - TAT가 지난 키 제거 (호출자가 잠금 보유)
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, tat := range s.tats {
+		if tat.Before(now) {
+			delete(s.tats, key)
+		}
+	}
+	s.lastSweep = now
+}
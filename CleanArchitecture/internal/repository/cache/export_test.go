@@ -0,0 +1,8 @@
+package cache
+
+import "time"
+
+// SetClock - 테스트용: 만료 계산에 쓰는 현재 시각 함수 교체
+func SetClock(r *UserRepository, now func() time.Time) {
+	r.now = now
+}
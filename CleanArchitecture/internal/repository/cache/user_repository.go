@@ -0,0 +1,317 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// Config - 캐시 설정
+type Config struct {
+	Size        int           // 최대 항목 수 (LRU)
+	TTL         time.Duration // 조회 결과 유지 시간
+	NegativeTTL time.Duration // "없음" 결과 유지 시간 (0이면 캐시하지 않음)
+}
+
+// entry - 캐시 항목 (user가 nil이면 negative 항목)
+type entry struct {
+	id        string
+	user      *domain.User
+	expiresAt time.Time
+}
+
+// UserRepository - 읽기 캐시 데코레이터 (어댑터)
+// GetByID 결과를 LRU로 캐시하고, Create/Update/Delete 시 해당 항목 무효화
+// GetByEmail, GetAll은 중복 검사 등에 쓰이므로 항상 원본 저장소 조회
+// 캐시는 ID 기준이므로 적중 시에도 컨텍스트의 테넌트를 다시 확인
+type UserRepository struct {
+	next usecase.UserRepository
+	cfg  Config
+	now  func() time.Time
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	gen   uint64 // 무효화할 때마다 증가 (조회 중 변경된 결과를 캐시하지 않기 위함)
+}
+
+// NewUserRepository - UserRepository 생성자
+func NewUserRepository(next usecase.UserRepository, cfg Config) *UserRepository {
+	if cfg.Size <= 0 {
+		cfg.Size = 1
+	}
+	return &UserRepository{
+		next:  next,
+		cfg:   cfg,
+		now:   time.Now,
+		lru:   list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get - 캐시 조회 (만료된 항목은 제거)
+func (r *UserRepository) get(id string) (*entry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.items[id]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !r.now().Before(e.expiresAt) {
+		r.lru.Remove(el)
+		delete(r.items, id)
+		return nil, false
+	}
+
+	r.lru.MoveToFront(el)
+	return e, true
+}
+
+// generation - 현재 무효화 세대
+func (r *UserRepository) generation() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.gen
+}
+
+// put - 캐시 저장 (용량 초과 시 가장 오래 안 쓴 항목 제거)
+// 원본 조회 이후 무효화가 있었다면 저장하지 않음
+func (r *UserRepository) put(id string, user *domain.User, ttl time.Duration, gen uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if gen != r.gen {
+		return
+	}
+
+	e := &entry{id: id, user: user, expiresAt: r.now().Add(ttl)}
+	if el, ok := r.items[id]; ok {
+		el.Value = e
+		r.lru.MoveToFront(el)
+		return
+	}
+
+	r.items[id] = r.lru.PushFront(e)
+	for r.lru.Len() > r.cfg.Size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.items, oldest.Value.(*entry).id)
+	}
+}
+
+// invalidate - 캐시 항목 제거
+func (r *UserRepository) invalidate(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gen++
+	if el, ok := r.items[id]; ok {
+		r.lru.Remove(el)
+		delete(r.items, id)
+	}
+}
+
+// Create - 사용자 생성 (같은 ID의 negative 항목 제거)
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	err := r.next.Create(ctx, user)
+	r.invalidate(user.ID)
+	return err
+}
+
+// GetByID - ID로 사용자 조회 (캐시 우선)
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, err
+	}
+
+	if e, ok := r.get(id); ok {
+		if e.user == nil {
+			return nil, domain.ErrUserNotFound
+		}
+		if err := tenant.Check(ctx, e.user); err != nil {
+			return nil, err
+		}
+		return e.user.Clone(), nil
+	}
+
+	gen := r.generation()
+	user, err := r.next.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		if r.cfg.NegativeTTL > 0 {
+			r.put(id, nil, r.cfg.NegativeTTL, gen)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.put(id, user.Clone(), r.cfg.TTL, gen)
+	return user, nil
+}
+
+// GetByIDs - 캐시에 있는 ID는 캐시에서, 나머지는 원본 저장소에서 한 번에 조회 (usecase.UserBatchGetter 구현)
+// 원본 저장소가 usecase.UserBatchGetter가 아니면 나머지를 ID마다 조회
+// 결과에서 빠진 ID는 다른 테넌트 소속일 수도 있으므로 "없음"으로 캐시하지 않음
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*domain.User, len(ids))
+	var misses []string
+	for _, id := range ids {
+		if e, ok := r.get(id); ok {
+			if e.user != nil && e.user.TenantID == tenantID {
+				users[id] = e.user.Clone()
+			}
+			continue
+		}
+		misses = append(misses, id)
+	}
+	if len(misses) == 0 {
+		return users, nil
+	}
+
+	gen := r.generation()
+	var fetched map[string]*domain.User
+	if getter, ok := r.next.(usecase.UserBatchGetter); ok {
+		if fetched, err = getter.GetByIDs(ctx, misses); err != nil {
+			return nil, err
+		}
+	} else {
+		fetched = make(map[string]*domain.User, len(misses))
+		for _, id := range misses {
+			user, err := r.next.GetByID(ctx, id)
+			if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrCrossTenant) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			fetched[id] = user
+		}
+	}
+
+	for id, user := range fetched {
+		r.put(id, user.Clone(), r.cfg.TTL, gen)
+		users[id] = user
+	}
+	return users, nil
+}
+
+// GetByEmail - 이메일로 사용자 조회 (캐시하지 않음)
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.next.GetByEmail(ctx, email)
+}
+
+// GetAll - 모든 사용자 조회 (캐시하지 않음)
+func (r *UserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	return r.next.GetAll(ctx)
+}
+
+// Update - 사용자 정보 수정 (캐시 무효화)
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	err := r.next.Update(ctx, user)
+	r.invalidate(user.ID)
+	return err
+}
+
+// Delete - 사용자 삭제 (캐시 무효화)
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	r.invalidate(id)
+	return err
+}
+
+// Stream - 원본 저장소가 usecase.UserStreamer를 구현하면 그대로 위임
+func (r *UserRepository) Stream(ctx context.Context, fn func(*domain.User) error) error {
+	if streamer, ok := r.next.(usecase.UserStreamer); ok {
+		return streamer.Stream(ctx, fn)
+	}
+
+	users, err := r.next.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByAttributes - 원본 저장소가 usecase.UserAttributeQuerier를 구현하면 그대로 위임
+func (r *UserRepository) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error) {
+	if querier, ok := r.next.(usecase.UserAttributeQuerier); ok {
+		return querier.FindByAttributes(ctx, filters)
+	}
+	for _, f := range filters {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		if domain.MatchAttributes(user, filters) {
+			matched = append(matched, user)
+		}
+	}
+	return matched, nil
+}
+
+// InTransaction - 원본 저장소가 usecase.UserTransactor를 구현하면 위임 (아니면 usecase.ErrTransactionsUnsupported)
+// 트랜잭션이 끝나면 (커밋/취소와 무관하게) 그 안에서 쓴 ID의 캐시를 무효화
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(tx usecase.UserRepository) error) error {
+	transactor, ok := r.next.(usecase.UserTransactor)
+	if !ok {
+		return usecase.ErrTransactionsUnsupported
+	}
+
+	var written []string
+	defer func() {
+		for _, id := range written {
+			r.invalidate(id)
+		}
+	}()
+	return transactor.InTransaction(ctx, func(tx usecase.UserRepository) error {
+		return fn(&txRecorder{UserRepository: tx, written: &written})
+	})
+}
+
+// txRecorder - 트랜잭션 안에서 쓴 ID 기록
+type txRecorder struct {
+	usecase.UserRepository
+	written *[]string
+}
+
+func (t *txRecorder) Create(ctx context.Context, user *domain.User) error {
+	*t.written = append(*t.written, user.ID)
+	return t.UserRepository.Create(ctx, user)
+}
+
+func (t *txRecorder) Update(ctx context.Context, user *domain.User) error {
+	*t.written = append(*t.written, user.ID)
+	return t.UserRepository.Update(ctx, user)
+}
+
+func (t *txRecorder) Delete(ctx context.Context, id string) error {
+	*t.written = append(*t.written, id)
+	return t.UserRepository.Delete(ctx, id)
+}
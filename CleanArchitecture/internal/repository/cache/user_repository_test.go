@@ -0,0 +1,201 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/cache"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/repotest"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func TestUserRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		return cache.NewUserRepository(memory.NewUserRepository(), cache.Config{
+			Size:        16,
+			TTL:         time.Minute,
+			NegativeTTL: time.Minute,
+		})
+	})
+}
+
+// countingRepository - 원본 저장소 GetByID 호출 횟수를 세고, onGet이 있으면 읽은 직후 실행
+type countingRepository struct {
+	usecase.UserRepository
+	gets  int
+	onGet func()
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	r.gets++
+	user, err := r.UserRepository.GetByID(ctx, id)
+	if r.onGet != nil {
+		onGet := r.onGet
+		r.onGet = nil
+		onGet()
+	}
+	return user, err
+}
+
+var ctx = tenant.WithID(context.Background(), "acme")
+
+func newCachedUser(t *testing.T, cfg cache.Config) (*cache.UserRepository, *countingRepository, *domain.User) {
+	t.Helper()
+
+	next := &countingRepository{UserRepository: memory.NewUserRepository()}
+	repo := cache.NewUserRepository(next, cfg)
+	user := &domain.User{ID: "user-1", TenantID: "acme", Email: "kim@example.com", Name: "Kim",
+		CreatedAt: time.Unix(0, 0), UpdatedAt: time.Unix(0, 0)}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return repo, next, user
+}
+
+// getName - GetByID 결과의 이름 (에러면 즉시 중단)
+func getName(t *testing.T, repo *cache.UserRepository, id string) string {
+	t.Helper()
+
+	user, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	return user.Name
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	repo, next, user := newCachedUser(t, cache.Config{Size: 16, TTL: time.Minute})
+
+	getName(t, repo, user.ID)
+	getName(t, repo, user.ID)
+	if next.gets != 1 {
+		t.Fatalf("after two reads: %d fetches, want 1", next.gets)
+	}
+
+	user.Name = "Lee"
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := getName(t, repo, user.ID); got != "Lee" || next.gets != 2 {
+		t.Fatalf("after update: name %q, %d fetches, want Lee and 2", got, next.gets)
+	}
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, user.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("after delete: got %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestCacheSkipsResultInvalidatedDuringFetch(t *testing.T) {
+	repo, next, user := newCachedUser(t, cache.Config{Size: 16, TTL: time.Minute})
+
+	// 원본을 읽은 뒤 캐시에 넣기 전에 다른 요청이 수정 → 읽은 (이전) 값은 캐시하면 안 됨
+	next.onGet = func() {
+		updated := *user
+		updated.Name = "Lee"
+		if err := repo.Update(ctx, &updated); err != nil {
+			t.Errorf("Update: %v", err)
+		}
+	}
+	if got := getName(t, repo, user.ID); got != "Kim" {
+		t.Fatalf("racing read: name %q, want the value read before the update", got)
+	}
+
+	if got := getName(t, repo, user.ID); got != "Lee" {
+		t.Fatalf("next read: name %q, want Lee (stale result was cached)", got)
+	}
+	if next.gets != 2 {
+		t.Fatalf("%d fetches, want 2", next.gets)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	repo, next, user := newCachedUser(t, cache.Config{Size: 16, TTL: time.Minute, NegativeTTL: time.Second})
+	now := time.Now()
+	cache.SetClock(repo, func() time.Time { return now })
+
+	getName(t, repo, user.ID)
+	now = now.Add(59 * time.Second)
+	getName(t, repo, user.ID)
+	if next.gets != 1 {
+		t.Fatalf("before TTL: %d fetches, want 1", next.gets)
+	}
+	now = now.Add(time.Second)
+	getName(t, repo, user.ID)
+	if next.gets != 2 {
+		t.Fatalf("after TTL: %d fetches, want 2", next.gets)
+	}
+
+	// 없음 결과는 NegativeTTL 동안만 유지
+	for range 2 {
+		if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("missing: got %v, want ErrUserNotFound", err)
+		}
+	}
+	if next.gets != 3 {
+		t.Fatalf("negative entry: %d fetches, want 3", next.gets)
+	}
+	now = now.Add(time.Second)
+	repo.GetByID(ctx, "missing")
+	if next.gets != 4 {
+		t.Fatalf("after NegativeTTL: %d fetches, want 4", next.gets)
+	}
+}
+
+func TestCacheNegativeEntryClearedByCreate(t *testing.T) {
+	repo, _, _ := newCachedUser(t, cache.Config{Size: 16, TTL: time.Minute, NegativeTTL: time.Minute})
+
+	if _, err := repo.GetByID(ctx, "user-2"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("before create: got %v, want ErrUserNotFound", err)
+	}
+	user := &domain.User{ID: "user-2", TenantID: "acme", Email: "lee@example.com", Name: "Lee"}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := getName(t, repo, "user-2"); got != "Lee" {
+		t.Fatalf("after create: name %q, want Lee", got)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	repo, next, _ := newCachedUser(t, cache.Config{Size: 2, TTL: time.Minute})
+	for _, id := range []string{"user-2", "user-3"} {
+		user := &domain.User{ID: id, TenantID: "acme", Email: id + "@example.com", Name: id}
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	getName(t, repo, "user-1")
+	getName(t, repo, "user-2")
+	getName(t, repo, "user-1") // user-1이 최근 → user-3을 넣으면 user-2가 빠짐
+	getName(t, repo, "user-3")
+	if next.gets != 3 {
+		t.Fatalf("fill: %d fetches, want 3", next.gets)
+	}
+
+	getName(t, repo, "user-1")
+	if next.gets != 3 {
+		t.Fatalf("user-1 should still be cached: %d fetches", next.gets)
+	}
+	getName(t, repo, "user-2")
+	if next.gets != 4 {
+		t.Fatalf("user-2 should have been evicted: %d fetches", next.gets)
+	}
+}
+
+func TestCacheChecksTenantOnHit(t *testing.T) {
+	repo, _, user := newCachedUser(t, cache.Config{Size: 16, TTL: time.Minute})
+
+	getName(t, repo, user.ID)
+	if _, err := repo.GetByID(tenant.WithID(context.Background(), "globex"), user.ID); !errors.Is(err, domain.ErrCrossTenant) {
+		t.Fatalf("other tenant on cache hit: got %v, want ErrCrossTenant", err)
+	}
+}
@@ -0,0 +1,180 @@
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// metaSuffix - 블롭 옆에 두는 메타데이터 파일 접미사 (키 구성 요소로는 쓸 수 없음)
+const metaSuffix = ".meta"
+
+// keySegment - 키 구성 요소 규칙 ('.'으로 시작할 수 없으므로 ".."도 불가)
+var keySegment = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,127}$`)
+
+// BlobStore - 로컬 파일 시스템 블롭 저장소 (어댑터)
+// 키를 디렉터리 경로로 그대로 쓰고, 내용 옆에 <키>.meta(JSON)로 Content-Type/ETag를 보관
+// 임시 파일에 쓴 뒤 rename하므로 읽는 쪽은 이전 내용이나 새 내용 중 하나만 봄
+type BlobStore struct {
+	root string
+}
+
+// blobMeta - 메타데이터 파일 형식
+type blobMeta struct {
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	ETag        string `json:"etag"`
+}
+
+// NewBlobStore - BlobStore 생성자 (디렉터리가 없으면 만듦)
+func NewBlobStore(root string) (*BlobStore, error) {
+	root = filepath.Clean(root)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &BlobStore{root: root}, nil
+}
+
+// path - 키를 파일 경로로 변환 (루트 밖을 가리킬 수 없도록 구성 요소마다 검사)
+func (s *BlobStore) path(key string) (string, error) {
+	segments := strings.Split(key, "/")
+	for _, seg := range segments {
+		if !keySegment.MatchString(seg) || strings.HasSuffix(seg, metaSuffix) {
+			return "", domain.ErrInvalidBlobKey
+		}
+	}
+	return filepath.Join(append([]string{s.root}, segments...)...), nil
+}
+
+// Put - 블롭 저장 (있으면 교체)
+func (s *BlobStore) Put(ctx context.Context, key, contentType string, r io.Reader) (*domain.BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	size, err := writeAtomic(path, io.TeeReader(r, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := blobMeta{
+		ContentType: contentType,
+		Size:        size,
+		ETag:        `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`,
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writeAtomic(path+metaSuffix, strings.NewReader(string(data))); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return toBlobInfo(key, meta, info.ModTime()), nil
+}
+
+// Get - 블롭 열기 (반환된 *os.File은 io.Seeker도 구현하므로 Range 요청에 쓸 수 있음)
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, *domain.BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(path + metaSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var meta blobMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, toBlobInfo(key, meta, info.ModTime()), nil
+}
+
+// Delete - 블롭 삭제 (없으면 성공), 비게 된 상위 디렉터리도 정리
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	// 메타데이터를 먼저 지워 Get이 반쯤 지워진 블롭을 보지 않게 함
+	for _, p := range []string{path + metaSuffix, path} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	for dir := filepath.Dir(path); dir != s.root && strings.HasPrefix(dir, s.root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break // 비어 있지 않음
+		}
+	}
+	return nil
+}
+
+// writeAtomic - 같은 디렉터리의 임시 파일에 쓴 뒤 rename
+func writeAtomic(path string, r io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name()) // rename 후에는 없는 파일이므로 무시됨
+
+	n, err := io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, os.Rename(tmp.Name(), path)
+}
+
+func toBlobInfo(key string, meta blobMeta, modTime time.Time) *domain.BlobInfo {
+	return &domain.BlobInfo{
+		Key:         key,
+		ContentType: meta.ContentType,
+		Size:        meta.Size,
+		ETag:        meta.ETag,
+		ModTime:     modTime,
+	}
+}
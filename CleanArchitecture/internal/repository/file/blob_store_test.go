@@ -0,0 +1,111 @@
+package file_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+)
+
+func newBlobStore(t *testing.T) (*file.BlobStore, string) {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "blobs")
+	store, err := file.NewBlobStore(root)
+	if err != nil {
+		t.Fatalf("NewBlobStore: %v", err)
+	}
+	return store, root
+}
+
+func readBlob(t *testing.T, store *file.BlobStore, key string) (string, *domain.BlobInfo) {
+	t.Helper()
+	rc, info, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", key, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return string(data), info
+}
+
+func TestBlobStorePutGet(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newBlobStore(t)
+	key := "avatars/acme/u1/original"
+
+	put, err := store.Put(ctx, key, "image/png", strings.NewReader("first"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if put.Size != 5 || put.ContentType != "image/png" || put.ETag == "" {
+		t.Fatalf("Put info = %+v", put)
+	}
+
+	data, info := readBlob(t, store, key)
+	if data != "first" || info.ETag != put.ETag || info.ContentType != "image/png" || info.Size != 5 {
+		t.Fatalf("Get = %q %+v", data, info)
+	}
+
+	// 교체하면 내용과 ETag가 바뀜
+	if _, err := store.Put(ctx, key, "image/jpeg", strings.NewReader("second!")); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+	data, info = readBlob(t, store, key)
+	if data != "second!" || info.ETag == put.ETag || info.ContentType != "image/jpeg" {
+		t.Fatalf("Get after replace = %q %+v", data, info)
+	}
+}
+
+func TestBlobStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	store, root := newBlobStore(t)
+	for _, key := range []string{"avatars/acme/u1/original", "avatars/acme/u1/thumbnail", "avatars/acme/u2/original"} {
+		if _, err := store.Put(ctx, key, "image/png", strings.NewReader(key)); err != nil {
+			t.Fatalf("Put(%s): %v", key, err)
+		}
+	}
+
+	for _, key := range []string{"avatars/acme/u1/original", "avatars/acme/u1/thumbnail"} {
+		if err := store.Delete(ctx, key); err != nil {
+			t.Fatalf("Delete(%s): %v", key, err)
+		}
+		if _, _, err := store.Get(ctx, key); !errors.Is(err, domain.ErrBlobNotFound) {
+			t.Fatalf("Get after Delete = %v, want ErrBlobNotFound", err)
+		}
+	}
+
+	// 비게 된 디렉터리는 정리하고, 다른 블롭이 있는 디렉터리는 남김
+	if _, err := os.Stat(filepath.Join(root, "avatars", "acme", "u1")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("empty directory not removed: %v", err)
+	}
+	if data, _ := readBlob(t, store, "avatars/acme/u2/original"); data != "avatars/acme/u2/original" {
+		t.Fatalf("sibling blob = %q", data)
+	}
+
+	if err := store.Delete(ctx, "avatars/acme/u1/original"); err != nil {
+		t.Fatalf("Delete missing = %v, want nil", err)
+	}
+}
+
+func TestBlobStoreInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newBlobStore(t)
+
+	for _, key := range []string{"", "../escape", "a/../../b", "a//b", "/abs", "a/.hidden", "a/b.meta", "a\\b"} {
+		if _, err := store.Put(ctx, key, "text/plain", strings.NewReader("x")); !errors.Is(err, domain.ErrInvalidBlobKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidBlobKey", key, err)
+		}
+		if _, _, err := store.Get(ctx, key); !errors.Is(err, domain.ErrInvalidBlobKey) {
+			t.Errorf("Get(%q) = %v, want ErrInvalidBlobKey", key, err)
+		}
+	}
+}
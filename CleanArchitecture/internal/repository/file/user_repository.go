@@ -0,0 +1,393 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// UserRepository - JSON 파일 기반 리포지토리 구현 (어댑터)
+// 변경할 때마다 전체 사용자를 파일에 다시 기록 (CLI 등 단일 프로세스용)
+// 모든 조회/변경은 컨텍스트의 테넌트로 범위가 제한됨
+// WithCipher를 쓰면 파일에는 이메일/이름 암호문만 남고, 메모리에서는 평문으로 다룸
+type UserRepository struct {
+	mu     sync.RWMutex
+	path   string
+	users  map[string]*domain.User
+	cipher *fieldcrypt.Cipher // nil이면 평문 저장
+	staged bool               // InTransaction이 넘긴 사본이면 변경마다 저장하지 않음
+}
+
+// Option - 선택적 저장소 설정
+type Option func(*UserRepository)
+
+// WithCipher - 이메일/이름을 암호화해 저장
+// 평문으로 저장된 기존 파일도 읽을 수 있으며, 다음 변경 때 전체가 현재 키로 다시 암호화됨 (키 교체도 같음)
+func WithCipher(c *fieldcrypt.Cipher) Option {
+	return func(r *UserRepository) {
+		r.cipher = c
+	}
+}
+
+// userRecord - 파일 저장 형식 (암호화하면 Email/Name 대신 *Enc 필드)
+type userRecord struct {
+	ID         string    `json:"id"`
+	TenantID   string    `json:"tenant_id"`
+	Email      string    `json:"email,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	EmailEnc   string    `json:"email_enc,omitempty"`
+	EmailIndex string    `json:"email_index,omitempty"`
+	NameEnc    string    `json:"name_enc,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Attributes domain.Attributes `json:"attributes,omitempty"`
+
+	Status        domain.UserStatus    `json:"status,omitempty"`
+	StatusHistory []statusChangeRecord `json:"status_history,omitempty"`
+}
+
+// statusChangeRecord - 상태 변경 기록 저장 형식
+type statusChangeRecord struct {
+	From   domain.UserStatus `json:"from"`
+	To     domain.UserStatus `json:"to"`
+	Reason string            `json:"reason,omitempty"`
+	Actor  string            `json:"actor"`
+	At     time.Time         `json:"at"`
+}
+
+func toStatusRecords(history []domain.StatusChange) []statusChangeRecord {
+	if len(history) == 0 {
+		return nil
+	}
+	records := make([]statusChangeRecord, len(history))
+	for i, c := range history {
+		records[i] = statusChangeRecord(c)
+	}
+	return records
+}
+
+func toStatusHistory(records []statusChangeRecord) []domain.StatusChange {
+	if len(records) == 0 {
+		return nil
+	}
+	history := make([]domain.StatusChange, len(records))
+	for i, rec := range records {
+		history[i] = domain.StatusChange(rec)
+	}
+	return history
+}
+
+// NewUserRepository - UserRepository 생성자 (파일이 없으면 빈 저장소)
+func NewUserRepository(path string, opts ...Option) (*UserRepository, error) {
+	r := &UserRepository{
+		path:  path,
+		users: make(map[string]*domain.User),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []userRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		// 테넌트 도입 전 파일은 기본 테넌트로 간주
+		if rec.TenantID == "" {
+			rec.TenantID = tenant.Default
+		}
+		user := &domain.User{
+			ID:        rec.ID,
+			TenantID:  rec.TenantID,
+			Email:     rec.Email,
+			Name:      rec.Name,
+			CreatedAt: rec.CreatedAt,
+			UpdatedAt: rec.UpdatedAt,
+
+			Attributes: rec.Attributes,
+
+			Status:        rec.Status,
+			StatusHistory: toStatusHistory(rec.StatusHistory),
+		}
+		if rec.EmailEnc != "" {
+			if r.cipher == nil {
+				return nil, fmt.Errorf("user %s: %w", rec.ID, fieldcrypt.ErrCipherRequired)
+			}
+			sealed := fieldcrypt.SealedUser{Email: rec.EmailEnc, EmailIndex: rec.EmailIndex, Name: rec.NameEnc}
+			if _, err := r.cipher.OpenUser(user, sealed); err != nil {
+				return nil, fmt.Errorf("user %s: %w", rec.ID, err)
+			}
+		}
+		r.users[rec.ID] = user
+	}
+
+	return r, nil
+}
+
+// record - 사용자를 파일 저장 형식으로 변환 (Cipher가 있으면 이메일/이름 암호화)
+func (r *UserRepository) record(user *domain.User) (userRecord, error) {
+	rec := userRecord{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+
+		Attributes: user.Attributes,
+
+		Status:        user.Status,
+		StatusHistory: toStatusRecords(user.StatusHistory),
+	}
+	if r.cipher != nil {
+		sealed, err := r.cipher.SealUser(user)
+		if err != nil {
+			return userRecord{}, err
+		}
+		rec.Email, rec.Name = "", ""
+		rec.EmailEnc, rec.EmailIndex, rec.NameEnc = sealed.Email, sealed.EmailIndex, sealed.Name
+	}
+	return rec, nil
+}
+
+// save - 임시 파일에 쓴 뒤 rename (원자적 교체)
+// 매번 전체를 다시 쓰므로 암호화하면 모든 사용자가 현재 Primary 키로 다시 암호화됨
+// 호출자가 쓰기 잠금을 보유해야 함
+func (r *UserRepository) save() error {
+	if r.staged {
+		return nil
+	}
+	records := make([]userRecord, 0, len(r.users))
+	for _, user := range r.users {
+		rec, err := r.record(user)
+		if err != nil {
+			return err
+		}
+		records = append(records, rec)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
+
+// emailTaken - 같은 테넌트의 다른 사용자가 이미 이 이메일을 쓰는지 (호출자가 잠금 보유)
+func (r *UserRepository) emailTaken(user *domain.User) bool {
+	for id, other := range r.users {
+		if id != user.ID && other.TenantID == user.TenantID && other.Email == user.Email {
+			return true
+		}
+	}
+	return false
+}
+
+// Create - 사용자 생성
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.users[user.ID]; exists {
+		return domain.ErrUserExists
+	}
+	if r.emailTaken(user) {
+		return domain.ErrUserExists
+	}
+
+	userCopy := user.Clone()
+	r.users[user.ID] = userCopy
+
+	if err := r.save(); err != nil {
+		delete(r.users, user.ID)
+		return err
+	}
+	return nil
+}
+
+// GetByID - ID로 사용자 조회
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := tenant.Check(ctx, user); err != nil {
+		return nil, err
+	}
+
+	userCopy := user.Clone()
+	return userCopy, nil
+}
+
+// GetByEmail - 이메일로 사용자 조회
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.TenantID == tenantID && user.Email == email {
+			userCopy := user.Clone()
+			return userCopy, nil
+		}
+	}
+
+	return nil, domain.ErrUserNotFound
+}
+
+// GetAll - 테넌트의 모든 사용자 조회
+func (r *UserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*domain.User, 0)
+	for _, user := range r.users {
+		if user.TenantID != tenantID {
+			continue
+		}
+		userCopy := user.Clone()
+		users = append(users, userCopy)
+	}
+
+	return users, nil
+}
+
+// Update - 사용자 정보 수정
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prev, exists := r.users[user.ID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	if prev.TenantID != user.TenantID {
+		return domain.ErrCrossTenant
+	}
+	if r.emailTaken(user) {
+		return domain.ErrUserExists
+	}
+
+	userCopy := user.Clone()
+	r.users[user.ID] = userCopy
+
+	if err := r.save(); err != nil {
+		r.users[user.ID] = prev
+		return err
+	}
+	return nil
+}
+
+// Delete - 사용자 삭제
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if _, err := tenant.Require(ctx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prev, exists := r.users[id]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	if err := tenant.Check(ctx, prev); err != nil {
+		return err
+	}
+
+	delete(r.users, id)
+
+	if err := r.save(); err != nil {
+		r.users[id] = prev
+		return err
+	}
+	return nil
+}
+
+// InTransaction - fn 안의 변경을 한 번에 저장 (usecase.UserTransactor 구현)
+// 사용자 맵의 사본을 tx로 넘기고, fn이 성공하면 사본으로 교체한 뒤 파일을 한 번만 다시 씀
+// fn이 실패하거나 저장에 실패하면 기존 상태를 유지
+// fn 안에서 이 저장소를 tx가 아닌 경로로 다시 호출하면 교착 상태가 됨
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(tx usecase.UserRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &UserRepository{
+		path:   r.path,
+		users:  make(map[string]*domain.User, len(r.users)),
+		cipher: r.cipher,
+		staged: true,
+	}
+	for id, user := range r.users {
+		tx.users[id] = user
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	prev := r.users
+	r.users = tx.users
+	if err := r.save(); err != nil {
+		r.users = prev
+		return err
+	}
+	return nil
+}
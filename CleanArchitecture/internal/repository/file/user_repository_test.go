@@ -0,0 +1,132 @@
+package file_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/file"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/repotest"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func TestUserRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		repo, err := file.NewUserRepository(filepath.Join(t.TempDir(), "users.json"))
+		if err != nil {
+			t.Fatalf("NewUserRepository: %v", err)
+		}
+		return repo
+	})
+}
+
+func TestEncryptedUserRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		dir := t.TempDir()
+		keys, err := fieldcrypt.OpenFileKeyring(filepath.Join(dir, "keyring.json"))
+		if err != nil {
+			t.Fatalf("OpenFileKeyring: %v", err)
+		}
+		repo, err := file.NewUserRepository(filepath.Join(dir, "users.json"), file.WithCipher(fieldcrypt.New(keys)))
+		if err != nil {
+			t.Fatalf("NewUserRepository: %v", err)
+		}
+		return repo
+	})
+}
+
+// 파일에는 이메일/이름 암호문만 남고, 키를 교체하면 다음 변경 때 모든 레코드가 새 키로 다시 암호화됨
+func TestUserRepositoryEncryptionAtRest(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.json")
+	keys, err := fieldcrypt.OpenFileKeyring(filepath.Join(dir, "keyring.json"))
+	if err != nil {
+		t.Fatalf("OpenFileKeyring: %v", err)
+	}
+	oldKey, _ := keys.Primary()
+
+	repo, err := file.NewUserRepository(path, file.WithCipher(fieldcrypt.New(keys)))
+	if err != nil {
+		t.Fatalf("NewUserRepository: %v", err)
+	}
+	alice := &domain.User{ID: "u1", TenantID: tenant.Default, Email: "alice@example.com", Name: "Alice"}
+	if err := repo.Create(ctx, alice); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte("alice")) || bytes.Contains(data, []byte("Alice")) {
+		t.Fatalf("plaintext PII in file:\n%s", data)
+	}
+
+	if _, err := file.NewUserRepository(path); !errors.Is(err, fieldcrypt.ErrCipherRequired) {
+		t.Fatalf("reopen without keyring: got %v, want ErrCipherRequired", err)
+	}
+
+	if _, err := keys.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	reopened, err := file.NewUserRepository(path, file.WithCipher(fieldcrypt.New(keys)))
+	if err != nil {
+		t.Fatalf("NewUserRepository(reopen): %v", err)
+	}
+	got, err := reopened.GetByEmail(ctx, "alice@example.com")
+	if err != nil || got.ID != "u1" || got.Name != "Alice" {
+		t.Fatalf("GetByEmail after rotation = %+v, %v", got, err)
+	}
+
+	bob := &domain.User{ID: "u2", TenantID: tenant.Default, Email: "bob@example.com", Name: "Bob"}
+	if err := reopened.Create(ctx, bob); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "v1."+oldKey.ID+".") {
+		t.Fatalf("records still sealed with rotated-out key %s:\n%s", oldKey.ID, data)
+	}
+}
+
+// 상태와 변경 기록은 파일을 다시 열어도 유지됨
+func TestUserRepositoryPersistsStatus(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo, err := file.NewUserRepository(path)
+	if err != nil {
+		t.Fatalf("NewUserRepository: %v", err)
+	}
+
+	user, err := domain.NewUser("a@example.com", "A")
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+	user.ID, user.TenantID = "u1", tenant.Default
+	if err := user.ChangeStatus(domain.UserSuspended, "spam", "admin"); err != nil {
+		t.Fatalf("ChangeStatus: %v", err)
+	}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	reopened, err := file.NewUserRepository(path)
+	if err != nil {
+		t.Fatalf("NewUserRepository(reopen): %v", err)
+	}
+	got, err := reopened.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != domain.UserSuspended || len(got.StatusHistory) != 1 {
+		t.Fatalf("status: got %s %+v", got.Status, got.StatusHistory)
+	}
+	c := got.StatusHistory[0]
+	if c.From != domain.UserActive || c.To != domain.UserSuspended || c.Reason != "spam" || c.Actor != "admin" ||
+		!c.At.Equal(user.StatusHistory[0].At) {
+		t.Fatalf("history: got %+v, want %+v", c, user.StatusHistory[0])
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// AttributeSchemaRepository - 메모리 기반 속성 스키마 저장소 (어댑터)
+type AttributeSchemaRepository struct {
+	mu      sync.RWMutex
+	schemas map[string]*domain.AttributeSchema
+}
+
+// NewAttributeSchemaRepository - AttributeSchemaRepository 생성자
+func NewAttributeSchemaRepository() *AttributeSchemaRepository {
+	return &AttributeSchemaRepository{
+		schemas: make(map[string]*domain.AttributeSchema),
+	}
+}
+
+// GetSchema - 네임스페이스 스키마 조회
+func (r *AttributeSchemaRepository) GetSchema(ctx context.Context, namespace string) (*domain.AttributeSchema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, exists := r.schemas[namespace]
+	if !exists {
+		return nil, domain.ErrSchemaNotFound
+	}
+	return copySchema(schema), nil
+}
+
+// ListSchemas - 모든 스키마 조회
+func (r *AttributeSchemaRepository) ListSchemas(ctx context.Context) ([]*domain.AttributeSchema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas := make([]*domain.AttributeSchema, 0, len(r.schemas))
+	for _, schema := range r.schemas {
+		schemas = append(schemas, copySchema(schema))
+	}
+	return schemas, nil
+}
+
+// PutSchema - 스키마 등록 또는 교체
+func (r *AttributeSchemaRepository) PutSchema(ctx context.Context, schema *domain.AttributeSchema) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schemas[schema.Namespace] = copySchema(schema)
+	return nil
+}
+
+// DeleteSchema - 스키마 삭제
+func (r *AttributeSchemaRepository) DeleteSchema(ctx context.Context, namespace string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schemas[namespace]; !exists {
+		return domain.ErrSchemaNotFound
+	}
+	delete(r.schemas, namespace)
+	return nil
+}
+
+func copySchema(schema *domain.AttributeSchema) *domain.AttributeSchema {
+	c := *schema
+	c.Schema = append([]byte(nil), schema.Schema...)
+	return &c
+}
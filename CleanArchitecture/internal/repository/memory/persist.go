@@ -0,0 +1,674 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// SyncPolicy - WAL fsync 정책 (내구성 ↔ 처리량)
+type SyncPolicy int
+
+const (
+	// SyncAlways - 기록마다 fsync (전원 장애에도 유실 없음, 가장 느림)
+	SyncAlways SyncPolicy = iota
+	// SyncInterval - SyncEvery 주기로 fsync (장애 시 마지막 주기 분량 유실 가능)
+	SyncInterval
+	// SyncNever - OS 페이지 캐시에 맡김 (프로세스 장애는 견디지만 전원 장애 시 유실 가능)
+	SyncNever
+)
+
+// PersistConfig - 영속 모드 설정
+type PersistConfig struct {
+	Dir          string        // WAL과 스냅샷을 둘 디렉터리
+	Sync         SyncPolicy    // fsync 정책
+	SyncEvery    time.Duration // SyncInterval 주기 (기본 1초)
+	CompactAfter int           // WAL 레코드가 이만큼 쌓이면 스냅샷으로 압축 (기본 10000, 음수면 자동 압축 안 함)
+	// Cipher - 이메일/이름을 암호화해 기록 (nil이면 평문)
+	// 복구 중 평문이나 이전 키로 암호화된 레코드가 있으면 다음 쓰기 때 압축해 현재 키로 다시 씀
+	Cipher *fieldcrypt.Cipher
+}
+
+var (
+	// ErrCorruptLog - 마지막 레코드가 아닌 위치의 손상 (체크섬 불일치, 길이 필드 손상 등, 자동 복구 불가)
+	ErrCorruptLog = errors.New("memory: corrupt write-ahead log")
+	// ErrClosed - Close 이후 쓰기 시도
+	ErrClosed = errors.New("memory: repository closed")
+)
+
+const (
+	walFileName      = "users.wal"
+	snapshotFileName = "users.snapshot"
+
+	// 레코드 형식: [길이 uint32 LE][CRC-32C uint32 LE][JSON 본문]
+	recordHeaderSize = 8
+	maxRecordSize    = 16 << 20
+
+	opPut    = "put"
+	opDelete = "delete"
+	opBatch  = "batch" // 트랜잭션: Batch의 레코드를 모두 반영하거나 (잘리면) 모두 버림
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// logRecord - WAL/스냅샷 레코드 (생성과 수정은 모두 put)
+type logRecord struct {
+	Op    string      `json:"op"`
+	User  *userRecord `json:"user,omitempty"`
+	ID    string      `json:"id,omitempty"`
+	Batch []logRecord `json:"batch,omitempty"`
+}
+
+// userRecord - 파일 저장 형식 (암호화하면 Email/Name 대신 *Enc 필드)
+type userRecord struct {
+	ID         string    `json:"id"`
+	TenantID   string    `json:"tenant_id"`
+	Email      string    `json:"email,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	EmailEnc   string    `json:"email_enc,omitempty"`
+	EmailIndex string    `json:"email_index,omitempty"`
+	NameEnc    string    `json:"name_enc,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Attributes domain.Attributes `json:"attributes,omitempty"`
+
+	Status        domain.UserStatus    `json:"status,omitempty"`
+	StatusHistory []statusChangeRecord `json:"status_history,omitempty"`
+}
+
+// statusChangeRecord - 상태 변경 기록 저장 형식
+type statusChangeRecord struct {
+	From   domain.UserStatus `json:"from"`
+	To     domain.UserStatus `json:"to"`
+	Reason string            `json:"reason,omitempty"`
+	Actor  string            `json:"actor"`
+	At     time.Time         `json:"at"`
+}
+
+func toStatusRecords(history []domain.StatusChange) []statusChangeRecord {
+	if len(history) == 0 {
+		return nil
+	}
+	records := make([]statusChangeRecord, len(history))
+	for i, c := range history {
+		records[i] = statusChangeRecord(c)
+	}
+	return records
+}
+
+func toStatusHistory(records []statusChangeRecord) []domain.StatusChange {
+	if len(records) == 0 {
+		return nil
+	}
+	history := make([]domain.StatusChange, len(records))
+	for i, rec := range records {
+		history[i] = domain.StatusChange(rec)
+	}
+	return history
+}
+
+func toRecord(user *domain.User) *userRecord {
+	return &userRecord{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+
+		Attributes: user.Attributes,
+
+		Status:        user.Status,
+		StatusHistory: toStatusRecords(user.StatusHistory),
+	}
+}
+
+func (rec *userRecord) toDomain() *domain.User {
+	return &domain.User{
+		ID:        rec.ID,
+		TenantID:  rec.TenantID,
+		Email:     rec.Email,
+		Name:      rec.Name,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+
+		Attributes: rec.Attributes,
+
+		Status:        rec.Status,
+		StatusHistory: toStatusHistory(rec.StatusHistory),
+	}
+}
+
+// seal - 이메일/이름을 암호화한 사본 (c가 nil이면 그대로)
+func (rec *userRecord) seal(c *fieldcrypt.Cipher) (*userRecord, error) {
+	if c == nil {
+		return rec, nil
+	}
+	sealed, err := c.SealUser(rec.toDomain())
+	if err != nil {
+		return nil, err
+	}
+	out := *rec
+	out.Email, out.Name = "", ""
+	out.EmailEnc, out.EmailIndex, out.NameEnc = sealed.Email, sealed.EmailIndex, sealed.Name
+	return &out, nil
+}
+
+// open - 암호화된 이메일/이름을 풀어 채움
+// stale은 평문이거나 이전 키로 암호화되어 다시 써야 하는지 (c가 nil이면 항상 false)
+func (rec *userRecord) open(c *fieldcrypt.Cipher) (stale bool, err error) {
+	if rec.EmailEnc == "" {
+		return c != nil, nil
+	}
+	if c == nil {
+		return false, fmt.Errorf("user %s: %w", rec.ID, fieldcrypt.ErrCipherRequired)
+	}
+	user := &domain.User{ID: rec.ID, TenantID: rec.TenantID}
+	if stale, err = c.OpenUser(user, fieldcrypt.SealedUser{Email: rec.EmailEnc, EmailIndex: rec.EmailIndex, Name: rec.NameEnc}); err != nil {
+		return false, fmt.Errorf("user %s: %w", rec.ID, err)
+	}
+	rec.Email, rec.Name = user.Email, user.Name
+	rec.EmailEnc, rec.EmailIndex, rec.NameEnc = "", "", ""
+	return stale, nil
+}
+
+// seal - 레코드(배치면 안의 레코드까지)의 이메일/이름을 암호화한 사본 (c가 nil이면 그대로)
+func (rec logRecord) seal(c *fieldcrypt.Cipher) (logRecord, error) {
+	if rec.User != nil {
+		user, err := rec.User.seal(c)
+		if err != nil {
+			return logRecord{}, err
+		}
+		rec.User = user
+	}
+	if len(rec.Batch) > 0 {
+		batch := make([]logRecord, len(rec.Batch))
+		for i, sub := range rec.Batch {
+			sealed, err := sub.seal(c)
+			if err != nil {
+				return logRecord{}, err
+			}
+			batch[i] = sealed
+		}
+		rec.Batch = batch
+	}
+	return rec, nil
+}
+
+// wal - 쓰기 전 로그 (호출자가 리포지토리 쓰기 잠금 보유)
+// mu는 주기적 fsync 고루틴과 파일 핸들을 공유하기 위한 것
+type wal struct {
+	cfg     PersistConfig
+	mu      sync.Mutex
+	f       *os.File
+	records int  // 마지막 스냅샷 이후 레코드 수
+	stale   bool // 평문이나 이전 키로 암호화된 레코드가 남아 있음 (다음 쓰기 때 압축)
+	buf     []byte
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// OpenUserRepository - 영속 모드 리포지토리 생성
+// 스냅샷을 읽고 WAL을 재생해 복구하며, 마지막 레코드가 잘려 있으면 그 앞까지만 복구
+func OpenUserRepository(cfg PersistConfig) (*UserRepository, error) {
+	if cfg.SyncEvery <= 0 {
+		cfg.SyncEvery = time.Second
+	}
+	if cfg.CompactAfter == 0 {
+		cfg.CompactAfter = 10000
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	r := NewUserRepository()
+
+	// 복구하면서 레코드를 평문으로 풀고, 다시 암호화해야 할 레코드가 있는지 기록
+	stale := false
+	var open func(rec logRecord) error
+	open = func(rec logRecord) error {
+		if rec.User != nil {
+			s, err := rec.User.open(cfg.Cipher)
+			if err != nil {
+				return err
+			}
+			stale = stale || s
+		}
+		for _, sub := range rec.Batch {
+			if err := open(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	apply := func(rec logRecord) error {
+		if err := open(rec); err != nil {
+			return err
+		}
+		return r.apply(rec)
+	}
+
+	if err := r.loadSnapshot(filepath.Join(cfg.Dir, snapshotFileName), apply); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(cfg.Dir, walFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	records, err := r.replay(f, apply)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	w := &wal{cfg: cfg, f: f, records: records, stale: stale}
+	if cfg.Sync == SyncInterval {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.syncLoop()
+	}
+	r.wal = w
+
+	return r, nil
+}
+
+// loadSnapshot - 스냅샷 적용 (원자적으로 교체되므로 손상은 곧 오류)
+func (r *UserRepository) loadSnapshot(path string, apply func(logRecord) error) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	_, torn, err := readRecords(f, info.Size(), apply)
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	if torn {
+		return fmt.Errorf("snapshot %s: %w", path, ErrCorruptLog)
+	}
+	return nil
+}
+
+// replay - WAL 재생, 잘린 마지막 레코드는 잘라내고 계속 사용
+func (r *UserRepository) replay(f *os.File, apply func(logRecord) error) (int, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	records := 0
+	valid, torn, err := readRecords(f, info.Size(), func(rec logRecord) error {
+		records++
+		return apply(rec)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("wal %s: %w", f.Name(), err)
+	}
+
+	if torn {
+		log.Printf("WAL 마지막 레코드 손상, %d바이트 이후 폐기: %s", valid, f.Name())
+		if err := f.Truncate(valid); err != nil {
+			return 0, err
+		}
+		if err := f.Sync(); err != nil {
+			return 0, err
+		}
+	}
+	return records, nil
+}
+
+// apply - 레코드를 메모리 상태에 반영 (호출자가 쓰기 잠금 보유 또는 초기화 중)
+func (r *UserRepository) apply(rec logRecord) error {
+	switch rec.Op {
+	case opPut:
+		if rec.User == nil {
+			return ErrCorruptLog
+		}
+		// 테넌트 도입 전 레코드는 기본 테넌트로 간주
+		if rec.User.TenantID == "" {
+			rec.User.TenantID = tenant.Default
+		}
+		r.put(rec.User.toDomain())
+	case opDelete:
+		r.remove(rec.ID)
+	case opBatch:
+		for _, sub := range rec.Batch {
+			if sub.Op == opBatch {
+				return fmt.Errorf("%w: nested batch", ErrCorruptLog)
+			}
+			if err := r.apply(sub); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrCorruptLog, rec.Op)
+	}
+	return nil
+}
+
+// readRecords - 레코드를 순서대로 읽어 fn에 전달
+// 반환값: 마지막 정상 레코드 끝 위치, 꼬리 손상 여부
+// 파일 끝에 걸친 레코드만 잘린 것으로 보고, 중간 손상 (길이 필드 손상 포함)은 ErrCorruptLog
+func readRecords(src io.Reader, size int64, fn func(logRecord) error) (int64, bool, error) {
+	br := bufio.NewReader(src)
+	header := make([]byte, recordHeaderSize)
+	var offset int64
+
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return offset, false, nil
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return offset, true, nil
+			}
+			return offset, false, err
+		}
+
+		length := int64(binary.LittleEndian.Uint32(header[0:4]))
+		sum := binary.LittleEndian.Uint32(header[4:8])
+		if length == 0 {
+			// 정상 레코드는 본문이 비지 않음
+			// 크래시로 파일 크기만 늘고 내용이 0으로 남은 꼬리면 잘린 것으로 봄
+			zero, err := zeroTail(br, sum)
+			if err != nil {
+				return offset, false, err
+			}
+			if zero {
+				return offset, true, nil
+			}
+			return offset, false, fmt.Errorf("%w: empty record at offset %d", ErrCorruptLog, offset)
+		}
+		end := offset + recordHeaderSize + length
+		if length > maxRecordSize {
+			// 기록한 적 없는 길이: 헤더가 파일의 마지막이면 잘린 꼬리, 뒤에 데이터가 있으면 중간 손상
+			if offset+recordHeaderSize == size {
+				return offset, true, nil
+			}
+			return offset, false, fmt.Errorf("%w: bad length %d at offset %d", ErrCorruptLog, length, offset)
+		}
+		if end > size {
+			// 잘린 꼬리면 헤더 뒤에는 끝까지 쓰이지 못한 본문만 남음
+			// 체크섬이 맞는 온전한 본문이 남아 있으면 길이 필드가 손상된 것
+			rest, err := io.ReadAll(br)
+			if err != nil {
+				return offset, false, err
+			}
+			if completePayload(rest, sum) {
+				return offset, false, fmt.Errorf("%w: bad length %d at offset %d", ErrCorruptLog, length, offset)
+			}
+			return offset, true, nil
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return offset, false, err
+		}
+		if crc32.Checksum(payload, crcTable) != sum {
+			if end == size {
+				return offset, true, nil
+			}
+			return offset, false, fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorruptLog, offset)
+		}
+
+		var rec logRecord
+		if err := json.Unmarshal(payload, &rec); err != nil {
+			if end == size {
+				return offset, true, nil
+			}
+			return offset, false, fmt.Errorf("%w: %v", ErrCorruptLog, err)
+		}
+		if err := fn(rec); err != nil {
+			return offset, false, err
+		}
+		offset = end
+	}
+}
+
+// completePayload - data 앞부분이 체크섬 sum과 맞는 온전한 JSON 본문인지
+func completePayload(data []byte, sum uint32) bool {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return false
+	}
+	return crc32.Checksum(data[:dec.InputOffset()], crcTable) == sum
+}
+
+// zeroTail - 체크섬과 남은 내용이 모두 0인지 확인 (나머지를 끝까지 읽음)
+func zeroTail(br *bufio.Reader, sum uint32) (bool, error) {
+	if sum != 0 {
+		return false, nil
+	}
+	buf := make([]byte, 4096)
+	for {
+		n, err := br.Read(buf)
+		for _, b := range buf[:n] {
+			if b != 0 {
+				return false, nil
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// encodeRecord - 레코드를 프레임 형식으로 buf 뒤에 추가
+func encodeRecord(buf []byte, rec logRecord) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return buf, err
+	}
+
+	var header [recordHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.Checksum(payload, crcTable))
+	buf = append(buf, header[:]...)
+	return append(buf, payload...), nil
+}
+
+// append - 레코드 기록 (정책에 따라 fsync)
+func (w *wal) append(rec logRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return ErrClosed
+	}
+	rec, err := rec.seal(w.cfg.Cipher)
+	if err != nil {
+		return err
+	}
+
+	buf, err := encodeRecord(w.buf[:0], rec)
+	if err != nil {
+		return err
+	}
+	w.buf = buf
+
+	// 실패하면 이 위치로 잘라 쓰다 만 레코드를 지움
+	// (남겨 두면 다음 레코드가 그 뒤에 붙어 재시작 때 중간 손상이 됨)
+	offset, err := w.f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if err := w.write(buf); err != nil {
+		if terr := w.f.Truncate(offset); terr != nil {
+			// 되돌리지 못하면 손상된 레코드 뒤에 쓰지 않도록 WAL을 닫음 (이후 기록은 ErrClosed)
+			log.Printf("WAL 기록 실패 후 %d바이트로 되돌리지 못해 닫음: %v", offset, terr)
+			w.f.Close()
+			w.f = nil
+			return errors.Join(err, terr)
+		}
+		return err
+	}
+	w.records++
+	return nil
+}
+
+// write - 프레임 기록 (정책에 따라 fsync, 호출자가 잠금 보유)
+func (w *wal) write(buf []byte) error {
+	if _, err := w.f.Write(buf); err != nil {
+		return err
+	}
+	if w.cfg.Sync == SyncAlways {
+		return w.f.Sync()
+	}
+	return nil
+}
+
+// needsCompaction - 자동 압축 기준 도달 여부 (다시 암호화할 레코드가 있으면 항상)
+func (w *wal) needsCompaction() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.f != nil && (w.stale || w.cfg.CompactAfter > 0 && w.records >= w.cfg.CompactAfter)
+}
+
+// compact - 현재 상태를 스냅샷으로 쓰고 WAL 비우기
+// 스냅샷 교체 후 WAL을 비우기 전에 중단돼도 재생이 멱등이라 안전
+func (w *wal) compact(users map[string]*domain.User) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return ErrClosed
+	}
+
+	tmp, err := os.CreateTemp(w.cfg.Dir, snapshotFileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	bw := bufio.NewWriter(tmp)
+	var buf []byte
+	for _, user := range users {
+		rec, err := toRecord(user).seal(w.cfg.Cipher)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		buf, err = encodeRecord(buf[:0], logRecord{Op: opPut, User: rec})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		if _, err := bw.Write(buf); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(w.cfg.Dir, snapshotFileName)); err != nil {
+		return err
+	}
+	if err := syncDir(w.cfg.Dir); err != nil {
+		return err
+	}
+
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	if err := w.f.Sync(); err != nil {
+		return err
+	}
+	w.records = 0
+	w.stale = false
+	return nil
+}
+
+// syncLoop - SyncInterval 정책의 주기적 fsync
+func (w *wal) syncLoop() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.cfg.SyncEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.f != nil {
+				if err := w.f.Sync(); err != nil {
+					log.Printf("WAL fsync 실패: %v", err)
+				}
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// close - 주기적 fsync 중지, 남은 기록 fsync 후 닫기
+func (w *wal) close() error {
+	if w.stop != nil {
+		close(w.stop)
+		<-w.done
+		w.stop = nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Sync()
+	if cerr := w.f.Close(); err == nil {
+		err = cerr
+	}
+	w.f = nil
+	return err
+}
+
+// syncDir - rename 결과를 디렉터리 항목까지 영속화
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
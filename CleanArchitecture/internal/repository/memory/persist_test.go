@@ -0,0 +1,419 @@
+package memory_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/repotest"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func openRepo(t *testing.T, cfg memory.PersistConfig) *memory.UserRepository {
+	t.Helper()
+
+	repo, err := memory.OpenUserRepository(cfg)
+	if err != nil {
+		t.Fatalf("OpenUserRepository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func testUser(id string) *domain.User {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &domain.User{ID: id, TenantID: "acme", Email: id + "@example.com", Name: id, CreatedAt: now, UpdatedAt: now}
+}
+
+func TestPersistentUserRepository(t *testing.T) {
+	policies := map[string]memory.SyncPolicy{
+		"SyncAlways":   memory.SyncAlways,
+		"SyncInterval": memory.SyncInterval,
+		"SyncNever":    memory.SyncNever,
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+				return openRepo(t, memory.PersistConfig{
+					Dir:          t.TempDir(),
+					Sync:         policy,
+					SyncEvery:    10 * time.Millisecond,
+					CompactAfter: 7,
+				})
+			})
+		})
+	}
+}
+
+func TestPersistentRecovery(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: 5}
+
+	repo := openRepo(t, cfg)
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		if err := repo.Create(ctx, testUser(id)); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	renamed := testUser("b")
+	renamed.Email = "renamed@example.com"
+	if err := repo.Update(ctx, renamed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.Delete(ctx, "c"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := repo.Create(ctx, testUser("z")); !errors.Is(err, memory.ErrClosed) {
+		t.Fatalf("Create after Close: got %v, want ErrClosed", err)
+	}
+
+	// 스냅샷(a~e) + WAL(f, g, b 수정, c 삭제)에서 복구
+	reopened := openRepo(t, cfg)
+	all, err := reopened.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 6 {
+		t.Fatalf("GetAll: got %d users, want 6", len(all))
+	}
+	if _, err := reopened.GetByID(ctx, "c"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("GetByID(c): got %v, want ErrUserNotFound", err)
+	}
+	got, err := reopened.GetByEmail(ctx, "renamed@example.com")
+	if err != nil || got.ID != "b" {
+		t.Fatalf("GetByEmail(renamed): got %v, %v", got, err)
+	}
+	if _, err := reopened.GetByEmail(ctx, "b@example.com"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("GetByEmail(old): got %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestPersistentTornTail(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+	repo := openRepo(t, cfg)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := repo.Create(ctx, testUser(id)); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	repo.Close()
+
+	// 마지막 레코드 기록 도중 중단된 상황 재현
+	walPath := filepath.Join(dir, "users.wal")
+	info, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if err := os.Truncate(walPath, info.Size()-3); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	reopened := openRepo(t, cfg)
+	all, err := reopened.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("GetAll: got %d users, want 2", len(all))
+	}
+
+	// 잘린 꼬리를 정리했으므로 이후 기록도 정상 복구
+	if err := reopened.Create(ctx, testUser("d")); err != nil {
+		t.Fatalf("Create(d): %v", err)
+	}
+	reopened.Close()
+
+	again := openRepo(t, cfg)
+	if _, err := again.GetByID(ctx, "d"); err != nil {
+		t.Fatalf("GetByID(d): %v", err)
+	}
+}
+
+// 크래시 후 남을 수 있는 WAL 꼬리: 0으로 채워진 영역, 체크섬은 맞지만 끝까지 쓰이지 않은 레코드
+func TestPersistentTornTailVariants(t *testing.T) {
+	frame := func(payload string) []byte {
+		buf := make([]byte, 8, 8+len(payload))
+		binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+		binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum([]byte(payload), crc32.MakeTable(crc32.Castagnoli)))
+		return append(buf, payload...)
+	}
+	tails := map[string][]byte{
+		"zero filled":      make([]byte, 4096),
+		"zero header only": make([]byte, 8),
+		"undecodable last": frame(`{"op":"put","user":{"id":`),
+	}
+
+	for name, tail := range tails {
+		t.Run(name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "acme")
+			dir := t.TempDir()
+			cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+			repo := openRepo(t, cfg)
+			for _, id := range []string{"a", "b"} {
+				if err := repo.Create(ctx, testUser(id)); err != nil {
+					t.Fatalf("Create(%s): %v", id, err)
+				}
+			}
+			repo.Close()
+
+			walPath := filepath.Join(dir, "users.wal")
+			info, err := os.Stat(walPath)
+			if err != nil {
+				t.Fatalf("Stat: %v", err)
+			}
+			f, err := os.OpenFile(walPath, os.O_WRONLY|os.O_APPEND, 0)
+			if err != nil {
+				t.Fatalf("OpenFile: %v", err)
+			}
+			if _, err := f.Write(tail); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			f.Close()
+
+			reopened := openRepo(t, cfg)
+			all, err := reopened.GetAll(ctx)
+			if err != nil {
+				t.Fatalf("GetAll: %v", err)
+			}
+			if len(all) != 2 {
+				t.Fatalf("GetAll: got %d users, want 2", len(all))
+			}
+			if after, err := os.Stat(walPath); err != nil || after.Size() != info.Size() {
+				t.Fatalf("WAL size after recovery: got %v, %v, want %d", after.Size(), err, info.Size())
+			}
+
+			if err := reopened.Create(ctx, testUser("c")); err != nil {
+				t.Fatalf("Create(c): %v", err)
+			}
+			reopened.Close()
+			if _, err := openRepo(t, cfg).GetByID(ctx, "c"); err != nil {
+				t.Fatalf("GetByID(c): %v", err)
+			}
+		})
+	}
+}
+
+// 길이 0 헤더 뒤에 데이터가 있으면 잘린 꼬리가 아니라 중간 손상
+func TestPersistentEmptyRecordMidLog(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+	repo := openRepo(t, cfg)
+	for _, id := range []string{"a", "b"} {
+		if err := repo.Create(ctx, testUser(id)); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	repo.Close()
+
+	walPath := filepath.Join(dir, "users.wal")
+	data, err := os.ReadFile(walPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// 첫 레코드의 헤더를 0으로 덮음
+	clear(data[:8])
+	if err := os.WriteFile(walPath, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := memory.OpenUserRepository(cfg); !errors.Is(err, memory.ErrCorruptLog) {
+		t.Fatalf("OpenUserRepository: got %v, want ErrCorruptLog", err)
+	}
+}
+
+// 중간 레코드의 길이 필드가 손상되면 잘린 꼬리로 보고 뒤 레코드를 버리지 않고 ErrCorruptLog
+func TestPersistentCorruptLengthMidLog(t *testing.T) {
+	lengths := map[string]uint32{
+		"beyond max size":  1 << 30,
+		"past end of file": 1 << 20,
+	}
+
+	for name, length := range lengths {
+		t.Run(name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "acme")
+			dir := t.TempDir()
+			cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+			repo := openRepo(t, cfg)
+			for _, id := range []string{"a", "b", "c"} {
+				if err := repo.Create(ctx, testUser(id)); err != nil {
+					t.Fatalf("Create(%s): %v", id, err)
+				}
+			}
+			repo.Close()
+
+			walPath := filepath.Join(dir, "users.wal")
+			data, err := os.ReadFile(walPath)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			// 두 번째 레코드의 길이 필드를 덮음
+			second := 8 + binary.LittleEndian.Uint32(data[0:4])
+			binary.LittleEndian.PutUint32(data[second:second+4], length)
+			if err := os.WriteFile(walPath, data, 0o644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+
+			if _, err := memory.OpenUserRepository(cfg); !errors.Is(err, memory.ErrCorruptLog) {
+				t.Fatalf("OpenUserRepository: got %v, want ErrCorruptLog", err)
+			}
+			// 복구 시도가 WAL을 잘라내면 안 됨
+			if after, err := os.Stat(walPath); err != nil || after.Size() != int64(len(data)) {
+				t.Fatalf("WAL size after open: got %v, %v, want %d", after.Size(), err, len(data))
+			}
+		})
+	}
+}
+
+// 트랜잭션은 WAL 레코드 하나로 기록되어, 잘리면 전부 버려지고 아니면 전부 복구됨
+func TestPersistentTransaction(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+	repo := openRepo(t, cfg)
+	if err := repo.Create(ctx, testUser("a")); err != nil {
+		t.Fatalf("Create(a): %v", err)
+	}
+	batch := func(ids ...string) error {
+		return repo.InTransaction(ctx, func(tx usecase.UserRepository) error {
+			for _, id := range ids {
+				if err := tx.Create(ctx, testUser(id)); err != nil {
+					return err
+				}
+			}
+			return tx.Delete(ctx, "a")
+		})
+	}
+	if err := batch("b", "c"); err != nil {
+		t.Fatalf("InTransaction: %v", err)
+	}
+	// 실패한 트랜잭션은 WAL에 남지 않음
+	if err := batch("d", "e"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("InTransaction(a already deleted): got %v, want ErrUserNotFound", err)
+	}
+	repo.Close()
+
+	reopened := openRepo(t, cfg)
+	all, err := reopened.GetAll(ctx)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("GetAll: got %d users, %v; want b, c", len(all), err)
+	}
+	if _, err := reopened.GetByID(ctx, "a"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("GetByID(a): got %v, want ErrUserNotFound", err)
+	}
+	reopened.Close()
+
+	// 트랜잭션 레코드 기록 도중 중단되면 그 안의 변경이 하나도 반영되지 않음
+	walPath := filepath.Join(dir, "users.wal")
+	info, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if err := os.Truncate(walPath, info.Size()-3); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	torn := openRepo(t, cfg)
+	if _, err := torn.GetByID(ctx, "a"); err != nil {
+		t.Fatalf("GetByID(a) after torn batch: %v", err)
+	}
+	if all, _ := torn.GetAll(ctx); len(all) != 1 {
+		t.Fatalf("GetAll after torn batch: got %d users, want 1", len(all))
+	}
+}
+
+func TestPersistentCorruptLog(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1}
+
+	repo := openRepo(t, cfg)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := repo.Create(ctx, testUser(id)); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	repo.Close()
+
+	// 첫 레코드 본문 1바이트 변조 (뒤에 정상 레코드가 있으므로 복구 불가)
+	walPath := filepath.Join(dir, "users.wal")
+	data, err := os.ReadFile(walPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data[10] ^= 0xff
+	if err := os.WriteFile(walPath, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := memory.OpenUserRepository(cfg); !errors.Is(err, memory.ErrCorruptLog) {
+		t.Fatalf("OpenUserRepository: got %v, want ErrCorruptLog", err)
+	}
+}
+
+// 암호화를 켜기 전에 평문으로 쓴 WAL은 그대로 읽히고, 다음 변경 때 압축되면서 전체가 암호화됨
+func TestPersistentEncryption(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	dir := t.TempDir()
+
+	plain := openRepo(t, memory.PersistConfig{Dir: dir, CompactAfter: -1})
+	for _, id := range []string{"alice", "bob"} {
+		if err := plain.Create(ctx, testUser(id)); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	plain.Close()
+
+	keys, err := fieldcrypt.OpenFileKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	if err != nil {
+		t.Fatalf("OpenFileKeyring: %v", err)
+	}
+	cfg := memory.PersistConfig{Dir: dir, CompactAfter: -1, Cipher: fieldcrypt.New(keys)}
+	repo := openRepo(t, cfg)
+	if got, err := repo.GetByEmail(ctx, "alice@example.com"); err != nil || got.ID != "alice" {
+		t.Fatalf("GetByEmail(plaintext record) = %v, %v", got, err)
+	}
+	if err := repo.Create(ctx, testUser("carol")); err != nil {
+		t.Fatalf("Create(carol): %v", err)
+	}
+	repo.Close()
+
+	for _, name := range []string{"users.wal", "users.snapshot"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("ReadFile(%s): %v", name, err)
+		}
+		if bytes.Contains(data, []byte("@example.com")) {
+			t.Fatalf("%s still holds plaintext emails", name)
+		}
+	}
+
+	if _, err := memory.OpenUserRepository(memory.PersistConfig{Dir: dir}); !errors.Is(err, fieldcrypt.ErrCipherRequired) {
+		t.Fatalf("reopen without keyring: got %v, want ErrCipherRequired", err)
+	}
+	reopened := openRepo(t, cfg)
+	all, err := reopened.GetAll(ctx)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("GetAll after reopen: got %d users, %v", len(all), err)
+	}
+}
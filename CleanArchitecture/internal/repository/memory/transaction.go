@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// ErrTxDone - InTransaction이 끝난 뒤 tx로 쓰기 시도
+var ErrTxDone = errors.New("memory: transaction already finished")
+
+// InTransaction - fn 안의 변경을 한 번에 반영 (usecase.UserTransactor 구현)
+// fn이 실행되는 동안 쓰기 잠금을 잡고 변경을 메모리에 바로 반영하되, 바뀐 사용자의 이전 상태를 보관해 두었다가
+// fn이 실패하면 되돌림. 성공하면 변경 전체를 WAL 레코드 하나(batch)로 기록하므로
+// 기록 도중 장애가 나도 복구 시 전부 반영되거나 전부 버려짐
+// fn 안에서 이 저장소를 tx가 아닌 경로로 다시 호출하면 교착 상태가 됨
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(tx usecase.UserRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &userTx{r: r, before: make(map[string]*domain.User)}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	tx.done = true
+	if len(tx.ops) > 0 {
+		if err := r.writeAhead(logRecord{Op: opBatch, Batch: tx.ops}); err != nil {
+			return err
+		}
+	}
+	committed = true
+	r.maybeCompact()
+	return nil
+}
+
+// userTx - 트랜잭션 안에서 쓰는 저장소 (InTransaction이 쓰기 잠금을 보유한 동안만 유효)
+type userTx struct {
+	r      *UserRepository
+	ops    []logRecord             // 커밋할 때 WAL에 기록할 변경
+	before map[string]*domain.User // 처음 바꾸기 전 상태 (nil이면 없던 사용자)
+	done   bool
+}
+
+// stage - 변경을 WAL 대신 모아 둠 (메모리 반영 전에 호출됨)
+func (tx *userTx) stage(rec logRecord) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	id := rec.ID
+	if rec.User != nil {
+		id = rec.User.ID
+	}
+	if _, seen := tx.before[id]; !seen {
+		tx.before[id] = tx.r.users[id]
+	}
+	tx.ops = append(tx.ops, rec)
+	return nil
+}
+
+// rollback - 바뀐 사용자를 이전 상태로 되돌림
+func (tx *userTx) rollback() {
+	for id, prev := range tx.before {
+		if prev == nil {
+			tx.r.remove(id)
+		} else {
+			tx.r.put(prev)
+		}
+	}
+	tx.done = true
+}
+
+func (tx *userTx) Create(ctx context.Context, user *domain.User) error {
+	return tx.r.create(ctx, user, tx.stage)
+}
+
+func (tx *userTx) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return tx.r.getByID(ctx, id)
+}
+
+func (tx *userTx) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return tx.r.getByEmail(ctx, email)
+}
+
+func (tx *userTx) GetAll(ctx context.Context) ([]*domain.User, error) {
+	return tx.r.getAll(ctx)
+}
+
+func (tx *userTx) Update(ctx context.Context, user *domain.User) error {
+	return tx.r.update(ctx, user, tx.stage)
+}
+
+func (tx *userTx) Delete(ctx context.Context, id string) error {
+	return tx.r.delete(ctx, id, tx.stage)
+}
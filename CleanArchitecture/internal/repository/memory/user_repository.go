@@ -2,22 +2,29 @@ package memory
 
 import (
 	"context"
+	"log"
 	"sync"
 
 	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
 )
 
 // UserRepository - 메모리 기반 리포지토리 구현 (어댑터)
 // 인터페이스를 구현하여 의존성 역전 원칙 적용
+// OpenUserRepository로 만들면 변경 내용을 WAL + 스냅샷으로 영속화
+// 모든 조회/변경은 컨텍스트의 테넌트로 범위가 제한됨
 type UserRepository struct {
-	mu    sync.RWMutex
-	users map[string]*domain.User
+	mu     sync.RWMutex
+	users  map[string]*domain.User
+	emails map[string]string // (테넌트, 이메일) → ID 인덱스
+	wal    *wal              // nil이면 순수 메모리 모드
 }
 
-// NewUserRepository - UserRepository 생성자
+// NewUserRepository - UserRepository 생성자 (순수 메모리 모드)
 func NewUserRepository() *UserRepository {
 	return &UserRepository{
-		users: make(map[string]*domain.User),
+		users:  make(map[string]*domain.User),
+		emails: make(map[string]string),
 	}
 }
 
@@ -26,14 +33,10 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.users[user.ID]; exists {
-		return domain.ErrUserExists
+	if err := r.create(ctx, user, r.writeAhead); err != nil {
+		return err
 	}
-
-	// 복사본 저장 (불변성 보장)
-	userCopy := *user
-	r.users[user.ID] = &userCopy
-
+	r.maybeCompact()
 	return nil
 }
 
@@ -42,14 +45,26 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	user, exists := r.users[id]
-	if !exists {
-		return nil, domain.ErrUserNotFound
+	return r.getByID(ctx, id)
+}
+
+// GetByIDs - 여러 ID를 한 번에 조회 (usecase.UserBatchGetter 구현, 없거나 다른 테넌트인 ID는 빠짐)
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// 복사본 반환 (불변성 보장)
-	userCopy := *user
-	return &userCopy, nil
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make(map[string]*domain.User, len(ids))
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok && user.TenantID == tenantID {
+			users[id] = user.Clone()
+		}
+	}
+	return users, nil
 }
 
 // GetByEmail - 이메일로 사용자 조회
@@ -57,25 +72,37 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	for _, user := range r.users {
-		if user.Email == email {
-			userCopy := *user
-			return &userCopy, nil
-		}
-	}
-
-	return nil, domain.ErrUserNotFound
+	return r.getByEmail(ctx, email)
 }
 
-// GetAll - 모든 사용자 조회
+// GetAll - 테넌트의 모든 사용자 조회
 func (r *UserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	users := make([]*domain.User, 0, len(r.users))
+	return r.getAll(ctx)
+}
+
+// FindByAttributes - 속성 조건을 모두 만족하는 테넌트 사용자 (usecase.UserAttributeQuerier 구현)
+func (r *UserRepository) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range filters {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*domain.User, 0)
 	for _, user := range r.users {
-		userCopy := *user
-		users = append(users, &userCopy)
+		if user.TenantID == tenantID && domain.MatchAttributes(user, filters) {
+			users = append(users, user.Clone())
+		}
 	}
 
 	return users, nil
@@ -86,13 +113,10 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.users[user.ID]; !exists {
-		return domain.ErrUserNotFound
+	if err := r.update(ctx, user, r.writeAhead); err != nil {
+		return err
 	}
-
-	userCopy := *user
-	r.users[user.ID] = &userCopy
-
+	r.maybeCompact()
 	return nil
 }
 
@@ -101,11 +125,236 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.users[id]; !exists {
+	if err := r.delete(ctx, id, r.writeAhead); err != nil {
+		return err
+	}
+	r.maybeCompact()
+	return nil
+}
+
+// create - 사용자 생성 (호출자가 쓰기 잠금 보유, 메모리 반영 전에 record로 기록)
+func (r *UserRepository) create(ctx context.Context, user *domain.User, record func(logRecord) error) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+	if _, exists := r.users[user.ID]; exists {
+		return domain.ErrUserExists
+	}
+	if r.emailTaken(user) {
+		return domain.ErrUserExists
+	}
+
+	if err := record(logRecord{Op: opPut, User: toRecord(user)}); err != nil {
+		return err
+	}
+
+	// 복사본 저장 (불변성 보장)
+	r.put(user.Clone())
+	return nil
+}
+
+// getByID - ID로 사용자 조회 (호출자가 잠금 보유)
+func (r *UserRepository) getByID(ctx context.Context, id string) (*domain.User, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, err
+	}
+
+	user, exists := r.users[id]
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := tenant.Check(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// 복사본 반환 (불변성 보장)
+	return user.Clone(), nil
+}
+
+// getByEmail - 이메일로 사용자 조회 (호출자가 잠금 보유)
+func (r *UserRepository) getByEmail(ctx context.Context, email string) (*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, exists := r.emails[emailKey(tenantID, email)]
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+	return r.users[id].Clone(), nil
+}
+
+// getAll - 테넌트의 모든 사용자 조회 (호출자가 잠금 보유)
+func (r *UserRepository) getAll(ctx context.Context) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0)
+	for _, user := range r.users {
+		if user.TenantID != tenantID {
+			continue
+		}
+		users = append(users, user.Clone())
+	}
+	return users, nil
+}
+
+// update - 사용자 정보 수정 (호출자가 쓰기 잠금 보유, 메모리 반영 전에 record로 기록)
+func (r *UserRepository) update(ctx context.Context, user *domain.User, record func(logRecord) error) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	prev, exists := r.users[user.ID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	// 다른 테넌트 사용자를 자기 테넌트로 옮겨 쓰는 것도 차단
+	if prev.TenantID != user.TenantID {
+		return domain.ErrCrossTenant
+	}
+	if r.emailTaken(user) {
+		return domain.ErrUserExists
+	}
+
+	if err := record(logRecord{Op: opPut, User: toRecord(user)}); err != nil {
+		return err
+	}
+
+	r.put(user.Clone())
+	return nil
+}
+
+// delete - 사용자 삭제 (호출자가 쓰기 잠금 보유, 메모리 반영 전에 record로 기록)
+func (r *UserRepository) delete(ctx context.Context, id string, record func(logRecord) error) error {
+	if _, err := tenant.Require(ctx); err != nil {
+		return err
+	}
+
+	user, exists := r.users[id]
+	if !exists {
 		return domain.ErrUserNotFound
 	}
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	if err := record(logRecord{Op: opDelete, ID: id}); err != nil {
+		return err
+	}
+
+	r.remove(id)
+	return nil
+}
+
+// Compact - 현재 상태를 스냅샷으로 저장하고 WAL 비우기 (순수 메모리 모드면 무시)
+func (r *UserRepository) Compact() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.wal == nil {
+		return nil
+	}
+	return r.wal.compact(r.users)
+}
+
+// Close - WAL fsync 후 닫기 (순수 메모리 모드면 무시)
+func (r *UserRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.wal == nil {
+		return nil
+	}
+	return r.wal.close()
+}
+
+// put - 사용자 저장 + 이메일 인덱스 갱신 (호출자가 쓰기 잠금 보유)
+func (r *UserRepository) put(user *domain.User) {
+	if prev, exists := r.users[user.ID]; exists {
+		delete(r.emails, emailKey(prev.TenantID, prev.Email))
+	}
+	r.users[user.ID] = user
+	r.emails[emailKey(user.TenantID, user.Email)] = user.ID
+}
+
+// remove - 사용자 삭제 + 이메일 인덱스 갱신 (호출자가 쓰기 잠금 보유)
+func (r *UserRepository) remove(id string) {
+	if user, exists := r.users[id]; exists {
+		delete(r.emails, emailKey(user.TenantID, user.Email))
+		delete(r.users, id)
+	}
+}
+
+// emailTaken - 같은 테넌트의 다른 사용자가 이미 이 이메일을 쓰는지 (호출자가 잠금 보유)
+// put은 인덱스를 그대로 덮어쓰므로 쓰기 전에 반드시 확인
+func (r *UserRepository) emailTaken(user *domain.User) bool {
+	id, exists := r.emails[emailKey(user.TenantID, user.Email)]
+	return exists && id != user.ID
+}
+
+// emailKey - 이메일 인덱스 키 (이메일은 테넌트 안에서만 고유)
+func emailKey(tenantID, email string) string {
+	return tenantID + "\x00" + email
+}
+
+// writeAhead - 메모리 반영 전에 WAL 기록 (호출자가 쓰기 잠금 보유)
+func (r *UserRepository) writeAhead(rec logRecord) error {
+	if r.wal == nil {
+		return nil
+	}
+	return r.wal.append(rec)
+}
+
+// maybeCompact - 자동 압축 (실패해도 변경은 이미 WAL에 있으므로 다음 기회에 재시도)
+func (r *UserRepository) maybeCompact() {
+	if r.wal == nil || !r.wal.needsCompaction() {
+		return
+	}
+	if err := r.wal.compact(r.users); err != nil {
+		log.Printf("WAL 압축 실패: %v", err)
+	}
+}
+
+// Stream - 사용자를 한 명씩 fn에 전달 (usecase.UserStreamer 구현)
+// ID 목록만 먼저 복사하고, fn 호출 중에는 잠금을 잡지 않음
+func (r *UserRepository) Stream(ctx context.Context, fn func(*domain.User) error) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	ids := make([]string, 0)
+	for id, user := range r.users {
+		if user.TenantID == tenantID {
+			ids = append(ids, id)
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		user, err := r.GetByID(ctx, id)
+		if err == domain.ErrUserNotFound {
+			// 순회 중 삭제된 사용자는 건너뜀
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
 
-	delete(r.users, id)
 	return nil
 }
 
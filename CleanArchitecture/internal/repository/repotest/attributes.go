@@ -0,0 +1,144 @@
+package repotest
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// withAttributes - JSON 디코딩 결과와 같은 형태의 속성을 가진 사용자
+func withAttributes(user *domain.User) *domain.User {
+	user.Attributes = domain.Attributes{
+		"hr": {
+			"department": "platform",
+			"level":      float64(3),
+			"remote":     true,
+			"skills":     []any{"go", "sql"},
+		},
+		"i18n": {
+			"locale": "ko-KR",
+			"extra":  map[string]any{"calendar": "gregorian"},
+		},
+	}
+	return user
+}
+
+// assertAttributes - 속성 깊은 비교
+func assertAttributes(t *testing.T, op string, got, want domain.Attributes) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("%s: attributes mismatch\n got: %#v\nwant: %#v", op, got, want)
+	}
+}
+
+func testAttributesRoundTrip(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := withAttributes(newUser(1))
+	want := user.Attributes.Clone()
+	mustCreate(t, repo, user)
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertAttributes(t, "GetByID", got.Attributes, want)
+
+	// 네임스페이스 삭제도 저장됨
+	got.RemoveAttributes("i18n")
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	delete(want, "i18n")
+
+	byEmail, err := repo.GetByEmail(ctx, user.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	assertAttributes(t, "GetByEmail after Update", byEmail.Attributes, want)
+}
+
+// testAttributesCopyIsolation - 호출자가 속성 맵을 고쳐도 저장된 값은 그대로
+func testAttributesCopyIsolation(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := withAttributes(newUser(1))
+	want := user.Attributes.Clone()
+	mustCreate(t, repo, user)
+	user.Attributes["hr"]["department"] = "mutated after Create"
+	user.Attributes["hr"]["skills"].([]any)[0] = "mutated"
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertAttributes(t, "GetByID after caller mutation", got.Attributes, want)
+
+	got.Attributes["i18n"]["extra"].(map[string]any)["calendar"] = "mutated after read"
+	delete(got.Attributes, "hr")
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("GetAll: got %d users, want 1", len(all))
+	}
+	assertAttributes(t, "GetAll after read mutation", all[0].Attributes, want)
+}
+
+// testFindByAttributes - usecase.UserAttributeQuerier를 구현한 저장소만 검사
+// 테넌트 범위, 값 종류별 비교, 배열 원소 일치, 여러 조건 AND
+func testFindByAttributes(t *testing.T, repo usecase.UserRepository) {
+	querier, ok := repo.(usecase.UserAttributeQuerier)
+	if !ok {
+		t.Skip("repository does not implement usecase.UserAttributeQuerier")
+	}
+
+	match := withAttributes(newUser(1))
+	other := withAttributes(newUser(2))
+	other.Attributes["hr"]["department"] = "sales"
+	bare := newUser(3)
+	foreign := inTenant(withAttributes(newUser(4)), tenantB)
+	for _, u := range []*domain.User{match, other, bare, foreign} {
+		mustCreate(t, repo, u)
+	}
+
+	tests := []struct {
+		name    string
+		filters []domain.AttributeFilter
+		want    []string
+	}{
+		{"string", []domain.AttributeFilter{{Namespace: "hr", Field: "department", Value: "platform"}}, []string{match.ID}},
+		{"number", []domain.AttributeFilter{{Namespace: "hr", Field: "level", Value: "3.0"}}, []string{match.ID, other.ID}},
+		{"bool", []domain.AttributeFilter{{Namespace: "hr", Field: "remote", Value: "true"}}, []string{match.ID, other.ID}},
+		{"array element", []domain.AttributeFilter{{Namespace: "hr", Field: "skills", Value: "sql"}}, []string{match.ID, other.ID}},
+		{"and", []domain.AttributeFilter{
+			{Namespace: "hr", Field: "skills", Value: "go"},
+			{Namespace: "hr", Field: "department", Value: "sales"},
+		}, []string{other.ID}},
+		{"missing field", []domain.AttributeFilter{{Namespace: "hr", Field: "manager", Value: "x"}}, nil},
+		{"type mismatch", []domain.AttributeFilter{{Namespace: "hr", Field: "level", Value: "three"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := querier.FindByAttributes(ctxA, tt.filters)
+			if err != nil {
+				t.Fatalf("FindByAttributes: %v", err)
+			}
+			got := make([]string, len(users))
+			for i, u := range users {
+				got[i] = u.ID
+			}
+			sort.Strings(got) // 순서는 계약에 없음
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Fatalf("FindByAttributes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := querier.FindByAttributes(ctxA, []domain.AttributeFilter{{Namespace: "HR", Field: "x", Value: "y"}})
+	assertErr(t, "FindByAttributes with invalid filter", err, domain.ErrInvalidFilter)
+}
@@ -0,0 +1,50 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// testGetByIDs - usecase.UserBatchGetter를 구현한 저장소만 검사
+func testGetByIDs(t *testing.T, repo usecase.UserRepository) {
+	getter, ok := repo.(usecase.UserBatchGetter)
+	if !ok {
+		t.Skip("repository does not implement usecase.UserBatchGetter")
+	}
+
+	first, second := newUser(1), newUser(2)
+	other := inTenant(newUser(3), tenantB)
+	for _, user := range []*domain.User{first, second, other} {
+		mustCreate(t, repo, user)
+	}
+
+	// 없는 ID와 다른 테넌트 소속 ID는 결과에서 빠짐
+	got, err := getter.GetByIDs(ctxA, []string{first.ID, other.ID, "missing", second.ID})
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetByIDs: got %d users, want 2 (%v)", len(got), got)
+	}
+	assertUser(t, got[first.ID], first)
+	assertUser(t, got[second.ID], second)
+
+	// 돌려준 값을 바꿔도 저장된 사용자는 그대로
+	got[first.ID].Name = "mutated by GetByIDs"
+	again, err := getter.GetByIDs(ctxA, []string{first.ID})
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	assertUser(t, again[first.ID], first)
+
+	empty, err := getter.GetByIDs(ctxA, nil)
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("GetByIDs(nil): got %v, %v, want empty", empty, err)
+	}
+
+	_, err = getter.GetByIDs(context.Background(), []string{first.ID})
+	assertErr(t, "GetByIDs without tenant", err, domain.ErrTenantRequired)
+}
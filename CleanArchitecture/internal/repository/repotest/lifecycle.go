@@ -0,0 +1,69 @@
+package repotest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// withStatus - 정지 기록이 있는 사용자 (시각은 초 단위 UTC)
+func withStatus(user *domain.User) *domain.User {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	user.Status = domain.UserSuspended
+	user.StatusHistory = []domain.StatusChange{
+		{From: domain.UserPending, To: domain.UserActive, Actor: "admin", At: at},
+		{From: domain.UserActive, To: domain.UserSuspended, Reason: "spam", Actor: "admin", At: at.Add(time.Hour)},
+	}
+	return user
+}
+
+// assertStatus - 상태와 변경 기록 비교 (시각은 Equal로 비교)
+func assertStatus(t *testing.T, op string, got, want *domain.User) {
+	t.Helper()
+
+	if got.Status != want.Status || len(got.StatusHistory) != len(want.StatusHistory) {
+		t.Fatalf("%s: status mismatch\n got: %s %+v\nwant: %s %+v", op, got.Status, got.StatusHistory, want.Status, want.StatusHistory)
+	}
+	for i := range want.StatusHistory {
+		g, w := got.StatusHistory[i], want.StatusHistory[i]
+		if !g.At.Equal(w.At) {
+			t.Fatalf("%s: history[%d].At = %v, want %v", op, i, g.At, w.At)
+		}
+		g.At, w.At = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(g, w) {
+			t.Fatalf("%s: history[%d] = %+v, want %+v", op, i, g, w)
+		}
+	}
+}
+
+// testStatusRoundTrip - 상태와 변경 기록이 저장되고, 호출자가 기록을 고쳐도 저장된 값은 그대로
+func testStatusRoundTrip(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := withStatus(newUser(1))
+	want := user.Clone()
+	mustCreate(t, repo, user)
+	user.StatusHistory[0].Reason = "mutated"
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertStatus(t, "GetByID", got, want)
+
+	if err := got.ChangeStatus(domain.UserActive, "appeal accepted", "admin"); err != nil {
+		t.Fatalf("ChangeStatus: %v", err)
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	want = got.Clone()
+
+	byEmail, err := repo.GetByEmail(ctx, user.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	assertStatus(t, "GetByEmail after Update", byEmail, want)
+}
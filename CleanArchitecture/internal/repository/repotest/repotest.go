@@ -0,0 +1,732 @@
+// Package repotest - usecase.UserRepository 구현체 공통 동작 검증 키트
+//
+// 새 어댑터는 자기 패키지의 테스트에서 팩토리만 넘기면 된다.
+//
+//	func TestUserRepository(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+//			return memory.NewUserRepository()
+//		})
+//	}
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// 테스트 테넌트 (별도 언급이 없으면 모든 사용자는 tenantA 소속)
+const (
+	tenantA = "tenant-a"
+	tenantB = "tenant-b"
+)
+
+var (
+	ctxA = tenant.WithID(context.Background(), tenantA)
+	ctxB = tenant.WithID(context.Background(), tenantB)
+)
+
+// Factory - 서브테스트마다 비어 있는 새 저장소 생성
+// 정리가 필요하면 t.Cleanup으로 등록
+type Factory func(t *testing.T) usecase.UserRepository
+
+// Run - 전체 동작 스위트 실행
+func Run(t *testing.T, newRepo Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo usecase.UserRepository)
+	}{
+		{"CreateAndGetByID", testCreateAndGetByID},
+		{"CreateDuplicateID", testCreateDuplicateID},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"GetByEmail", testGetByEmail},
+		{"GetByEmailNotFound", testGetByEmailNotFound},
+		{"GetAll", testGetAll},
+		{"GetAllEmpty", testGetAllEmpty},
+		{"Update", testUpdate},
+		{"UpdateEmailChange", testUpdateEmailChange},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"Delete", testDelete},
+		{"DeleteNotFound", testDeleteNotFound},
+		{"CopyOnCreate", testCopyOnCreate},
+		{"CopyOnUpdate", testCopyOnUpdate},
+		{"CopyOnRead", testCopyOnRead},
+		{"Stream", testStream},
+		{"Concurrent", testConcurrent},
+		{"TenantRequired", testTenantRequired},
+		{"CrossTenantCreate", testCrossTenantCreate},
+		{"CrossTenantGetByID", testCrossTenantGetByID},
+		{"CrossTenantUpdate", testCrossTenantUpdate},
+		{"CrossTenantDelete", testCrossTenantDelete},
+		{"EmailUniquePerTenant", testEmailUniquePerTenant},
+		{"CreateDuplicateEmail", testCreateDuplicateEmail},
+		{"UpdateEmailConflict", testUpdateEmailConflict},
+		{"ConcurrentCreateSameEmail", testConcurrentCreateSameEmail},
+		{"GetAllScopedByTenant", testGetAllScopedByTenant},
+		{"StreamScopedByTenant", testStreamScopedByTenant},
+		{"GetByIDs", testGetByIDs},
+		{"AttributesRoundTrip", testAttributesRoundTrip},
+		{"AttributesCopyIsolation", testAttributesCopyIsolation},
+		{"FindByAttributes", testFindByAttributes},
+		{"StatusRoundTrip", testStatusRoundTrip},
+		{"TransactionCommit", testTransactionCommit},
+		{"TransactionRollback", testTransactionRollback},
+		{"TransactionNoLostUpdates", testTransactionNoLostUpdates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// newUser - 테스트용 사용자 (시각은 저장소 왕복에도 유지되도록 초 단위 UTC)
+func newUser(n int) *domain.User {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(n) * time.Second)
+	return &domain.User{
+		ID:        fmt.Sprintf("user-%04d", n),
+		TenantID:  tenantA,
+		Email:     fmt.Sprintf("user%d@example.com", n),
+		Name:      fmt.Sprintf("User %d", n),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// inTenant - 다른 테넌트 소속 사용자
+func inTenant(user *domain.User, tenantID string) *domain.User {
+	user.TenantID = tenantID
+	return user
+}
+
+// mustCreate - 사용자의 테넌트로 생성, 실패 시 즉시 중단
+func mustCreate(t *testing.T, repo usecase.UserRepository, user *domain.User) {
+	t.Helper()
+
+	if err := repo.Create(tenant.WithID(context.Background(), user.TenantID), user); err != nil {
+		t.Fatalf("Create(%s): %v", user.ID, err)
+	}
+}
+
+// assertUser - 필드 비교 (시각은 Equal로 비교)
+func assertUser(t *testing.T, got, want *domain.User) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("got nil user, want %+v", want)
+	}
+	if got.ID != want.ID || got.TenantID != want.TenantID || got.Email != want.Email || got.Name != want.Name ||
+		!got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("user mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// assertErr - errors.Is로 오류 계약 확인
+func assertErr(t *testing.T, op string, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("%s: got error %v, want %v", op, err, want)
+	}
+}
+
+func testCreateAndGetByID(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+func testCreateDuplicateID(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	dup := newUser(2)
+	dup.ID = user.ID
+	assertErr(t, "Create duplicate", repo.Create(ctx, dup), domain.ErrUserExists)
+
+	// 기존 사용자는 그대로 유지
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+func testGetByIDNotFound(t *testing.T, repo usecase.UserRepository) {
+	user, err := repo.GetByID(ctxA, "missing")
+	assertErr(t, "GetByID", err, domain.ErrUserNotFound)
+	if user != nil {
+		t.Fatalf("GetByID: got %+v with error, want nil", user)
+	}
+}
+
+func testGetByEmail(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	mustCreate(t, repo, newUser(1))
+	want := newUser(2)
+	mustCreate(t, repo, want)
+
+	got, err := repo.GetByEmail(ctx, want.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	assertUser(t, got, want)
+}
+
+func testGetByEmailNotFound(t *testing.T, repo usecase.UserRepository) {
+	mustCreate(t, repo, newUser(1))
+
+	user, err := repo.GetByEmail(ctxA, "nobody@example.com")
+	assertErr(t, "GetByEmail", err, domain.ErrUserNotFound)
+	if user != nil {
+		t.Fatalf("GetByEmail: got %+v with error, want nil", user)
+	}
+}
+
+func testGetAll(t *testing.T, repo usecase.UserRepository) {
+	want := []*domain.User{newUser(1), newUser(2), newUser(3)}
+	for _, user := range want {
+		mustCreate(t, repo, user)
+	}
+
+	got, err := repo.GetAll(ctxA)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GetAll: got %d users, want %d", len(got), len(want))
+	}
+
+	// 순서는 계약에 없으므로 ID로 정렬해 비교
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	for i := range want {
+		assertUser(t, got[i], want[i])
+	}
+}
+
+func testGetAllEmpty(t *testing.T, repo usecase.UserRepository) {
+	got, err := repo.GetAll(ctxA)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("GetAll: got %d users, want 0", len(got))
+	}
+}
+
+func testUpdate(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	updated := *user
+	updated.Name = "Renamed"
+	updated.UpdatedAt = user.UpdatedAt.Add(time.Hour)
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, &updated)
+}
+
+func testUpdateEmailChange(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	updated := *user
+	updated.Email = "changed@example.com"
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repo.GetByEmail(ctx, updated.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail(new): %v", err)
+	}
+	assertUser(t, got, &updated)
+
+	_, err = repo.GetByEmail(ctx, user.Email)
+	assertErr(t, "GetByEmail(old)", err, domain.ErrUserNotFound)
+}
+
+func testUpdateNotFound(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	assertErr(t, "Update", repo.Update(ctx, user), domain.ErrUserNotFound)
+
+	// Update가 upsert처럼 동작하면 안 됨
+	_, err := repo.GetByID(ctx, user.ID)
+	assertErr(t, "GetByID after Update", err, domain.ErrUserNotFound)
+}
+
+func testDelete(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	other := newUser(2)
+	mustCreate(t, repo, user)
+	mustCreate(t, repo, other)
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	_, err := repo.GetByID(ctx, user.ID)
+	assertErr(t, "GetByID after Delete", err, domain.ErrUserNotFound)
+	_, err = repo.GetByEmail(ctx, user.Email)
+	assertErr(t, "GetByEmail after Delete", err, domain.ErrUserNotFound)
+
+	got, err := repo.GetByID(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("GetByID(other): %v", err)
+	}
+	assertUser(t, got, other)
+
+	// 삭제한 ID로 다시 생성 가능
+	mustCreate(t, repo, newUser(1))
+}
+
+func testDeleteNotFound(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	assertErr(t, "Delete", repo.Delete(ctx, "missing"), domain.ErrUserNotFound)
+
+	user := newUser(1)
+	mustCreate(t, repo, user)
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	assertErr(t, "Delete twice", repo.Delete(ctx, user.ID), domain.ErrUserNotFound)
+}
+
+func testCopyOnCreate(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	want := *user
+	mustCreate(t, repo, user)
+
+	// 호출자가 넘긴 값을 바꿔도 저장된 값은 그대로
+	user.Name = "mutated"
+	user.Email = "mutated@example.com"
+
+	got, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, &want)
+}
+
+func testCopyOnUpdate(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	updated := *user
+	updated.Name = "Renamed"
+	want := updated
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	updated.Name = "mutated"
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, &want)
+}
+
+func testCopyOnRead(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	byID, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	byID.Name = "mutated by GetByID"
+
+	byEmail, err := repo.GetByEmail(ctx, user.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	assertUser(t, byEmail, user)
+	byEmail.Name = "mutated by GetByEmail"
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("GetAll: got %d users, want 1", len(all))
+	}
+	assertUser(t, all[0], user)
+	all[0].Name = "mutated by GetAll"
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+// testStream - usecase.UserStreamer를 구현한 저장소만 검사
+func testStream(t *testing.T, repo usecase.UserRepository) {
+	streamer, ok := repo.(usecase.UserStreamer)
+	if !ok {
+		t.Skip("repository does not implement usecase.UserStreamer")
+	}
+
+	ctx := ctxA
+	for i := 1; i <= 5; i++ {
+		mustCreate(t, repo, newUser(i))
+	}
+
+	seen := make(map[string]bool)
+	err := streamer.Stream(ctx, func(user *domain.User) error {
+		if seen[user.ID] {
+			t.Errorf("Stream: user %s visited twice", user.ID)
+		}
+		seen[user.ID] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(seen) != 5 {
+		t.Fatalf("Stream: visited %d users, want 5", len(seen))
+	}
+
+	// fn의 오류는 그대로 전파되고 순회 중단
+	stop := errors.New("stop")
+	calls := 0
+	err = streamer.Stream(ctx, func(*domain.User) error {
+		calls++
+		return stop
+	})
+	assertErr(t, "Stream with failing fn", err, stop)
+	if calls != 1 {
+		t.Fatalf("Stream: fn called %d times after error, want 1", calls)
+	}
+}
+
+// testConcurrent - 동시 접근 (go test -race로 실행해야 의미가 있음)
+func testConcurrent(t *testing.T, repo usecase.UserRepository) {
+	const workers = 8
+	const perWorker = 20
+
+	ctx := ctxA
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				user := newUser(w*perWorker + i)
+				if err := repo.Create(ctx, user); err != nil {
+					errs <- fmt.Errorf("Create(%s): %w", user.ID, err)
+					continue
+				}
+
+				got, err := repo.GetByID(ctx, user.ID)
+				if err != nil {
+					errs <- fmt.Errorf("GetByID(%s): %w", user.ID, err)
+					continue
+				}
+				got.Name = "Updated"
+				if err := repo.Update(ctx, got); err != nil {
+					errs <- fmt.Errorf("Update(%s): %w", user.ID, err)
+				}
+
+				if _, err := repo.GetAll(ctx); err != nil {
+					errs <- fmt.Errorf("GetAll: %w", err)
+				}
+
+				// 홀수 번째는 삭제
+				if i%2 == 1 {
+					if err := repo.Delete(ctx, user.ID); err != nil {
+						errs <- fmt.Errorf("Delete(%s): %w", user.ID, err)
+					}
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if want := workers * perWorker / 2; len(all) != want {
+		t.Fatalf("GetAll: got %d users, want %d", len(all), want)
+	}
+	for _, user := range all {
+		if user.Name != "Updated" {
+			t.Fatalf("user %s: got name %q, want %q", user.ID, user.Name, "Updated")
+		}
+	}
+}
+
+func testTenantRequired(t *testing.T, repo usecase.UserRepository) {
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	ctx := context.Background()
+	assertErr(t, "Create", repo.Create(ctx, newUser(2)), domain.ErrTenantRequired)
+	_, err := repo.GetByID(ctx, user.ID)
+	assertErr(t, "GetByID", err, domain.ErrTenantRequired)
+	_, err = repo.GetByEmail(ctx, user.Email)
+	assertErr(t, "GetByEmail", err, domain.ErrTenantRequired)
+	_, err = repo.GetAll(ctx)
+	assertErr(t, "GetAll", err, domain.ErrTenantRequired)
+	assertErr(t, "Update", repo.Update(ctx, user), domain.ErrTenantRequired)
+	assertErr(t, "Delete", repo.Delete(ctx, user.ID), domain.ErrTenantRequired)
+}
+
+func testCrossTenantCreate(t *testing.T, repo usecase.UserRepository) {
+	// 컨텍스트는 A인데 B 소속 사용자 생성
+	user := inTenant(newUser(1), tenantB)
+	assertErr(t, "Create", repo.Create(ctxA, user), domain.ErrCrossTenant)
+
+	_, err := repo.GetByID(ctxB, user.ID)
+	assertErr(t, "GetByID in B", err, domain.ErrUserNotFound)
+}
+
+func testCrossTenantGetByID(t *testing.T, repo usecase.UserRepository) {
+	user := inTenant(newUser(1), tenantB)
+	mustCreate(t, repo, user)
+
+	// 자기 테넌트 조회를 먼저 해서 캐시 등 중간 계층도 검사
+	if _, err := repo.GetByID(ctxB, user.ID); err != nil {
+		t.Fatalf("GetByID in B: %v", err)
+	}
+
+	got, err := repo.GetByID(ctxA, user.ID)
+	assertErr(t, "GetByID in A", err, domain.ErrCrossTenant)
+	if got != nil {
+		t.Fatalf("GetByID in A: got %+v with error, want nil", got)
+	}
+}
+
+func testCrossTenantUpdate(t *testing.T, repo usecase.UserRepository) {
+	user := inTenant(newUser(1), tenantB)
+	mustCreate(t, repo, user)
+
+	// A 컨텍스트에서 B 소속 사용자 수정
+	updated := *user
+	updated.Name = "hijacked"
+	assertErr(t, "Update B user in A", repo.Update(ctxA, &updated), domain.ErrCrossTenant)
+
+	// B 사용자를 A 소속으로 바꿔 쓰기
+	moved := *user
+	moved.TenantID = tenantA
+	moved.Name = "hijacked"
+	assertErr(t, "Update moving user to A", repo.Update(ctxA, &moved), domain.ErrCrossTenant)
+
+	got, err := repo.GetByID(ctxB, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID in B: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+func testCrossTenantDelete(t *testing.T, repo usecase.UserRepository) {
+	user := inTenant(newUser(1), tenantB)
+	mustCreate(t, repo, user)
+
+	assertErr(t, "Delete in A", repo.Delete(ctxA, user.ID), domain.ErrCrossTenant)
+
+	got, err := repo.GetByID(ctxB, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID in B: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+func testEmailUniquePerTenant(t *testing.T, repo usecase.UserRepository) {
+	a := newUser(1)
+	b := inTenant(newUser(2), tenantB)
+	b.Email = a.Email
+	mustCreate(t, repo, a)
+	mustCreate(t, repo, b)
+
+	got, err := repo.GetByEmail(ctxA, a.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail in A: %v", err)
+	}
+	assertUser(t, got, a)
+
+	got, err = repo.GetByEmail(ctxB, b.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail in B: %v", err)
+	}
+	assertUser(t, got, b)
+
+	// 한쪽 삭제가 다른 테넌트의 이메일 인덱스에 영향을 주면 안 됨
+	if err := repo.Delete(ctxB, b.ID); err != nil {
+		t.Fatalf("Delete in B: %v", err)
+	}
+	got, err = repo.GetByEmail(ctxA, a.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail in A after delete in B: %v", err)
+	}
+	assertUser(t, got, a)
+}
+
+func testCreateDuplicateEmail(t *testing.T, repo usecase.UserRepository) {
+	user := newUser(1)
+	mustCreate(t, repo, user)
+
+	dup := newUser(2)
+	dup.Email = user.Email
+	assertErr(t, "Create duplicate email", repo.Create(ctxA, dup), domain.ErrUserExists)
+
+	_, err := repo.GetByID(ctxA, dup.ID)
+	assertErr(t, "GetByID(dup)", err, domain.ErrUserNotFound)
+	got, err := repo.GetByEmail(ctxA, user.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	assertUser(t, got, user)
+}
+
+func testUpdateEmailConflict(t *testing.T, repo usecase.UserRepository) {
+	a, b := newUser(1), newUser(2)
+	mustCreate(t, repo, a)
+	mustCreate(t, repo, b)
+
+	updated := *b
+	updated.Email = a.Email
+	assertErr(t, "Update to taken email", repo.Update(ctxA, &updated), domain.ErrUserExists)
+
+	// 두 사용자 모두 자기 이메일로 그대로 조회되어야 함
+	got, err := repo.GetByEmail(ctxA, a.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail(a): %v", err)
+	}
+	assertUser(t, got, a)
+	got, err = repo.GetByEmail(ctxA, b.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail(b): %v", err)
+	}
+	assertUser(t, got, b)
+}
+
+// testConcurrentCreateSameEmail - 같은 이메일 동시 생성은 하나만 성공
+func testConcurrentCreateSameEmail(t *testing.T, repo usecase.UserRepository) {
+	const workers = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			user := newUser(w)
+			user.Email = "same@example.com"
+			errs <- repo.Create(ctxA, user)
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, domain.ErrUserExists):
+			t.Errorf("Create: got error %v, want nil or %v", err, domain.ErrUserExists)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("created %d users with the same email, want 1", created)
+	}
+
+	all, err := repo.GetAll(ctxA)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("GetAll: got %d users, want 1", len(all))
+	}
+}
+
+func testGetAllScopedByTenant(t *testing.T, repo usecase.UserRepository) {
+	mustCreate(t, repo, newUser(1))
+	mustCreate(t, repo, newUser(2))
+	mustCreate(t, repo, inTenant(newUser(3), tenantB))
+
+	all, err := repo.GetAll(ctxA)
+	if err != nil {
+		t.Fatalf("GetAll in A: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("GetAll in A: got %d users, want 2", len(all))
+	}
+	for _, user := range all {
+		if user.TenantID != tenantA {
+			t.Fatalf("GetAll in A: got user %s of tenant %q", user.ID, user.TenantID)
+		}
+	}
+
+	all, err = repo.GetAll(ctxB)
+	if err != nil {
+		t.Fatalf("GetAll in B: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("GetAll in B: got %d users, want 1", len(all))
+	}
+}
+
+func testStreamScopedByTenant(t *testing.T, repo usecase.UserRepository) {
+	streamer, ok := repo.(usecase.UserStreamer)
+	if !ok {
+		t.Skip("repository does not implement usecase.UserStreamer")
+	}
+
+	mustCreate(t, repo, newUser(1))
+	mustCreate(t, repo, inTenant(newUser(2), tenantB))
+
+	err := streamer.Stream(ctxB, func(user *domain.User) error {
+		if user.TenantID != tenantB {
+			t.Errorf("Stream in B: got user %s of tenant %q", user.ID, user.TenantID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream in B: %v", err)
+	}
+
+	err = streamer.Stream(context.Background(), func(*domain.User) error { return nil })
+	assertErr(t, "Stream without tenant", err, domain.ErrTenantRequired)
+}
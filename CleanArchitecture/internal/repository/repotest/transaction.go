@@ -0,0 +1,145 @@
+package repotest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// transactor - usecase.UserTransactor를 구현하지 않은 저장소는 트랜잭션 테스트를 건너뜀
+func transactor(t *testing.T, repo usecase.UserRepository) usecase.UserTransactor {
+	t.Helper()
+
+	tx, ok := repo.(usecase.UserTransactor)
+	if !ok {
+		t.Skip("repository does not implement usecase.UserTransactor")
+	}
+	return tx
+}
+
+func testTransactionCommit(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	existing := newUser(1)
+	mustCreate(t, repo, existing)
+
+	created := newUser(2)
+	err := transactor(t, repo).InTransaction(ctx, func(tx usecase.UserRepository) error {
+		if err := tx.Create(ctx, created); err != nil {
+			return err
+		}
+		// 트랜잭션 안에서는 자기 변경이 보임
+		if _, err := tx.GetByEmail(ctx, created.Email); err != nil {
+			return err
+		}
+		return tx.Delete(ctx, existing.ID)
+	})
+	if err != nil {
+		t.Fatalf("InTransaction: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID(created): %v", err)
+	}
+	assertUser(t, got, created)
+	_, err = repo.GetByID(ctx, existing.ID)
+	assertErr(t, "GetByID(deleted)", err, domain.ErrUserNotFound)
+}
+
+func testTransactionRollback(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	existing := newUser(1)
+	mustCreate(t, repo, existing)
+	// 캐시 데코레이터가 있으면 이전 상태가 캐시에 남아 있는 경우도 확인
+	if _, err := repo.GetByID(ctx, existing.ID); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	boom := errors.New("boom")
+	created := newUser(2)
+	err := transactor(t, repo).InTransaction(ctx, func(tx usecase.UserRepository) error {
+		if err := tx.Create(ctx, created); err != nil {
+			return err
+		}
+		renamed := existing.Clone()
+		renamed.Email = "renamed@example.com"
+		renamed.Name = "Renamed"
+		if err := tx.Update(ctx, renamed); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, created.ID); err != nil {
+			return err
+		}
+		return boom
+	})
+	assertErr(t, "InTransaction", err, boom)
+
+	_, err = repo.GetByID(ctx, created.ID)
+	assertErr(t, "GetByID(rolled back create)", err, domain.ErrUserNotFound)
+	got, err := repo.GetByID(ctx, existing.ID)
+	if err != nil {
+		t.Fatalf("GetByID(existing): %v", err)
+	}
+	assertUser(t, got, existing)
+	if got, err := repo.GetByEmail(ctx, existing.Email); err != nil || got.ID != existing.ID {
+		t.Fatalf("GetByEmail(original email): %v, %v", got, err)
+	}
+	_, err = repo.GetByEmail(ctx, "renamed@example.com")
+	assertErr(t, "GetByEmail(rolled back email)", err, domain.ErrUserNotFound)
+
+	all, err := repo.GetAll(ctx)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("GetAll after rollback: %d users, %v", len(all), err)
+	}
+}
+
+// 같은 사용자를 읽고-고쳐-쓰는 트랜잭션이 동시에 돌아도 서로의 변경을 덮어쓰지 않음
+func testTransactionNoLostUpdates(t *testing.T, repo usecase.UserRepository) {
+	ctx := ctxA
+	user := newUser(1)
+	user.Name = "count 0"
+	mustCreate(t, repo, user)
+	tr := transactor(t, repo)
+
+	const workers, rounds = 4, 5
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				err := tr.InTransaction(ctx, func(tx usecase.UserRepository) error {
+					current, err := tx.GetByID(ctx, user.ID)
+					if err != nil {
+						return err
+					}
+					n, err := strconv.Atoi(strings.TrimPrefix(current.Name, "count "))
+					if err != nil {
+						return err
+					}
+					current.Name = fmt.Sprintf("count %d", n+1)
+					return tx.Update(ctx, current)
+				})
+				if err != nil {
+					t.Errorf("InTransaction: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if want := fmt.Sprintf("count %d", workers*rounds); got.Name != want {
+		t.Fatalf("name = %q, want %q (lost update)", got.Name, want)
+	}
+}
@@ -0,0 +1,216 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// 필드 가중치 - 이름 일치를 이메일 일치보다 앞에 둠
+const (
+	nameWeight  = 2.0
+	emailWeight = 1.0
+
+	// suffixFactor - 한글 이름 중간부터 일치 ("김민수"에서 "민수")
+	suffixFactor = 0.7
+)
+
+// 일치 종류별 점수 (가중치에 곱함)
+const (
+	exactScore     = 1.0
+	prefixMinScore = 0.5 // 접두어 일치: 0.5 ~ 0.9 (단어를 많이 덮을수록 높음)
+	prefixMaxScore = 0.9
+	fuzzyScore     = 0.4 // 오타 허용 일치: 거리 1이면 0.4, 2이면 0.2
+)
+
+// index - 테넌트 하나의 역색인 (동시성 제어는 호출자 책임)
+type index struct {
+	postings map[string]map[string]float64 // 단어 → 사용자 ID → 가중치
+	docs     map[string]*domain.User       // 사용자 ID → 색인된 사용자 (복사본)
+	docTerms map[string][]string           // 사용자 ID → 색인한 단어 (삭제용)
+
+	terms []string // 정렬된 단어 목록 (접두어 검색용, dirty면 다시 만듦)
+	dirty bool
+}
+
+func newIndex() *index {
+	return &index{
+		postings: make(map[string]map[string]float64),
+		docs:     make(map[string]*domain.User),
+		docTerms: make(map[string][]string),
+	}
+}
+
+// put - 사용자 색인 (이미 있으면 교체)
+func (ix *index) put(user *domain.User) {
+	ix.remove(user.ID)
+
+	weights := make(map[string]float64)
+	add := func(term string, w float64) {
+		if w > weights[term] {
+			weights[term] = w
+		}
+	}
+
+	for _, tok := range tokenize(user.Name) {
+		add(tok, nameWeight)
+		// 한글 이름은 띄어 쓰지 않으므로 접미어도 색인해 이름 중간부터 검색 가능하게 함
+		if runes := []rune(tok); isHangul(runes[0]) {
+			for i := 1; i < len(runes); i++ {
+				add(string(runes[i:]), nameWeight*suffixFactor)
+			}
+		}
+	}
+	for _, tok := range tokenize(user.Email) {
+		add(tok, emailWeight)
+	}
+
+	terms := make([]string, 0, len(weights))
+	for term, w := range weights {
+		docs, ok := ix.postings[term]
+		if !ok {
+			docs = make(map[string]float64)
+			ix.postings[term] = docs
+			ix.dirty = true
+		}
+		docs[user.ID] = w
+		terms = append(terms, term)
+	}
+
+	ix.docs[user.ID] = user.Clone()
+	ix.docTerms[user.ID] = terms
+}
+
+// remove - 사용자 색인 제거
+func (ix *index) remove(id string) {
+	for _, term := range ix.docTerms[id] {
+		docs := ix.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(ix.postings, term)
+			ix.dirty = true
+		}
+	}
+	delete(ix.docs, id)
+	delete(ix.docTerms, id)
+}
+
+// sortedTerms - 정렬된 단어 목록
+func (ix *index) sortedTerms() []string {
+	if ix.dirty || ix.terms == nil {
+		ix.terms = make([]string, 0, len(ix.postings))
+		for term := range ix.postings {
+			ix.terms = append(ix.terms, term)
+		}
+		sort.Strings(ix.terms)
+		ix.dirty = false
+	}
+	return ix.terms
+}
+
+// hit - 검색 결과 하나
+type hit struct {
+	user  *domain.User
+	score float64
+}
+
+// search - 모든 검색어와 일치하는 사용자를 관련도 순으로 반환 (AND 검색)
+// 검색어마다 정확 일치 → 접두어 일치 순으로 찾고, 둘 다 없을 때만 오타 허용 일치
+// 점수가 같으면 이름, ID 순
+func (ix *index) search(query string, limit int) []hit {
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	var scores map[string]float64
+	for _, qt := range queryTerms {
+		matched := ix.match(qt)
+		if scores == nil {
+			scores = matched
+		} else {
+			for id, s := range scores {
+				if m, ok := matched[id]; ok {
+					scores[id] = s + m
+				} else {
+					delete(scores, id)
+				}
+			}
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	hits := make([]hit, 0, len(scores))
+	for id, s := range scores {
+		hits = append(hits, hit{user: ix.docs[id], score: s})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		if hits[i].user.Name != hits[j].user.Name {
+			return hits[i].user.Name < hits[j].user.Name
+		}
+		return hits[i].user.ID < hits[j].user.ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// match - 검색어 하나와 일치하는 사용자별 최고 점수
+func (ix *index) match(queryTerm string) map[string]float64 {
+	scores := make(map[string]float64)
+	collect := func(term string, factor float64) {
+		for id, w := range ix.postings[term] {
+			if s := w * factor; s > scores[id] {
+				scores[id] = s
+			}
+		}
+	}
+
+	// 정확 일치 + 접두어 일치 (정렬된 단어 목록에서 범위 탐색)
+	qLen := utf8.RuneCountInString(queryTerm)
+	terms := ix.sortedTerms()
+	for i := sort.SearchStrings(terms, queryTerm); i < len(terms) && strings.HasPrefix(terms[i], queryTerm); i++ {
+		if terms[i] == queryTerm {
+			collect(terms[i], exactScore)
+			continue
+		}
+		coverage := float64(qLen) / float64(utf8.RuneCountInString(terms[i]))
+		collect(terms[i], prefixMinScore+(prefixMaxScore-prefixMinScore)*coverage)
+	}
+	if len(scores) > 0 {
+		return scores
+	}
+
+	// 오타 허용 일치 (짧은 검색어는 오타 판단이 어려우므로 제외)
+	maxDist := maxEditDistance(qLen)
+	if maxDist == 0 {
+		return scores
+	}
+	q := []rune(queryTerm)
+	for _, term := range terms {
+		if d := editDistance(q, []rune(term), maxDist); d <= maxDist {
+			collect(term, fuzzyScore/float64(d))
+		}
+	}
+	return scores
+}
+
+// maxEditDistance - 검색어 길이별 허용 오타 수
+func maxEditDistance(runeLen int) int {
+	switch {
+	case runeLen < 3:
+		return 0
+	case runeLen < 6:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"unicode"
+)
+
+// 한글 자모 결합 범위 (유니코드 표준 3.12 Conjoining Jamo Behavior)
+const (
+	hangulBase  = 0xAC00
+	choseongLen = 19
+	jungLen     = 21
+	jongLen     = 28
+
+	choseongBase = 0x1100
+	jungBase     = 0x1161
+	jongBase     = 0x11A7 // 종성 없음 = 0
+)
+
+// tokenize - 문자열을 검색 단어로 분리
+// 소문자로 바꾸고 글자/숫자가 아닌 문자(공백, '.', '@', '-' 등)와
+// 한글/비한글 경계에서 자름 ("kim민수" → "kim", "민수")
+// 조합형(NFD)으로 들어온 한글 자모는 완성형 음절로 합침
+func tokenize(s string) []string {
+	runes := composeHangul([]rune(s))
+
+	tokens := make([]string, 0, 4)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				tokens = append(tokens, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		runes[i] = unicode.ToLower(r)
+		if start >= 0 && isHangul(runes[i-1]) != isHangul(r) {
+			tokens = append(tokens, string(runes[start:i]))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, string(runes[start:]))
+	}
+	return tokens
+}
+
+// isHangul - 한글 음절/자모인지 확인
+func isHangul(r rune) bool {
+	return unicode.Is(unicode.Hangul, r)
+}
+
+// composeHangul - 초성+중성(+종성) 자모열을 완성형 음절로 결합
+// macOS 등에서 NFD로 입력된 이름도 같은 단어로 색인/검색되도록 함
+func composeHangul(runes []rune) []rune {
+	out := runes[:0]
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		l := r - choseongBase
+		if l < 0 || l >= choseongLen || i+1 >= len(runes) {
+			out = append(out, r)
+			continue
+		}
+		v := runes[i+1] - jungBase
+		if v < 0 || v >= jungLen {
+			out = append(out, r)
+			continue
+		}
+
+		syllable := hangulBase + (l*jungLen+v)*jongLen
+		i++
+		if i+1 < len(runes) {
+			if t := runes[i+1] - jongBase; t > 0 && t < jongLen {
+				syllable += t
+				i++
+			}
+		}
+		out = append(out, syllable)
+	}
+	return out
+}
+
+// editDistance - 두 단어의 편집 거리 (인접 글자 바꿈도 1회로 계산)
+// max를 넘는 것이 확실해지면 max+1을 반환하고 중단
+func editDistance(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+
+	// prev2, prev, cur - 직전 두 행과 현재 행
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,302 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// UserRepository - 전문 검색 데코레이터 (어댑터, usecase.UserSearcher 구현)
+// 테넌트별 메모리 역색인을 두고 Create/Update/Delete가 성공하면 색인도 갱신
+// 테넌트 색인은 처음 검색할 때 원본 저장소에서 읽어 만듦 (재시작 후 WAL 복구분 포함)
+// 쓰기와 색인 갱신 순서를 맞추기 위해 같은 테넌트의 쓰기는 이 데코레이터에서 직렬화됨
+type UserRepository struct {
+	next usecase.UserRepository
+
+	mu      sync.Mutex
+	tenants map[string]*tenantIndex
+}
+
+// tenantIndex - 테넌트 하나의 색인 상태
+type tenantIndex struct {
+	mu     sync.Mutex // 원본 쓰기 + 색인 갱신, 적재, 검색을 보호
+	loaded bool       // false면 아직 색인을 만들지 않음 (쓰기는 원본에만 반영)
+	idx    *index
+}
+
+// NewUserRepository - UserRepository 생성자
+func NewUserRepository(next usecase.UserRepository) *UserRepository {
+	return &UserRepository{
+		next:    next,
+		tenants: make(map[string]*tenantIndex),
+	}
+}
+
+// indexFor - 테넌트 색인 상태 조회 (없으면 생성)
+func (r *UserRepository) indexFor(tenantID string) *tenantIndex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ti, ok := r.tenants[tenantID]
+	if !ok {
+		ti = &tenantIndex{idx: newIndex()}
+		r.tenants[tenantID] = ti
+	}
+	return ti
+}
+
+// write - 원본 쓰기가 성공하고 색인이 적재돼 있으면 색인 갱신
+func (r *UserRepository) write(ctx context.Context, op func() error, apply func(*index)) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		// 테넌트가 없으면 원본 저장소가 에러를 반환하도록 그대로 위임
+		return op()
+	}
+
+	ti := r.indexFor(tenantID)
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if err := op(); err != nil {
+		return err
+	}
+	if ti.loaded {
+		apply(ti.idx)
+	}
+	return nil
+}
+
+// Create - 사용자 생성 후 색인
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.write(ctx,
+		func() error { return r.next.Create(ctx, user) },
+		func(ix *index) { ix.put(user) },
+	)
+}
+
+// GetByID - ID로 사용자 조회 (위임)
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDs - 원본 저장소가 usecase.UserBatchGetter를 구현하면 그대로 위임 (아니면 ID마다 조회)
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.User, error) {
+	if getter, ok := r.next.(usecase.UserBatchGetter); ok {
+		return getter.GetByIDs(ctx, ids)
+	}
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*domain.User, len(ids))
+	for _, id := range ids {
+		user, err := r.next.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrCrossTenant) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users[id] = user
+	}
+	return users, nil
+}
+
+// GetByEmail - 이메일로 사용자 조회 (위임)
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.next.GetByEmail(ctx, email)
+}
+
+// GetAll - 모든 사용자 조회 (위임)
+func (r *UserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	return r.next.GetAll(ctx)
+}
+
+// Update - 사용자 정보 수정 후 재색인
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.write(ctx,
+		func() error { return r.next.Update(ctx, user) },
+		func(ix *index) { ix.put(user) },
+	)
+}
+
+// Delete - 사용자 삭제 후 색인 제거
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	return r.write(ctx,
+		func() error { return r.next.Delete(ctx, id) },
+		func(ix *index) { ix.remove(id) },
+	)
+}
+
+// Stream - 원본 저장소가 usecase.UserStreamer를 구현하면 그대로 위임
+func (r *UserRepository) Stream(ctx context.Context, fn func(*domain.User) error) error {
+	if streamer, ok := r.next.(usecase.UserStreamer); ok {
+		return streamer.Stream(ctx, fn)
+	}
+
+	users, err := r.next.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByAttributes - 원본 저장소가 usecase.UserAttributeQuerier를 구현하면 그대로 위임
+func (r *UserRepository) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error) {
+	if querier, ok := r.next.(usecase.UserAttributeQuerier); ok {
+		return querier.FindByAttributes(ctx, filters)
+	}
+	for _, f := range filters {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		if domain.MatchAttributes(user, filters) {
+			matched = append(matched, user)
+		}
+	}
+	return matched, nil
+}
+
+// Search - 이름/이메일 부분 검색 (관련도 순, 최대 limit명)
+func (r *UserRepository) Search(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ti := r.indexFor(tenantID)
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if !ti.loaded {
+		if err := r.Stream(ctx, func(user *domain.User) error {
+			ti.idx.put(user)
+			return nil
+		}); err != nil {
+			ti.idx = newIndex()
+			return nil, err
+		}
+		ti.loaded = true
+	}
+
+	hits := ti.idx.search(query, limit)
+	users := make([]*domain.User, len(hits))
+	for i, h := range hits {
+		users[i] = h.user.Clone()
+	}
+	return users, nil
+}
+
+// Rebuild - 적재된 테넌트 색인을 원본 저장소에서 다시 만듦 (주기 작업용)
+// 다른 경로로 원본이 바뀌어 색인이 어긋났을 때 바로잡음. 새 색인을 다 만든 뒤 교체하므로
+// 실패한 테넌트는 기존 색인을 유지하며, 적재되지 않은 테넌트는 다음 검색 때 만들어짐
+func (r *UserRepository) Rebuild(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	tenantIDs := make([]string, 0, len(r.tenants))
+	for id := range r.tenants {
+		tenantIDs = append(tenantIDs, id)
+	}
+	r.mu.Unlock()
+
+	rebuilt := 0
+	for _, tenantID := range tenantIDs {
+		if err := ctx.Err(); err != nil {
+			return rebuilt, err
+		}
+		ti := r.indexFor(tenantID)
+		if err := r.rebuildTenant(tenant.WithID(ctx, tenantID), ti); err != nil {
+			return rebuilt, err
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
+
+func (r *UserRepository) rebuildTenant(ctx context.Context, ti *tenantIndex) error {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if !ti.loaded {
+		return nil
+	}
+	idx := newIndex()
+	if err := r.Stream(ctx, func(user *domain.User) error {
+		idx.put(user)
+		return nil
+	}); err != nil {
+		return err
+	}
+	ti.idx = idx
+	return nil
+}
+
+// InTransaction - 원본 저장소가 usecase.UserTransactor를 구현하면 위임 (아니면 usecase.ErrTransactionsUnsupported)
+// 트랜잭션 안의 쓰기는 모아 두었다가 커밋된 뒤에만 색인에 반영
+// 트랜잭션은 컨텍스트의 테넌트 안에서만 쓴다고 보고 그 테넌트의 쓰기를 직렬화
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(tx usecase.UserRepository) error) error {
+	transactor, ok := r.next.(usecase.UserTransactor)
+	if !ok {
+		return usecase.ErrTransactionsUnsupported
+	}
+
+	var pending []func(*index)
+	run := func() error {
+		return transactor.InTransaction(ctx, func(tx usecase.UserRepository) error {
+			return fn(&txRecorder{UserRepository: tx, pending: &pending})
+		})
+	}
+	return r.write(ctx, run, func(ix *index) {
+		for _, apply := range pending {
+			apply(ix)
+		}
+	})
+}
+
+// txRecorder - 트랜잭션 안의 쓰기가 성공하면 색인 갱신을 모아 둠
+type txRecorder struct {
+	usecase.UserRepository
+	pending *[]func(*index)
+}
+
+func (t *txRecorder) Create(ctx context.Context, user *domain.User) error {
+	if err := t.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	user = user.Clone()
+	*t.pending = append(*t.pending, func(ix *index) { ix.put(user) })
+	return nil
+}
+
+func (t *txRecorder) Update(ctx context.Context, user *domain.User) error {
+	if err := t.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	user = user.Clone()
+	*t.pending = append(*t.pending, func(ix *index) { ix.put(user) })
+	return nil
+}
+
+func (t *txRecorder) Delete(ctx context.Context, id string) error {
+	if err := t.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	*t.pending = append(*t.pending, func(ix *index) { ix.remove(id) })
+	return nil
+}
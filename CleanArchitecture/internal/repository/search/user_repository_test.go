@@ -0,0 +1,188 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/memory"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/repotest"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/search"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+func TestUserRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		return search.NewUserRepository(memory.NewUserRepository())
+	})
+}
+
+// seed - 테넌트에 사용자 생성 후 이름 → ID 맵 반환
+func seed(t *testing.T, ctx context.Context, repo usecase.UserRepository, users ...[2]string) map[string]string {
+	t.Helper()
+	tenantID, _ := tenant.FromContext(ctx)
+
+	ids := make(map[string]string)
+	for i, u := range users {
+		user, err := domain.NewUser(u[0], u[1])
+		if err != nil {
+			t.Fatalf("NewUser(%q, %q): %v", u[0], u[1], err)
+		}
+		user.ID = fmt.Sprintf("%s-%d-%s", tenantID, i, u[0])
+		user.TenantID = tenantID
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		ids[u[1]] = user.ID
+	}
+	return ids
+}
+
+func names(users []*domain.User) []string {
+	out := make([]string, len(users))
+	for i, u := range users {
+		out[i] = u.Name
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	repo := search.NewUserRepository(memory.NewUserRepository())
+	seed(t, ctx, repo,
+		[2]string{"minsu.kim@example.com", "김민수"},
+		[2]string{"minji.kim@example.com", "김민지"},
+		[2]string{"seojun.park@example.com", "박서준"},
+		[2]string{"john.doe@example.com", "John Doe"},
+		[2]string{"jane@doe.io", "Jane Smith"},
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string // 순서까지 비교
+	}{
+		{"korean surname prefix", "김", []string{"김민수", "김민지"}},
+		{"korean given name infix", "민수", []string{"김민수"}},
+		{"korean infix prefix", "민", []string{"김민수", "김민지"}},
+		{"korean typo", "박서즌", []string{"박서준"}},
+		{"latin prefix case-insensitive", "JO", []string{"John Doe"}},
+		{"email fragment", "seojun", []string{"박서준"}},
+		{"name beats email", "doe", []string{"John Doe", "Jane Smith"}},
+		{"all terms must match", "kim minji", []string{"김민지"}},
+		{"email with punctuation", "john.doe@example", []string{"John Doe"}},
+		{"latin typo", "smiht", []string{"Jane Smith"}},
+		{"no match", "zzz", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Search(ctx, tt.query, 10)
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			if g := names(got); len(g) != len(tt.want) || (len(g) > 0 && !equal(g, tt.want)) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, g, tt.want)
+			}
+		})
+	}
+}
+
+func equal(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearchTracksWrites(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	repo := search.NewUserRepository(memory.NewUserRepository())
+	ids := seed(t, ctx, repo, [2]string{"a@example.com", "김민수"})
+
+	// 첫 검색에서 색인 적재
+	if got, _ := repo.Search(ctx, "민수", 10); len(got) != 1 {
+		t.Fatalf("before update = %v", names(got))
+	}
+
+	user, _ := repo.GetByID(ctx, ids["김민수"])
+	if err := user.UpdateName("이서연"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := repo.Search(ctx, "민수", 10); len(got) != 0 {
+		t.Fatalf("old name still indexed: %v", names(got))
+	}
+	if got, _ := repo.Search(ctx, "서연", 10); len(got) != 1 {
+		t.Fatalf("new name not indexed: %v", names(got))
+	}
+
+	seed(t, ctx, repo, [2]string{"b@example.com", "이서진"})
+	if got, _ := repo.Search(ctx, "이서", 10); len(got) != 2 {
+		t.Fatalf("created user not indexed: %v", names(got))
+	}
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := repo.Search(ctx, "이서", 10); len(got) != 1 || got[0].Name != "이서진" {
+		t.Fatalf("deleted user still indexed: %v", names(got))
+	}
+}
+
+func TestSearchTenantIsolation(t *testing.T) {
+	ctxA := tenant.WithID(context.Background(), "acme")
+	ctxB := tenant.WithID(context.Background(), "globex")
+	repo := search.NewUserRepository(memory.NewUserRepository())
+	seed(t, ctxA, repo, [2]string{"kim@acme.com", "김민수"})
+	seed(t, ctxB, repo, [2]string{"kim@globex.com", "김민수"})
+
+	got, err := repo.Search(ctxB, "김민수", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].TenantID != "globex" {
+		t.Fatalf("Search in globex = %+v", got)
+	}
+
+	if _, err := repo.Search(context.Background(), "김", 10); err != domain.ErrTenantRequired {
+		t.Fatalf("Search without tenant = %v", err)
+	}
+}
+
+func TestSearchIndexesExistingData(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	base := memory.NewUserRepository()
+	seed(t, ctx, base, [2]string{"a@example.com", "김민수"})
+
+	// 데코레이터를 나중에 씌워도 첫 검색 때 원본에서 색인을 만듦
+	repo := search.NewUserRepository(base)
+	if got, _ := repo.Search(ctx, "민수", 10); len(got) != 1 {
+		t.Fatalf("existing user not indexed: %v", names(got))
+	}
+
+	// 조합형(NFD) 자모로 입력해도 같은 음절로 검색
+	if got, _ := repo.Search(ctx, "\u1100\u1175\u11b7", 10); len(got) != 1 {
+		t.Fatalf("NFD query = %v", names(got))
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	repo := search.NewUserRepository(memory.NewUserRepository())
+	seed(t, ctx, repo,
+		[2]string{"a@example.com", "Kim A"},
+		[2]string{"b@example.com", "Kim B"},
+		[2]string{"c@example.com", "Kim C"},
+	)
+
+	got, _ := repo.Search(ctx, "kim", 2)
+	if g := names(got); len(g) != 2 || g[0] != "Kim A" || g[1] != "Kim B" {
+		t.Fatalf("limited search = %v", g)
+	}
+}
@@ -0,0 +1,107 @@
+package spanner
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const attributeSchemasTable = "attribute_schemas"
+
+var attributeSchemaColumns = []string{"namespace", "schema", "version", "created_at", "updated_at"}
+
+// AttributeSchemaRepository - Spanner 기반 속성 스키마 저장소 (어댑터)
+// 여러 인스턴스가 같은 스키마를 보도록 공유 저장소에 둠
+type AttributeSchemaRepository struct {
+	client *spanner.Client
+}
+
+// NewAttributeSchemaRepository - AttributeSchemaRepository 생성자
+func NewAttributeSchemaRepository(client *spanner.Client) *AttributeSchemaRepository {
+	return &AttributeSchemaRepository{
+		client: client,
+	}
+}
+
+// GetSchema - 네임스페이스 스키마 조회
+func (r *AttributeSchemaRepository) GetSchema(ctx context.Context, namespace string) (*domain.AttributeSchema, error) {
+	row, err := r.client.Single().ReadRow(ctx, attributeSchemasTable, spanner.Key{namespace}, attributeSchemaColumns)
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, domain.ErrSchemaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanSchema(row)
+}
+
+// ListSchemas - 모든 스키마 조회
+func (r *AttributeSchemaRepository) ListSchemas(ctx context.Context) ([]*domain.AttributeSchema, error) {
+	iter := r.client.Single().Read(ctx, attributeSchemasTable, spanner.AllKeys(), attributeSchemaColumns)
+	defer iter.Stop()
+
+	schemas := make([]*domain.AttributeSchema, 0)
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return schemas, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err := scanSchema(row)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+}
+
+// PutSchema - 스키마 등록 또는 교체
+func (r *AttributeSchemaRepository) PutSchema(ctx context.Context, schema *domain.AttributeSchema) error {
+	_, err := r.client.Apply(ctx, []*spanner.Mutation{
+		spanner.InsertOrUpdate(attributeSchemasTable, attributeSchemaColumns, []interface{}{
+			schema.Namespace, string(schema.Schema), int64(schema.Version), schema.CreatedAt, schema.UpdatedAt,
+		}),
+	})
+	return err
+}
+
+// DeleteSchema - 스키마 삭제
+func (r *AttributeSchemaRepository) DeleteSchema(ctx context.Context, namespace string) error {
+	_, err := r.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		_, err := txn.ReadRow(ctx, attributeSchemasTable, spanner.Key{namespace}, []string{"namespace"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return domain.ErrSchemaNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Delete(attributeSchemasTable, spanner.Key{namespace}),
+		})
+	})
+	if errors.Is(err, domain.ErrSchemaNotFound) {
+		return domain.ErrSchemaNotFound
+	}
+	return err
+}
+
+func scanSchema(row *spanner.Row) (*domain.AttributeSchema, error) {
+	var (
+		schema  domain.AttributeSchema
+		raw     string
+		version int64
+	)
+	if err := row.Columns(&schema.Namespace, &raw, &version, &schema.CreatedAt, &schema.UpdatedAt); err != nil {
+		return nil, err
+	}
+	schema.Schema = []byte(raw)
+	schema.Version = int(version)
+	return &schema, nil
+}
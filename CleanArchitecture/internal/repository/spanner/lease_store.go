@@ -0,0 +1,91 @@
+package spanner
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+)
+
+const schedulerLeasesTable = "scheduler_leases"
+
+// LeaseStore - Spanner 기반 작업 리스 저장소 (어댑터, scheduler.LeaseStore 구현)
+// 여러 인스턴스가 같은 스케줄러를 돌릴 때 회차마다 한 곳만 실행하도록 조정
+// 만료 판단은 인스턴스 시계를 쓰므로 인스턴스 간 시계 차이는 리스 여유(LeaseMargin)보다 작아야 함
+type LeaseStore struct {
+	client *spanner.Client
+	now    func() time.Time
+}
+
+// NewLeaseStore - LeaseStore 생성자
+func NewLeaseStore(client *spanner.Client) *LeaseStore {
+	return &LeaseStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Acquire - 읽기-쓰기 트랜잭션으로 작업 회차 리스 획득
+// 기록된 회차가 요청 회차보다 이전이고 기존 리스가 만료/해제됐을 때만 획득
+func (s *LeaseStore) Acquire(ctx context.Context, job, holder string, occurrence time.Time, ttl time.Duration) (bool, error) {
+	var acquired bool
+	_, err := s.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		acquired = false
+		now := s.now()
+
+		row, err := txn.ReadRow(ctx, schedulerLeasesTable, spanner.Key{job}, []string{"occurrence", "expires_at"})
+		switch {
+		case spanner.ErrCode(err) == codes.NotFound:
+		case err != nil:
+			return err
+		default:
+			var recorded, expiresAt time.Time
+			if err := row.Columns(&recorded, &expiresAt); err != nil {
+				return err
+			}
+			if !recorded.Before(occurrence) || now.Before(expiresAt) {
+				return nil
+			}
+		}
+
+		acquired = true
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.InsertOrUpdate(schedulerLeasesTable,
+				[]string{"job", "holder", "occurrence", "expires_at"},
+				[]interface{}{job, holder, occurrence, now.Add(ttl)},
+			),
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return acquired, nil
+}
+
+// Release - holder가 같으면 리스를 만료시킴 (회차 기록은 유지)
+func (s *LeaseStore) Release(ctx context.Context, job, holder string) error {
+	_, err := s.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, schedulerLeasesTable, spanner.Key{job}, []string{"holder"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var current string
+		if err := row.Columns(&current); err != nil {
+			return err
+		}
+		if current != holder {
+			return nil
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Update(schedulerLeasesTable,
+				[]string{"job", "expires_at"},
+				[]interface{}{job, s.now()},
+			),
+		})
+	})
+	return err
+}
@@ -0,0 +1,61 @@
+package spanner
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/ratelimit"
+	"google.golang.org/grpc/codes"
+)
+
+const rateLimitsTable = "rate_limits"
+
+// RateLimitStore - Spanner 기반 속도 제한 저장소 (어댑터)
+// 여러 인스턴스가 같은 카운터를 공유해야 할 때 사용
+type RateLimitStore struct {
+	client *spanner.Client
+}
+
+// NewRateLimitStore - RateLimitStore 생성자
+func NewRateLimitStore(client *spanner.Client) *RateLimitStore {
+	return &RateLimitStore{
+		client: client,
+	}
+}
+
+// Update - 읽기-쓰기 트랜잭션으로 키의 TAT 원자적 갱신
+// 트랜잭션이 중단되면 Spanner 클라이언트가 fn을 다시 호출
+func (s *RateLimitStore) Update(ctx context.Context, key string, fn ratelimit.UpdateFunc) error {
+	_, err := s.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		var (
+			tat    time.Time
+			exists bool
+		)
+
+		row, err := txn.ReadRow(ctx, rateLimitsTable, spanner.Key{key}, []string{"tat"})
+		switch {
+		case spanner.ErrCode(err) == codes.NotFound:
+		case err != nil:
+			return err
+		default:
+			if err := row.Columns(&tat); err != nil {
+				return err
+			}
+			exists = true
+		}
+
+		next, store := fn(tat, exists)
+		if !store {
+			return nil
+		}
+
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.InsertOrUpdate(rateLimitsTable,
+				[]string{"key", "tat", "expires_at"},
+				[]interface{}{key, next, next},
+			),
+		})
+	})
+	return err
+}
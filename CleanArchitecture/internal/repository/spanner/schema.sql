@@ -0,0 +1,84 @@
+-- ============================================================================
+-- Clean Architecture - Spanner 어댑터용 스키마
+-- ============================================================================
+--
+-- internal/repository/spanner 패키지의 어댑터가 사용하는 테이블
+--
+-- ============================================================================
+
+-- ============================================================================
+-- Rate Limits Table (ratelimit.Store)
+-- ============================================================================
+--
+-- 키별 GCRA TAT(theoretical arrival time) 저장
+-- expires_at이 지난 행은 상태가 없는 것과 같으므로 TTL 정책으로 자동 삭제
+--
+CREATE TABLE rate_limits (
+  key STRING(MAX) NOT NULL,
+  tat TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (key),
+  ROW DELETION POLICY (OLDER_THAN(expires_at, INTERVAL 1 DAY));
+
+-- ============================================================================
+-- Scheduler Leases Table (scheduler.LeaseStore)
+-- ============================================================================
+--
+-- 작업별 마지막 회차와 리스 (한 회차는 한 인스턴스만 실행)
+-- 행은 작업 이름당 하나이며 해제해도 occurrence를 남겨 같은 회차를 다시 실행하지 않음
+--
+CREATE TABLE scheduler_leases (
+  job STRING(MAX) NOT NULL,
+  holder STRING(MAX) NOT NULL,
+  occurrence TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (job);
+
+-- ============================================================================
+-- Users Table (spanner.UserRepository)
+-- ============================================================================
+--
+-- 이메일은 테넌트 안에서만 고유 (users_by_email)
+-- email, name: 필드 암호화(KEYRING_FILE)를 켜면 봉투 "v1.<키 ID>.<감싼 DEK>.<암호문>", 끄면 평문
+-- email_index: 이메일 블라인드 인덱스 (HMAC-SHA256 16진수, 테넌트마다 다름), 암호화를 끄면 평문 이메일
+--   GetByEmail과 테넌트 안 고유성 검사는 이 컬럼으로 함 (암호문은 매번 달라 비교할 수 없음)
+--   평문이나 이전 키로 암호화된 행은 읽을 때 현재 키로 다시 씀
+--   기존 데이터베이스: email을 STRING(MAX)로 늘리고 email_index를 추가해 email 값으로 채운 뒤
+--   NOT NULL 지정, users_by_email을 (tenant_id, email_index)로 다시 만듦
+-- attributes: 네임스페이스 → 필드 → 값 JSON 객체 (attribute_schemas로 검증)
+--   예: {"i18n": {"locale": "ko-KR", "timezone": "Asia/Seoul"}, "hr": {"department": "eng"}}
+--   목록 조건은 JSON_VALUE(attributes, '$."hr"."department"')로 거름
+-- status: pending | active | suspended | deactivated (전이 규칙은 domain.UserStatus)
+-- status_history: 상태 변경 기록 배열 [{"from", "to", "reason", "actor", "at"}]
+--
+CREATE TABLE users (
+  id STRING(36) NOT NULL,
+  tenant_id STRING(63) NOT NULL,
+  email STRING(MAX) NOT NULL,
+  email_index STRING(320) NOT NULL,
+  name STRING(MAX) NOT NULL,
+  attributes JSON,
+  status STRING(16) NOT NULL DEFAULT ('active'),
+  status_history JSON,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX users_by_email ON users(tenant_id, email_index)
+  STORING (email, name, attributes, status, status_history, created_at, updated_at);
+
+CREATE INDEX users_by_tenant ON users(tenant_id, created_at);
+
+-- ============================================================================
+-- Attribute Schemas Table (spanner.AttributeSchemaRepository)
+-- ============================================================================
+--
+-- 속성 네임스페이스별 JSON Schema 원문 (관리자가 등록, 등록할 때마다 version 증가)
+--
+CREATE TABLE attribute_schemas (
+  namespace STRING(63) NOT NULL,
+  schema STRING(MAX) NOT NULL,
+  version INT64 NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (namespace);
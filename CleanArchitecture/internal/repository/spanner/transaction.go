@@ -0,0 +1,138 @@
+package spanner
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+	"google.golang.org/grpc/codes"
+)
+
+var _ reader = (*spanner.ReadWriteTransaction)(nil)
+
+// InTransaction - fn의 읽기와 쓰기를 읽기-쓰기 트랜잭션 하나에서 실행 (usecase.UserTransactor 구현)
+// 쓰기는 뮤테이션이 아닌 DML로 하므로 fn 안에서 자기 변경이 바로 보임
+// 읽은 행은 커밋까지 잠기므로, 그 사이 다른 인스턴스가 같은 사용자를 바꾸면 한쪽이 중단되고
+// Spanner 클라이언트가 fn을 처음부터 다시 실행함 (fn은 tx로 읽은 값만으로 동작해야 함)
+// fn이 에러를 반환하면 아무것도 반영하지 않고 그 에러를 그대로 반환
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(tx usecase.UserRepository) error) error {
+	var fnErr error
+	_, err := r.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		fnErr = fn(&userTx{r: r, txn: txn})
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+// userTx - 트랜잭션 안에서 쓰는 저장소 (InTransaction의 fn 안에서만 유효)
+// 지연 재암호화는 하지 않음 (트랜잭션 밖의 쓰기가 되므로; 다음 트랜잭션 밖 조회 때 처리)
+type userTx struct {
+	r   *UserRepository
+	txn *spanner.ReadWriteTransaction
+}
+
+func (t *userTx) Create(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	values, err := t.r.userValues(user)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]interface{}, len(userColumns))
+	names := make([]string, len(userColumns))
+	for i, column := range userColumns {
+		params[column] = values[i]
+		names[i] = "@" + column
+	}
+	_, err = t.txn.Update(ctx, spanner.Statement{
+		SQL: "INSERT INTO " + usersTable + " (" + strings.Join(userColumns, ", ") + ") VALUES (" +
+			strings.Join(names, ", ") + ")",
+		Params: params,
+	})
+	if spanner.ErrCode(err) == codes.AlreadyExists {
+		return domain.ErrUserExists
+	}
+	return err
+}
+
+func (t *userTx) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	user, _, err := t.r.readByID(ctx, t.txn, id)
+	return user, err
+}
+
+func (t *userTx) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, _, err := t.r.readByEmail(ctx, t.txn, email)
+	return user, err
+}
+
+func (t *userTx) GetAll(ctx context.Context) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0)
+	_, err = t.r.scan(ctx, t.txn, tenantUsers(tenantID), func(user *domain.User) error {
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (t *userTx) Update(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+	if err := checkOwner(ctx, t.txn, user.ID, user.TenantID); err != nil {
+		return err
+	}
+
+	values, err := t.r.userValues(user)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]interface{}, len(userColumns))
+	sets := make([]string, 0, len(userColumns)-1)
+	for i, column := range userColumns {
+		params[column] = values[i]
+		if column != "id" {
+			sets = append(sets, column+" = @"+column)
+		}
+	}
+	_, err = t.txn.Update(ctx, spanner.Statement{
+		SQL:    "UPDATE " + usersTable + " SET " + strings.Join(sets, ", ") + " WHERE id = @id",
+		Params: params,
+	})
+	if spanner.ErrCode(err) == codes.AlreadyExists {
+		// 이메일 변경이 테넌트 안 고유 인덱스와 충돌
+		return domain.ErrUserExists
+	}
+	return err
+}
+
+func (t *userTx) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkOwner(ctx, t.txn, id, tenantID); err != nil {
+		return err
+	}
+
+	_, err = t.txn.Update(ctx, spanner.Statement{
+		SQL:    "DELETE FROM " + usersTable + " WHERE id = @id",
+		Params: map[string]interface{}{"id": id},
+	})
+	return err
+}
@@ -0,0 +1,537 @@
+package spanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	usersTable        = "users"
+	usersByEmailIndex = "users_by_email"
+)
+
+var userColumns = []string{"id", "tenant_id", "email", "email_index", "name", "attributes", "status", "status_history", "created_at", "updated_at"}
+
+// UserRepository - Spanner 기반 사용자 리포지토리 (어댑터)
+// 사용자 정의 속성은 JSON 컬럼에 저장하고, 속성 조건 조회는 JSON_VALUE로 서버에서 거름
+// 모든 조회/변경은 컨텍스트의 테넌트로 범위가 제한됨
+// Cipher가 있으면 이메일과 이름을 암호화해 저장하고 email_index에 블라인드 인덱스를 둠 (없으면 평문 이메일)
+type UserRepository struct {
+	client *spanner.Client
+	cipher *fieldcrypt.Cipher
+}
+
+// Option - UserRepository 설정 옵션
+type Option func(*UserRepository)
+
+// WithCipher - 이메일과 이름 필드 암호화
+// 평문이나 이전 키로 암호화된 행은 읽을 때 현재 키로 다시 씀
+func WithCipher(c *fieldcrypt.Cipher) Option {
+	return func(r *UserRepository) {
+		r.cipher = c
+	}
+}
+
+// NewUserRepository - UserRepository 생성자
+func NewUserRepository(client *spanner.Client, opts ...Option) *UserRepository {
+	r := &UserRepository{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Create - 사용자 생성 (ID 또는 테넌트 안 이메일이 겹치면 ErrUserExists)
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	values, err := r.userValues(user)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert(usersTable, userColumns, values),
+	})
+	if spanner.ErrCode(err) == codes.AlreadyExists {
+		return domain.ErrUserExists
+	}
+	return err
+}
+
+// reader - 단일 읽기(client.Single)와 읽기-쓰기 트랜잭션이 공통으로 가진 읽기 메서드
+type reader interface {
+	ReadRow(ctx context.Context, table string, key spanner.Key, columns []string) (*spanner.Row, error)
+	ReadRowUsingIndex(ctx context.Context, table, index string, key spanner.Key, columns []string) (*spanner.Row, error)
+	Query(ctx context.Context, statement spanner.Statement) *spanner.RowIterator
+}
+
+// GetByID - ID로 사용자 조회
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	user, stale, err := r.readByID(ctx, r.client.Single(), id)
+	if err != nil {
+		return nil, err
+	}
+	r.reseal(ctx, stale)
+	return user, nil
+}
+
+// readByID - rd로 ID 조회 (다시 암호화할 행이면 staleRow도 반환)
+func (r *UserRepository) readByID(ctx context.Context, rd reader, id string) (*domain.User, *staleRow, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	row, err := rd.ReadRow(ctx, usersTable, spanner.Key{id}, userColumns)
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, stale, err := r.scanUser(row)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tenant.Check(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	return user, stale, nil
+}
+
+// GetByEmail - 이메일로 사용자 조회 (테넌트 + 이메일 인덱스 고유 인덱스 사용)
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, stale, err := r.readByEmail(ctx, r.client.Single(), email)
+	if err != nil {
+		return nil, err
+	}
+	r.reseal(ctx, stale)
+	return user, nil
+}
+
+// readByEmail - rd로 이메일 조회
+// 암호화를 켠 뒤 아직 다시 쓰지 않은 평문 행은 평문 이메일 인덱스로 한 번 더 찾음
+func (r *UserRepository) readByEmail(ctx context.Context, rd reader, email string) (*domain.User, *staleRow, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index := email
+	if r.cipher != nil {
+		if index, err = r.cipher.EmailIndex(tenantID, email); err != nil {
+			return nil, nil, err
+		}
+	}
+	row, err := rd.ReadRowUsingIndex(ctx, usersTable, usersByEmailIndex,
+		spanner.Key{tenantID, index}, userColumns)
+	if spanner.ErrCode(err) == codes.NotFound && index != email {
+		row, err = rd.ReadRowUsingIndex(ctx, usersTable, usersByEmailIndex,
+			spanner.Key{tenantID, email}, userColumns)
+	}
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.scanUser(row)
+}
+
+// GetAll - 테넌트의 모든 사용자 조회 (생성순)
+func (r *UserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	users := make([]*domain.User, 0)
+	err := r.Stream(ctx, func(user *domain.User) error {
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Stream - 사용자를 한 명씩 fn에 전달 (usecase.UserStreamer 구현)
+func (r *UserRepository) Stream(ctx context.Context, fn func(*domain.User) error) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.query(ctx, tenantUsers(tenantID), fn)
+}
+
+// tenantUsers - 테넌트의 모든 사용자 (생성순)
+func tenantUsers(tenantID string) spanner.Statement {
+	return spanner.Statement{
+		SQL: "SELECT " + strings.Join(userColumns, ", ") + " FROM " + usersTable +
+			" WHERE tenant_id = @tenant ORDER BY created_at, id",
+		Params: map[string]interface{}{"tenant": tenantID},
+	}
+}
+
+// FindByAttributes - 속성 조건을 모두 만족하는 테넌트 사용자 (usecase.UserAttributeQuerier 구현)
+// SQL에서는 JSON 텍스트 표현으로 후보를 좁히고(스칼라 또는 배열 원소),
+// 숫자/불리언 비교 규칙은 domain.AttributeFilter.Match로 다시 확인
+func (r *UserRepository) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := attributeQuery(tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0)
+	err = r.query(ctx, stmt, func(user *domain.User) error {
+		if domain.MatchAttributes(user, filters) {
+			users = append(users, user)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// attributeQuery - 속성 조건 SQL 생성
+// JSON 경로는 매개변수로 넘길 수 없으므로 리터럴로 넣되, 이름은 Validate로 식별자 형태만 허용
+func attributeQuery(tenantID string, filters []domain.AttributeFilter) (spanner.Statement, error) {
+	var sql strings.Builder
+	sql.WriteString("SELECT " + strings.Join(userColumns, ", ") + " FROM " + usersTable + " WHERE tenant_id = @tenant")
+	params := map[string]interface{}{"tenant": tenantID}
+
+	for i, f := range filters {
+		if err := f.Validate(); err != nil {
+			return spanner.Statement{}, err
+		}
+		path := fmt.Sprintf(`'$."%s"."%s"'`, f.Namespace, f.Field)
+		param := "v" + strconv.Itoa(i)
+		fmt.Fprintf(&sql,
+			" AND (JSON_VALUE(attributes, %[1]s) IN UNNEST(@%[2]s)"+
+				" OR EXISTS (SELECT 1 FROM UNNEST(JSON_VALUE_ARRAY(attributes, %[1]s)) AS e WHERE e IN UNNEST(@%[2]s)))",
+			path, param)
+		params[param] = filterCandidates(f.Value)
+	}
+	sql.WriteString(" ORDER BY created_at, id")
+
+	return spanner.Statement{SQL: sql.String(), Params: params}, nil
+}
+
+// filterCandidates - JSON_VALUE 결과와 비교할 텍스트 후보 ("30.0"은 숫자 30의 표현 "30"도 포함)
+func filterCandidates(value string) []string {
+	candidates := []string{value}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		if canonical := strconv.FormatFloat(f, 'f', -1, 64); canonical != value {
+			candidates = append(candidates, canonical)
+		}
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		if canonical := strconv.FormatBool(b); canonical != value {
+			candidates = append(candidates, canonical)
+		}
+	}
+	return candidates
+}
+
+// Update - 사용자 정보 수정 (다른 테넌트 행이면 ErrCrossTenant)
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := tenant.Check(ctx, user); err != nil {
+		return err
+	}
+
+	values, err := r.userValues(user)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		if err := checkOwner(ctx, txn, user.ID, user.TenantID); err != nil {
+			return err
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Update(usersTable, userColumns, values),
+		})
+	})
+	if spanner.ErrCode(err) == codes.AlreadyExists {
+		// 이메일 변경이 테넌트 안 고유 인덱스와 충돌
+		return domain.ErrUserExists
+	}
+	return unwrapDomain(err)
+}
+
+// Delete - 사용자 삭제
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		if err := checkOwner(ctx, txn, id, tenantID); err != nil {
+			return err
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Delete(usersTable, spanner.Key{id}),
+		})
+	})
+	return unwrapDomain(err)
+}
+
+// checkOwner - 행이 있고 같은 테넌트 소속인지 트랜잭션 안에서 확인
+func checkOwner(ctx context.Context, txn *spanner.ReadWriteTransaction, id, tenantID string) error {
+	row, err := txn.ReadRow(ctx, usersTable, spanner.Key{id}, []string{"tenant_id"})
+	if spanner.ErrCode(err) == codes.NotFound {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var owner string
+	if err := row.Columns(&owner); err != nil {
+		return err
+	}
+	if owner != tenantID {
+		return domain.ErrCrossTenant
+	}
+	return nil
+}
+
+// unwrapDomain - 트랜잭션 함수가 반환한 도메인 에러를 꺼냄 (Spanner 클라이언트가 감싸서 반환)
+func unwrapDomain(err error) error {
+	for _, target := range []error{domain.ErrUserNotFound, domain.ErrCrossTenant} {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+	return err
+}
+
+// query - 쿼리 결과를 사용자로 변환해 fn에 전달
+// 다시 암호화할 행은 모아 두었다가 스트림이 끝난 뒤 씀 (읽기 전용 트랜잭션 안에서 쓰지 않음)
+func (r *UserRepository) query(ctx context.Context, stmt spanner.Statement, fn func(*domain.User) error) error {
+	stale, err := r.scan(ctx, r.client.Single(), stmt, fn)
+	r.reseal(ctx, stale...)
+	return err
+}
+
+// scan - rd로 쿼리해 사용자로 변환해 fn에 전달하고, 다시 암호화할 행 반환
+func (r *UserRepository) scan(ctx context.Context, rd reader, stmt spanner.Statement, fn func(*domain.User) error) ([]*staleRow, error) {
+	iter := rd.Query(ctx, stmt)
+	defer iter.Stop()
+
+	var stale []*staleRow
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return stale, nil
+		}
+		if err != nil {
+			return stale, err
+		}
+
+		user, s, err := r.scanUser(row)
+		if err != nil {
+			return stale, err
+		}
+		if s != nil {
+			stale = append(stale, s)
+		}
+		if err := fn(user); err != nil {
+			return stale, err
+		}
+	}
+}
+
+// staleRow - 평문이거나 이전 키로 암호화되어 다시 써야 하는 행
+type staleRow struct {
+	user  domain.User // 읽은 시점의 복사본 (fn이 바꿔도 영향 없음)
+	email string      // 읽은 email 컬럼 값 (다시 쓰기 전에 그 사이 바뀌지 않았는지 비교)
+}
+
+// reseal - 행의 이메일과 이름을 현재 키로 다시 암호화 (지연 재암호화)
+// 그 사이 다른 요청이 행을 고쳤으면 이미 현재 키로 쓰였으므로 건너뜀
+// 실패해도 조회 결과에는 영향이 없으므로 기록만 하고 다음 조회 때 다시 시도
+func (r *UserRepository) reseal(ctx context.Context, rows ...*staleRow) {
+	for _, s := range rows {
+		if s == nil {
+			continue
+		}
+		sealed, err := r.cipher.SealUser(&s.user)
+		if err != nil {
+			log.Printf("spanner: reseal user %s: %v", s.user.ID, err)
+			continue
+		}
+		_, err = r.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+			row, err := txn.ReadRow(ctx, usersTable, spanner.Key{s.user.ID}, []string{"email"})
+			if spanner.ErrCode(err) == codes.NotFound {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			var current string
+			if err := row.Columns(&current); err != nil {
+				return err
+			}
+			if current != s.email {
+				return nil
+			}
+			return txn.BufferWrite([]*spanner.Mutation{
+				spanner.Update(usersTable, []string{"id", "email", "email_index", "name"},
+					[]interface{}{s.user.ID, sealed.Email, sealed.EmailIndex, sealed.Name}),
+			})
+		})
+		if err != nil {
+			log.Printf("spanner: reseal user %s: %v", s.user.ID, err)
+		}
+	}
+}
+
+// userValues - userColumns 순서의 컬럼 값 (Cipher가 있으면 이메일과 이름은 봉투)
+func (r *UserRepository) userValues(user *domain.User) ([]interface{}, error) {
+	sealed := fieldcrypt.SealedUser{Email: user.Email, EmailIndex: user.Email, Name: user.Name}
+	if r.cipher != nil {
+		var err error
+		if sealed, err = r.cipher.SealUser(user); err != nil {
+			return nil, err
+		}
+	}
+	return []interface{}{
+		user.ID,
+		user.TenantID,
+		sealed.Email,
+		sealed.EmailIndex,
+		sealed.Name,
+		spanner.NullJSON{Value: user.Attributes, Valid: len(user.Attributes) > 0},
+		string(user.CurrentStatus()),
+		spanner.NullJSON{Value: toStatusRecords(user.StatusHistory), Valid: len(user.StatusHistory) > 0},
+		user.CreatedAt,
+		user.UpdatedAt,
+	}, nil
+}
+
+// scanUser - 행을 사용자로 변환 (암호화된 이메일과 이름은 풀어서 채움)
+// 다시 암호화해야 하는 행이면 staleRow도 반환
+func (r *UserRepository) scanUser(row *spanner.Row) (*domain.User, *staleRow, error) {
+	var (
+		user    domain.User
+		sealed  fieldcrypt.SealedUser
+		attrs   spanner.NullJSON
+		status  string
+		history spanner.NullJSON
+	)
+	if err := row.Columns(&user.ID, &user.TenantID, &sealed.Email, &sealed.EmailIndex, &sealed.Name, &attrs, &status, &history,
+		&user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, nil, err
+	}
+
+	var err error
+	if user.Attributes, err = toAttributes(attrs); err != nil {
+		return nil, nil, fmt.Errorf("user %s: %w", user.ID, err)
+	}
+	user.Status = domain.UserStatus(status)
+	if user.StatusHistory, err = toStatusHistory(history); err != nil {
+		return nil, nil, fmt.Errorf("user %s: %w", user.ID, err)
+	}
+
+	stale := true
+	switch {
+	case fieldcrypt.IsSealed(sealed.Email):
+		if r.cipher == nil {
+			return nil, nil, fmt.Errorf("user %s: %w", user.ID, fieldcrypt.ErrCipherRequired)
+		}
+		if stale, err = r.cipher.OpenUser(&user, sealed); err != nil {
+			return nil, nil, fmt.Errorf("user %s: %w", user.ID, err)
+		}
+	default:
+		// 암호화를 켜기 전에 쓴 평문 행
+		user.Email, user.Name = sealed.Email, sealed.Name
+		stale = r.cipher != nil
+	}
+	if !stale {
+		return &user, nil, nil
+	}
+	return &user, &staleRow{user: user, email: sealed.Email}, nil
+}
+
+// statusChangeRecord - status_history 컬럼의 배열 원소 형식
+type statusChangeRecord struct {
+	From   domain.UserStatus `json:"from"`
+	To     domain.UserStatus `json:"to"`
+	Reason string            `json:"reason,omitempty"`
+	Actor  string            `json:"actor"`
+	At     time.Time         `json:"at"`
+}
+
+func toStatusRecords(history []domain.StatusChange) []statusChangeRecord {
+	records := make([]statusChangeRecord, len(history))
+	for i, c := range history {
+		records[i] = statusChangeRecord(c)
+	}
+	return records
+}
+
+// toStatusHistory - JSON 컬럼 값을 상태 변경 기록으로 변환
+// NullJSON은 일반 JSON 값으로 디코딩되므로 다시 인코딩해 구조체로 읽음
+func toStatusHistory(history spanner.NullJSON) ([]domain.StatusChange, error) {
+	if !history.Valid || history.Value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(history.Value)
+	if err != nil {
+		return nil, err
+	}
+	var records []statusChangeRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("status_history column: %w", err)
+	}
+
+	out := make([]domain.StatusChange, len(records))
+	for i, rec := range records {
+		out[i] = domain.StatusChange(rec)
+	}
+	return out, nil
+}
+
+// toAttributes - JSON 컬럼 값을 도메인 속성으로 변환 (최상위는 네임스페이스 → 객체)
+func toAttributes(attrs spanner.NullJSON) (domain.Attributes, error) {
+	if !attrs.Valid || attrs.Value == nil {
+		return nil, nil
+	}
+	raw, ok := attrs.Value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("attributes column is not a JSON object")
+	}
+
+	out := make(domain.Attributes, len(raw))
+	for namespace, values := range raw {
+		m, ok := values.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("attributes namespace %q is not a JSON object", namespace)
+		}
+		out[namespace] = m
+	}
+	return out, nil
+}
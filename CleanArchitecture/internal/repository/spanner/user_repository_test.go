@@ -0,0 +1,143 @@
+package spanner_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/milman2/go-api/clean-architecture/internal/fieldcrypt"
+	"github.com/milman2/go-api/clean-architecture/internal/repository/repotest"
+	spannerRepo "github.com/milman2/go-api/clean-architecture/internal/repository/spanner"
+	"github.com/milman2/go-api/clean-architecture/internal/usecase"
+)
+
+// 에뮬레이터 프로젝트 (에뮬레이터는 아무 프로젝트 이름이나 받음)
+const emulatorProject = "clean-architecture-test"
+
+// emulatorClient - SPANNER_EMULATOR_HOST의 에뮬레이터에 schema.sql로 새 데이터베이스를 만들고 클라이언트 반환
+// 환경 변수가 없으면 건너뜀 (예: gcloud emulators spanner start 후 SPANNER_EMULATOR_HOST=localhost:9010)
+func emulatorClient(t *testing.T) *spanner.Client {
+	t.Helper()
+
+	if os.Getenv("SPANNER_EMULATOR_HOST") == "" {
+		t.Skip("SPANNER_EMULATOR_HOST not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	instanceName := "projects/" + emulatorProject + "/instances/test"
+	instances, err := instance.NewInstanceAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("NewInstanceAdminClient: %v", err)
+	}
+	defer instances.Close()
+	if _, err := instances.GetInstance(ctx, &instancepb.GetInstanceRequest{Name: instanceName}); err != nil {
+		op, err := instances.CreateInstance(ctx, &instancepb.CreateInstanceRequest{
+			Parent:     "projects/" + emulatorProject,
+			InstanceId: "test",
+			Instance: &instancepb.Instance{
+				Config:      "projects/" + emulatorProject + "/instanceConfigs/emulator-config",
+				DisplayName: "test",
+				NodeCount:   1,
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateInstance: %v", err)
+		}
+		if _, err := op.Wait(ctx); err != nil {
+			t.Fatalf("CreateInstance: %v", err)
+		}
+	}
+
+	statements, err := schemaStatements()
+	if err != nil {
+		t.Fatalf("schema.sql: %v", err)
+	}
+	databases, err := database.NewDatabaseAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("NewDatabaseAdminClient: %v", err)
+	}
+	defer databases.Close()
+	databaseID := fmt.Sprintf("test-%d", time.Now().UnixNano()%1e9)
+	op, err := databases.CreateDatabase(ctx, &databasepb.CreateDatabaseRequest{
+		Parent:          instanceName,
+		CreateStatement: "CREATE DATABASE `" + databaseID + "`",
+		ExtraStatements: statements,
+	})
+	if err != nil {
+		t.Fatalf("CreateDatabase: %v", err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		t.Fatalf("CreateDatabase: %v", err)
+	}
+
+	client, err := spanner.NewClient(context.Background(), instanceName+"/databases/"+databaseID)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// schemaStatements - schema.sql을 DDL 문장으로 나눔 (-- 주석 제거, ;로 구분)
+func schemaStatements() ([]string, error) {
+	data, err := os.ReadFile("schema.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var sql strings.Builder
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "--"); i >= 0 {
+			line = line[:i]
+		}
+		sql.WriteString(line + "\n")
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(sql.String(), ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements, nil
+}
+
+// clearUsers - 서브테스트마다 빈 저장소에서 시작하도록 users 테이블 비움
+func clearUsers(t *testing.T, client *spanner.Client) {
+	t.Helper()
+
+	_, err := client.Apply(context.Background(), []*spanner.Mutation{spanner.Delete("users", spanner.AllKeys())})
+	if err != nil {
+		t.Fatalf("clear users: %v", err)
+	}
+}
+
+func TestUserRepository(t *testing.T) {
+	client := emulatorClient(t)
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		clearUsers(t, client)
+		return spannerRepo.NewUserRepository(client)
+	})
+}
+
+func TestEncryptedUserRepository(t *testing.T) {
+	client := emulatorClient(t)
+	repotest.Run(t, func(t *testing.T) usecase.UserRepository {
+		clearUsers(t, client)
+		keys, err := fieldcrypt.OpenFileKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+		if err != nil {
+			t.Fatalf("OpenFileKeyring: %v", err)
+		}
+		return spannerRepo.NewUserRepository(client, spannerRepo.WithCipher(fieldcrypt.New(keys)))
+	})
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestScheduleNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		from string
+		want string
+	}{
+		{"* * * * *", "2026-10-19 09:15:30", "2026-10-19 09:16:00"},
+		{"*/15 * * * *", "2026-10-19 09:15:00", "2026-10-19 09:30:00"},
+		{"*/15 * * * *", "2026-10-19 09:50:00", "2026-10-19 10:00:00"},
+		{"30 3 * * *", "2026-10-19 03:30:00", "2026-10-20 03:30:00"},
+		{"0 9 * * MON-FRI", "2026-10-23 10:00:00", "2026-10-26 09:00:00"}, // 금 → 월
+		{"0 0 * * 7", "2026-10-19 00:00:00", "2026-10-25 00:00:00"},       // 7 = 일요일
+		{"0 0 1,15 * *", "2026-10-02 00:00:00", "2026-10-15 00:00:00"},
+		{"0 0 31 * *", "2026-11-01 00:00:00", "2026-12-31 00:00:00"}, // 11월은 31일 없음
+		{"0 0 29 FEB *", "2026-03-01 00:00:00", "2028-02-29 00:00:00"},
+		{"5/20 * * * *", "2026-10-19 09:00:00", "2026-10-19 09:05:00"},
+		{"0 12 13 * FRI", "2026-10-19 00:00:00", "2026-10-23 12:00:00"}, // 일/요일 둘 다 지정 → OR
+		{"@daily", "2026-12-31 23:59:59", "2027-01-01 00:00:00"},
+		{"@hourly", "2026-10-19 09:59:00", "2026-10-19 10:00:00"},
+		{"@every 10m", "2026-10-19 09:03:12", "2026-10-19 09:10:00"},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.expr, err)
+		}
+		if got := s.Next(mustTime(t, tt.from)); !got.Equal(mustTime(t, tt.want)) {
+			t.Errorf("%q from %s: got %s, want %s", tt.expr, tt.from, got.Format(time.DateTime), tt.want)
+		}
+	}
+}
+
+func TestScheduleLocation(t *testing.T) {
+	seoul := time.FixedZone("KST", 9*60*60)
+	s, err := ParseSchedule("0 9 * * *", seoul)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 09:00 KST = 00:00 UTC
+	got := s.Next(mustTime(t, "2026-10-19 01:00:00"))
+	if want := mustTime(t, "2026-10-20 00:00:00"); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestScheduleNeverMatches(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 FEB *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(mustTime(t, "2026-01-01 00:00:00")); !got.IsZero() {
+		t.Fatalf("got %s, want zero", got)
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * FOO *",
+		"@every 10ms",
+		"@every soon",
+		"@fortnightly",
+	} {
+		if _, err := ParseSchedule(expr, nil); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%q: got %v, want ErrInvalidSchedule", expr, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RunStatus - 실행 결과
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	RunTimedOut  RunStatus = "timed_out" // 제한 시간 초과 (작업이 컨텍스트를 무시하면 끝날 때까지 리스를 유지)
+	RunPanicked  RunStatus = "panicked"
+)
+
+// Run - 실행 기록 한 건
+type Run struct {
+	ID         string
+	Job        string
+	Holder     string    // 실행한 인스턴스
+	Occurrence time.Time // 예정 시각 (수동 실행은 요청 시각)
+	Manual     bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Status     RunStatus
+	Error      string
+}
+
+// Duration - 실행 시간
+func (r *Run) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// HistoryStore - 실행 기록 저장소 (포트)
+type HistoryStore interface {
+	Record(ctx context.Context, run *Run) error
+	// List - 작업의 최근 기록 (최신순, 최대 limit건)
+	List(ctx context.Context, job string, limit int) ([]*Run, error)
+}
+
+// MemoryHistory - 메모리 기반 실행 기록 (작업마다 최근 keep건만 보관)
+type MemoryHistory struct {
+	mu   sync.Mutex
+	keep int
+	runs map[string][]*Run // 오래된 것부터
+}
+
+// NewMemoryHistory - MemoryHistory 생성자 (keep이 0 이하면 100)
+func NewMemoryHistory(keep int) *MemoryHistory {
+	if keep <= 0 {
+		keep = 100
+	}
+	return &MemoryHistory{
+		keep: keep,
+		runs: make(map[string][]*Run),
+	}
+}
+
+// Record - 기록 추가 (보관 수를 넘으면 가장 오래된 것부터 버림)
+func (h *MemoryHistory) Record(ctx context.Context, run *Run) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cp := *run
+	runs := append(h.runs[run.Job], &cp)
+	if len(runs) > h.keep {
+		runs = append([]*Run(nil), runs[len(runs)-h.keep:]...)
+	}
+	h.runs[run.Job] = runs
+	return nil
+}
+
+// List - 최근 기록 (최신순)
+func (h *MemoryHistory) List(ctx context.Context, job string, limit int) ([]*Run, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	runs := h.runs[job]
+	if limit <= 0 || limit > len(runs) {
+		limit = len(runs)
+	}
+	out := make([]*Run, 0, limit)
+	for i := len(runs) - 1; i >= 0 && len(out) < limit; i-- {
+		cp := *runs[i]
+		out = append(out, &cp)
+	}
+	return out, nil
+}
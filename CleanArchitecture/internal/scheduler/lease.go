@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LeaseStore - 작업 리스 저장소 (포트)
+// 여러 인스턴스 중 한 곳만 작업의 한 회차를 실행하도록 조정한다.
+//
+// Acquire는 다음을 모두 만족할 때만 리스를 주고 true를 반환해야 함 (원자적으로):
+//   - 기록된 회차(occurrence)가 요청한 회차보다 이전이거나 기록이 없음 (같은 회차는 한 번만)
+//   - 기록된 리스가 만료됐거나 해제됨 (이전 회차가 아직 실행 중이면 건너뜀)
+//
+// Release는 holder가 같을 때만 리스를 해제하고, 회차 기록은 남겨야 함.
+type LeaseStore interface {
+	Acquire(ctx context.Context, job, holder string, occurrence time.Time, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, job, holder string) error
+}
+
+// leaseRecord - 작업 하나의 리스 상태
+type leaseRecord struct {
+	holder     string
+	occurrence time.Time
+	expiresAt  time.Time
+}
+
+// grantable - 리스를 줄 수 있는지 (저장소 구현이 공통으로 쓰는 규칙)
+func grantable(held bool, rec leaseRecord, occurrence, now time.Time) bool {
+	if !held {
+		return true
+	}
+	return rec.occurrence.Before(occurrence) && !now.Before(rec.expiresAt)
+}
+
+// MemoryLeaseStore - 메모리 기반 리스 저장소 (단일 인스턴스용)
+type MemoryLeaseStore struct {
+	mu     sync.Mutex
+	leases map[string]leaseRecord
+	now    func() time.Time
+}
+
+// NewMemoryLeaseStore - MemoryLeaseStore 생성자
+func NewMemoryLeaseStore() *MemoryLeaseStore {
+	return &MemoryLeaseStore{
+		leases: make(map[string]leaseRecord),
+		now:    time.Now,
+	}
+}
+
+// Acquire - 작업 회차 리스 획득
+func (s *MemoryLeaseStore) Acquire(ctx context.Context, job, holder string, occurrence time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rec, held := s.leases[job]
+	if !grantable(held, rec, occurrence, now) {
+		return false, nil
+	}
+	s.leases[job] = leaseRecord{holder: holder, occurrence: occurrence, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Release - 리스 해제 (회차 기록은 유지)
+func (s *MemoryLeaseStore) Release(ctx context.Context, job, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.leases[job]; ok && rec.holder == holder {
+		rec.expiresAt = s.now()
+		s.leases[job] = rec
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+// Package scheduler - 주기 작업 스케줄러
+//
+// cron 식으로 등록한 작업을 요청과 무관하게 실행한다.
+// 작업마다 제한 시간을 두고 패닉은 작업 안에서 막으며, 결과는 실행 기록으로 남긴다.
+// 여러 인스턴스가 같은 스케줄러를 돌려도 LeaseStore가 회차마다 한 인스턴스만 실행하게 한다.
+// 인스턴스 자신의 메모리 상태를 다루는 작업(PerInstance)은 리스 없이 인스턴스마다 실행한다.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+var (
+	ErrJobNotFound = domain.NewError("job_not_found", "job not found")
+	ErrJobRunning  = domain.NewError("job_running", "job is already running")
+	ErrLeaseHeld   = domain.NewError("job_lease_held", "job is running on another instance")
+
+	errDuplicateJob = errors.New("job already registered")
+	errInvalidJob   = errors.New("invalid job")
+)
+
+// stopGrace - 제한 시간이 지난 뒤 작업이 취소를 따라 끝나기를 기다리는 시간
+const stopGrace = time.Second
+
+// Job - 등록할 작업
+type Job struct {
+	Name     string
+	Schedule string        // cron 식 (ParseSchedule 참고)
+	Timeout  time.Duration // 0이면 Config.DefaultTimeout
+	Run      func(ctx context.Context) error
+
+	// PerInstance - 리스 없이 모든 인스턴스가 매 회차 실행 (메모리 색인/캐시처럼 인스턴스마다 따로인 상태)
+	// 한 인스턴스 안에서는 여전히 이전 실행이 끝나야 다음 회차를 실행함
+	PerInstance bool
+}
+
+// JobInfo - 등록된 작업 상태
+type JobInfo struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration
+	Next     time.Time // 다음 예정 시각
+	Running  bool      // 이 인스턴스에서 실행 중
+
+	PerInstance bool // 리스 없이 인스턴스마다 실행
+}
+
+// Config - 스케줄러 설정 (0 값은 기본값 사용)
+type Config struct {
+	Holder         string         // 인스턴스 식별자 (기본 "<호스트 이름>-<PID>")
+	Location       *time.Location // cron 식 기준 시간대 (기본 UTC)
+	DefaultTimeout time.Duration  // 작업 기본 제한 시간 (기본 10분)
+	LeaseMargin    time.Duration  // 리스 TTL = 제한 시간 + 여유 (기본 1분)
+}
+
+// Scheduler - 작업 등록부 + 실행 루프
+type Scheduler struct {
+	leases  LeaseStore
+	history HistoryStore
+	cfg     Config
+	now     func() time.Time
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	baseCtx context.Context // Run이 받은 컨텍스트 (수동 실행도 종료 시 함께 취소)
+	wake    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// entry - 등록된 작업 하나
+type entry struct {
+	job      Job
+	schedule Schedule
+	next     time.Time
+	running  bool
+}
+
+// New - Scheduler 생성자
+func New(leases LeaseStore, history HistoryStore, cfg Config) *Scheduler {
+	if cfg.Holder == "" {
+		host, _ := os.Hostname()
+		cfg.Holder = host + "-" + strconv.Itoa(os.Getpid())
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	if cfg.DefaultTimeout <= 0 {
+		cfg.DefaultTimeout = 10 * time.Minute
+	}
+	if cfg.LeaseMargin <= 0 {
+		cfg.LeaseMargin = time.Minute
+	}
+	return &Scheduler{
+		leases:  leases,
+		history: history,
+		cfg:     cfg,
+		now:     time.Now,
+		jobs:    make(map[string]*entry),
+		baseCtx: context.Background(),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Register - 작업 등록 (이름 중복, 잘못된 cron 식은 에러)
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("%w: name and run function are required", errInvalidJob)
+	}
+	schedule, err := ParseSchedule(job.Schedule, s.cfg.Location)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = s.cfg.DefaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateJob, job.Name)
+	}
+	s.jobs[job.Name] = &entry{job: job, schedule: schedule, next: schedule.Next(s.now())}
+	s.notify()
+	return nil
+}
+
+// Jobs - 등록된 작업 목록 (이름순)
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		infos = append(infos, JobInfo{
+			Name:     e.job.Name,
+			Schedule: e.job.Schedule,
+			Timeout:  e.job.Timeout,
+			Next:     e.next,
+			Running:  e.running,
+
+			PerInstance: e.job.PerInstance,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// History - 작업의 최근 실행 기록 (최신순)
+func (s *Scheduler) History(ctx context.Context, name string, limit int) ([]*Run, error) {
+	s.mu.Lock()
+	_, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return s.history.List(ctx, name, limit)
+}
+
+// Run - ctx가 끝날 때까지 예정 시각이 된 작업 실행
+// 놓친 회차는 몰아서 실행하지 않고 다음 회차부터 다시 계산
+// 반환 전에 실행 중인 작업이 끝나기를 기다림 (작업 컨텍스트는 ctx와 함께 취소)
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	s.baseCtx = ctx
+	s.mu.Unlock()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		next := s.dispatchDue(ctx, s.now())
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if !next.IsZero() {
+			timer.Reset(next.Sub(s.now()))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return ctx.Err()
+		case <-timer.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// Trigger - 작업을 지금 한 번 실행 (예정 회차와 별개, 리스는 똑같이 획득)
+// 실행은 백그라운드에서 하고 결과는 실행 기록으로 남음
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if e.running {
+		return ErrJobRunning
+	}
+	s.start(s.baseCtx, e, s.now(), true)
+	return nil
+}
+
+// RunNow - 작업을 지금 실행하고 끝날 때까지 기다림 (CLI, 테스트용)
+// 다른 인스턴스가 리스를 가지고 있으면 ErrLeaseHeld (PerInstance 작업은 이 인스턴스에서 바로 실행)
+func (s *Scheduler) RunNow(ctx context.Context, name string) (*Run, error) {
+	s.mu.Lock()
+	e, ok := s.jobs[name]
+	if !ok {
+		s.mu.Unlock()
+		return nil, ErrJobNotFound
+	}
+	if e.running {
+		s.mu.Unlock()
+		return nil, ErrJobRunning
+	}
+	e.running = true
+	s.mu.Unlock()
+
+	return s.execute(ctx, e, s.now(), true)
+}
+
+// dispatchDue - 예정 시각이 된 작업을 시작하고 가장 이른 다음 예정 시각 반환
+func (s *Scheduler) dispatchDue(ctx context.Context, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, e := range s.jobs {
+		if !e.next.IsZero() && !e.next.After(now) {
+			occurrence := e.next
+			e.next = e.schedule.Next(now)
+			if e.running {
+				log.Printf("작업 %s: 이전 실행이 끝나지 않아 %s 회차를 건너뜀", e.job.Name, occurrence.Format(time.RFC3339))
+			} else {
+				s.start(ctx, e, occurrence, false)
+			}
+		}
+		if !e.next.IsZero() && (earliest.IsZero() || e.next.Before(earliest)) {
+			earliest = e.next
+		}
+	}
+	return earliest
+}
+
+// start - 백그라운드 실행 시작 (호출자가 잠금 보유)
+func (s *Scheduler) start(ctx context.Context, e *entry, occurrence time.Time, manual bool) {
+	e.running = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if _, err := s.execute(ctx, e, occurrence, manual); err != nil && !errors.Is(err, ErrLeaseHeld) {
+			log.Printf("작업 %s 실행 실패: %v", e.job.Name, err)
+		}
+	}()
+}
+
+// execute - 리스를 얻어 작업을 한 번 실행하고 기록 (e.running은 호출자가 true로 둠)
+func (s *Scheduler) execute(ctx context.Context, e *entry, occurrence time.Time, manual bool) (*Run, error) {
+	job := e.job
+	if !job.PerInstance {
+		acquired, err := s.leases.Acquire(ctx, job.Name, s.cfg.Holder, occurrence, job.Timeout+s.cfg.LeaseMargin)
+		if err != nil || !acquired {
+			s.setRunning(e, false)
+			if err != nil {
+				return nil, fmt.Errorf("리스 획득 실패: %w", err)
+			}
+			return nil, ErrLeaseHeld
+		}
+	}
+
+	run := &Run{
+		ID:         uuid.New().String(),
+		Job:        job.Name,
+		Holder:     s.cfg.Holder,
+		Occurrence: occurrence,
+		Manual:     manual,
+		StartedAt:  s.now(),
+	}
+	run.Status, run.Error = s.invoke(ctx, e)
+	run.FinishedAt = s.now()
+
+	if err := s.history.Record(context.WithoutCancel(ctx), run); err != nil {
+		log.Printf("작업 %s 실행 기록 저장 실패: %v", job.Name, err)
+	}
+	if run.Status != RunSucceeded {
+		log.Printf("작업 %s %s: %s", job.Name, run.Status, run.Error)
+	}
+	return run, nil
+}
+
+// invoke - 제한 시간과 패닉 격리 안에서 작업 실행
+// 작업 고루틴이 실제로 끝나야 실행 중 표시와 리스를 해제함
+// (제한 시간을 넘기고도 돌고 있으면 그동안 이 인스턴스도, 리스 TTL까지는 다른 인스턴스도 새로 시작하지 않음)
+func (s *Scheduler) invoke(ctx context.Context, e *entry) (RunStatus, string) {
+	jobCtx, cancel := context.WithTimeout(ctx, e.job.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		defer s.finish(ctx, e)
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &panicError{value: r, stack: debug.Stack()}
+			}
+		}()
+		done <- e.job.Run(jobCtx)
+	}()
+
+	select {
+	case err := <-done:
+		<-finished
+		var pe *panicError
+		switch {
+		case err == nil:
+			return RunSucceeded, ""
+		case errors.As(err, &pe):
+			log.Printf("작업 %s 패닉: %v\n%s", e.job.Name, pe.value, pe.stack)
+			return RunPanicked, pe.Error()
+		case errors.Is(err, context.DeadlineExceeded) && jobCtx.Err() == context.DeadlineExceeded:
+			return RunTimedOut, err.Error()
+		default:
+			return RunFailed, err.Error()
+		}
+	case <-jobCtx.Done():
+		// 취소를 따르는 작업은 곧 끝나므로 잠시 기다렸다가 실행 중 표시가 풀린 뒤 보고
+		// 그래도 끝나지 않으면 컨텍스트를 무시하고 계속 도는 작업
+		select {
+		case <-finished:
+		case <-time.After(stopGrace):
+		}
+		if ctx.Err() != nil {
+			return RunFailed, ctx.Err().Error()
+		}
+		return RunTimedOut, fmt.Sprintf("timed out after %s", e.job.Timeout)
+	}
+}
+
+// finish - 작업 고루틴 종료 처리 (리스 해제 + 실행 중 표시 해제)
+func (s *Scheduler) finish(ctx context.Context, e *entry) {
+	if !e.job.PerInstance {
+		if err := s.leases.Release(context.WithoutCancel(ctx), e.job.Name, s.cfg.Holder); err != nil {
+			log.Printf("작업 %s 리스 해제 실패: %v", e.job.Name, err)
+		}
+	}
+	s.setRunning(e, false)
+}
+
+func (s *Scheduler) setRunning(e *entry, running bool) {
+	s.mu.Lock()
+	e.running = running
+	s.mu.Unlock()
+}
+
+// notify - 실행 루프를 깨워 다음 예정 시각을 다시 계산 (호출자가 잠금 보유해도 됨)
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// panicError - 작업 패닉
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
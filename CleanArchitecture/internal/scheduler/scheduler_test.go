@@ -0,0 +1,312 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(holder string, leases LeaseStore) *Scheduler {
+	return New(leases, NewMemoryHistory(10), Config{Holder: holder, LeaseMargin: time.Millisecond})
+}
+
+func register(t *testing.T, s *Scheduler, job Job) {
+	t.Helper()
+	if job.Schedule == "" {
+		job.Schedule = "@hourly"
+	}
+	if err := s.Register(job); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunNowStatuses(t *testing.T) {
+	s := newTestScheduler("a", NewMemoryLeaseStore())
+	register(t, s, Job{Name: "ok", Run: func(context.Context) error { return nil }})
+	register(t, s, Job{Name: "fail", Run: func(context.Context) error { return errors.New("disk full") }})
+	register(t, s, Job{Name: "panic", Run: func(context.Context) error { panic("boom") }})
+	register(t, s, Job{Name: "slow", Timeout: 20 * time.Millisecond, Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	tests := []struct {
+		job    string
+		status RunStatus
+		errMsg string
+	}{
+		{"ok", RunSucceeded, ""},
+		{"fail", RunFailed, "disk full"},
+		{"panic", RunPanicked, "panic: boom"},
+		{"slow", RunTimedOut, "timed out after 20ms"},
+	}
+	for _, tt := range tests {
+		run, err := s.RunNow(context.Background(), tt.job)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.job, err)
+		}
+		if run.Status != tt.status || run.Error != tt.errMsg || !run.Manual || run.Holder != "a" {
+			t.Errorf("%s: got %+v", tt.job, run)
+		}
+
+		// 패닉/실패 후에도 다시 실행할 수 있어야 함
+		if _, err := s.RunNow(context.Background(), tt.job); err != nil {
+			t.Errorf("%s: second run: %v", tt.job, err)
+		}
+		runs, _ := s.History(context.Background(), tt.job, 0)
+		if len(runs) != 2 {
+			t.Errorf("%s: got %d runs, want 2", tt.job, len(runs))
+		}
+	}
+}
+
+func TestTimeoutIgnoringContextKeepsLease(t *testing.T) {
+	leases := NewMemoryLeaseStore()
+	s := newTestScheduler("a", leases)
+	release := make(chan struct{})
+	register(t, s, Job{Name: "stuck", Timeout: 10 * time.Millisecond, Run: func(context.Context) error {
+		<-release // 컨텍스트를 무시
+		return nil
+	}})
+
+	run, err := s.RunNow(context.Background(), "stuck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != RunTimedOut {
+		t.Fatalf("got %s, want %s", run.Status, RunTimedOut)
+	}
+
+	// 작업이 아직 돌고 있으므로 이 인스턴스에서도 다시 시작하지 않음
+	if _, err := s.RunNow(context.Background(), "stuck"); !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("got %v, want ErrJobRunning", err)
+	}
+
+	close(release)
+	waitFor(t, func() bool { return !s.Jobs()[0].Running })
+	if _, err := s.RunNow(context.Background(), "stuck"); err != nil {
+		t.Fatalf("after finish: %v", err)
+	}
+}
+
+func TestLeaseSingleRunnerAcrossInstances(t *testing.T) {
+	leases := NewMemoryLeaseStore()
+	var runs atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	job := Job{Name: "purge", Run: func(context.Context) error {
+		if runs.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return nil
+	}}
+
+	a := newTestScheduler("a", leases)
+	b := newTestScheduler("b", leases)
+	register(t, a, job)
+	register(t, b, job)
+
+	occurrence := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	e := a.jobs["purge"]
+	e.running = true
+	done := make(chan *Run)
+	go func() {
+		run, _ := a.execute(context.Background(), e, occurrence, false)
+		done <- run
+	}()
+	<-started
+
+	// 같은 회차: 실행 중이든 끝났든 b는 실행하지 못함
+	eb := b.jobs["purge"]
+	eb.running = true
+	if _, err := b.execute(context.Background(), eb, occurrence, false); !errors.Is(err, ErrLeaseHeld) {
+		t.Fatalf("while running: got %v, want ErrLeaseHeld", err)
+	}
+	// 다음 회차라도 a가 아직 실행 중이면 건너뜀
+	eb.running = true
+	if _, err := b.execute(context.Background(), eb, occurrence.Add(time.Hour), false); !errors.Is(err, ErrLeaseHeld) {
+		t.Fatalf("overlapping next occurrence: got %v, want ErrLeaseHeld", err)
+	}
+
+	close(release)
+	if run := <-done; run.Status != RunSucceeded {
+		t.Fatalf("got %+v", run)
+	}
+
+	eb.running = true
+	if _, err := b.execute(context.Background(), eb, occurrence, false); !errors.Is(err, ErrLeaseHeld) {
+		t.Fatalf("same occurrence after finish: got %v, want ErrLeaseHeld", err)
+	}
+	eb.running = true
+	run, err := b.execute(context.Background(), eb, occurrence.Add(time.Hour), false)
+	if err != nil || run.Holder != "b" {
+		t.Fatalf("next occurrence: got %+v, %v", run, err)
+	}
+	if n := runs.Load(); n != 2 {
+		t.Fatalf("job ran %d times, want 2", n)
+	}
+}
+
+// 인스턴스마다 따로인 상태를 다루는 작업은 다른 인스턴스가 실행 중이어도 같은 회차를 실행
+func TestPerInstanceJobSkipsLease(t *testing.T) {
+	leases := NewMemoryLeaseStore()
+	var runs atomic.Int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	job := Job{Name: "rebuild", PerInstance: true, Run: func(context.Context) error {
+		runs.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	}}
+
+	a := newTestScheduler("a", leases)
+	b := newTestScheduler("b", leases)
+	register(t, a, job)
+	register(t, b, job)
+
+	occurrence := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	done := make(chan *Run, 2)
+	for _, s := range []*Scheduler{a, b} {
+		e := s.jobs["rebuild"]
+		e.running = true
+		go func() {
+			run, err := s.execute(context.Background(), e, occurrence, false)
+			if err != nil {
+				t.Errorf("execute: %v", err)
+			}
+			done <- run
+		}()
+	}
+	<-started
+	<-started
+
+	// 같은 인스턴스 안에서는 겹쳐 실행하지 않음
+	if _, err := a.RunNow(context.Background(), "rebuild"); !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("overlap on same instance: got %v, want ErrJobRunning", err)
+	}
+
+	close(release)
+	holders := map[string]bool{}
+	for range 2 {
+		run := <-done
+		if run == nil || run.Status != RunSucceeded {
+			t.Fatalf("got %+v", run)
+		}
+		holders[run.Holder] = true
+	}
+	if !holders["a"] || !holders["b"] || runs.Load() != 2 {
+		t.Fatalf("holders = %v, runs = %d", holders, runs.Load())
+	}
+	// 리스를 잡지 않았으므로 공유 작업은 그대로 이 회차를 실행할 수 있음
+	if ok, err := leases.Acquire(context.Background(), "rebuild", "c", occurrence, time.Minute); err != nil || !ok {
+		t.Fatalf("lease after per-instance run: %v, %v", ok, err)
+	}
+}
+
+func TestExpiredLeaseCanBeTakenOver(t *testing.T) {
+	leases := NewMemoryLeaseStore()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	leases.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if ok, _ := leases.Acquire(ctx, "job", "a", now, time.Minute); !ok {
+		t.Fatal("first acquire failed")
+	}
+	// a가 죽어서 해제하지 못함
+	if ok, _ := leases.Acquire(ctx, "job", "b", now.Add(30*time.Second), time.Minute); ok {
+		t.Fatal("acquired live lease")
+	}
+	now = now.Add(2 * time.Minute)
+	if ok, _ := leases.Acquire(ctx, "job", "b", now, time.Minute); !ok {
+		t.Fatal("expired lease not taken over")
+	}
+	// 다른 holder는 해제할 수 없음
+	leases.Release(ctx, "job", "a")
+	if ok, _ := leases.Acquire(ctx, "job", "a", now.Add(time.Second), time.Minute); ok {
+		t.Fatal("lease released by non-holder")
+	}
+}
+
+func TestRunDispatchesDueJobs(t *testing.T) {
+	s := newTestScheduler("a", NewMemoryLeaseStore())
+	register(t, s, Job{Name: "tick", Schedule: "@every 1s", Run: func(context.Context) error { return nil }})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	var runs []*Run
+	waitFor(t, func() bool {
+		runs, _ = s.History(context.Background(), "tick", 0)
+		return len(runs) >= 2
+	})
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	for _, r := range runs {
+		if r.Manual || r.Status != RunSucceeded || !r.Occurrence.Equal(r.Occurrence.Truncate(time.Second)) {
+			t.Errorf("unexpected run %+v", r)
+		}
+	}
+	if !runs[0].Occurrence.After(runs[1].Occurrence) {
+		t.Errorf("occurrences not increasing: %v, %v", runs[1].Occurrence, runs[0].Occurrence)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	s := newTestScheduler("a", NewMemoryLeaseStore())
+	run := func(context.Context) error { return nil }
+	register(t, s, Job{Name: "dup", Run: run})
+
+	if err := s.Register(Job{Name: "dup", Schedule: "@daily", Run: run}); !errors.Is(err, errDuplicateJob) {
+		t.Errorf("duplicate: got %v", err)
+	}
+	if err := s.Register(Job{Name: "bad", Schedule: "every day", Run: run}); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("bad schedule: got %v", err)
+	}
+	if err := s.Register(Job{Name: "nil", Schedule: "@daily"}); !errors.Is(err, errInvalidJob) {
+		t.Errorf("nil run: got %v", err)
+	}
+	if _, err := s.RunNow(context.Background(), "missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("missing: got %v", err)
+	}
+	if err := s.Trigger("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("trigger missing: got %v", err)
+	}
+}
+
+func TestHistoryKeepsNewest(t *testing.T) {
+	h := NewMemoryHistory(3)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		h.Record(ctx, &Run{ID: string(rune('a' + i)), Job: "job"})
+	}
+	runs, _ := h.List(ctx, "job", 0)
+	var ids string
+	for _, r := range runs {
+		ids += r.ID
+	}
+	if ids != "edc" {
+		t.Fatalf("got %q, want newest first %q", ids, "edc")
+	}
+	if runs, _ := h.List(ctx, "job", 1); len(runs) != 1 || runs[0].ID != "e" {
+		t.Fatalf("limit 1: got %+v", runs)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,55 @@
+// Package tenant - 요청 범위 테넌트(조직) 식별
+// 전달 계층이 테넌트를 확인해 컨텍스트에 넣고, 유스케이스와 리포지토리는 이를 기준으로 범위를 제한
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// Default - 단일 조직 배포와 테넌트 도입 전에 저장된 사용자가 속하는 테넌트
+const Default = "default"
+
+type contextKey struct{}
+
+// idPattern - 테넌트 ID 형식 (서브도메인으로도 쓸 수 있는 소문자 DNS 레이블)
+var idPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Valid - 테넌트 ID 형식 검사
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// WithID - 테넌트 ID를 컨텍스트에 저장
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext - 컨텍스트에서 테넌트 ID 조회
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Require - 테넌트 ID 조회 (없으면 domain.ErrTenantRequired)
+func Require(ctx context.Context) (string, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return "", domain.ErrTenantRequired
+	}
+	return id, nil
+}
+
+// Check - 대상 사용자가 컨텍스트의 테넌트 소속인지 확인
+func Check(ctx context.Context, user *domain.User) error {
+	id, err := Require(ctx)
+	if err != nil {
+		return err
+	}
+	if user.TenantID != id {
+		return domain.ErrCrossTenant
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/jsonschema"
+)
+
+// AttributeValidationError - 속성이 네임스페이스 스키마와 맞지 않음
+// errors.Is(err, domain.ErrInvalidAttributes)로 판별하고, 필드별 이유는 Errors에 있음
+type AttributeValidationError struct {
+	Namespace string
+	Errors    []jsonschema.FieldError
+}
+
+func (e *AttributeValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", domain.ErrInvalidAttributes, e.Namespace,
+		(&jsonschema.ValidationError{Errors: e.Errors}).Error())
+}
+
+func (e *AttributeValidationError) Unwrap() error {
+	return domain.ErrInvalidAttributes
+}
+
+// compiledSchema - 버전별 컴파일 결과 캐시
+type compiledSchema struct {
+	version int
+	schema  *jsonschema.Schema
+}
+
+// AttributeSchemaUseCase - 속성 스키마 관리 + 속성 검증 유스케이스
+type AttributeSchemaUseCase struct {
+	repo AttributeSchemaRepository
+
+	mu       sync.Mutex
+	compiled map[string]compiledSchema
+}
+
+// NewAttributeSchemaUseCase - AttributeSchemaUseCase 생성자
+func NewAttributeSchemaUseCase(repo AttributeSchemaRepository) *AttributeSchemaUseCase {
+	return &AttributeSchemaUseCase{
+		repo:     repo,
+		compiled: make(map[string]compiledSchema),
+	}
+}
+
+// RegisterSchema - 네임스페이스 스키마 등록 또는 교체 (버전 증가)
+// 최상위는 "type": "object"여야 함
+// 교체해도 이미 저장된 속성은 다시 검증하지 않음 (다음 수정부터 새 스키마 적용)
+func (uc *AttributeSchemaUseCase) RegisterSchema(ctx context.Context, namespace string, raw []byte) (*domain.AttributeSchema, error) {
+	if !domain.ValidNamespace(namespace) {
+		return nil, domain.ErrInvalidNamespace
+	}
+
+	compiled, err := jsonschema.Compile(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidSchema, err)
+	}
+	if types := compiled.Types(); len(types) != 1 || types[0] != "object" {
+		return nil, fmt.Errorf("%w: top-level type must be \"object\"", domain.ErrInvalidSchema)
+	}
+
+	now := time.Now()
+	schema := &domain.AttributeSchema{
+		Namespace: namespace,
+		Schema:    append([]byte(nil), raw...),
+		Version:   1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	prev, err := uc.repo.GetSchema(ctx, namespace)
+	switch {
+	case errors.Is(err, domain.ErrSchemaNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		schema.Version = prev.Version + 1
+		schema.CreatedAt = prev.CreatedAt
+	}
+
+	if err := uc.repo.PutSchema(ctx, schema); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	uc.compiled[namespace] = compiledSchema{version: schema.Version, schema: compiled}
+	uc.mu.Unlock()
+
+	return schema, nil
+}
+
+// GetSchema - 네임스페이스 스키마 조회
+func (uc *AttributeSchemaUseCase) GetSchema(ctx context.Context, namespace string) (*domain.AttributeSchema, error) {
+	return uc.repo.GetSchema(ctx, namespace)
+}
+
+// ListSchemas - 등록된 스키마 목록 (네임스페이스 순)
+func (uc *AttributeSchemaUseCase) ListSchemas(ctx context.Context) ([]*domain.AttributeSchema, error) {
+	schemas, err := uc.repo.ListSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Namespace < schemas[j].Namespace
+	})
+	return schemas, nil
+}
+
+// DeleteSchema - 네임스페이스 스키마 삭제
+// 이미 저장된 속성은 남지만, 이후 그 네임스페이스에 쓰면 ErrUnknownNamespace
+func (uc *AttributeSchemaUseCase) DeleteSchema(ctx context.Context, namespace string) error {
+	if err := uc.repo.DeleteSchema(ctx, namespace); err != nil {
+		return err
+	}
+
+	uc.mu.Lock()
+	delete(uc.compiled, namespace)
+	uc.mu.Unlock()
+	return nil
+}
+
+// ValidateAttributes - 네임스페이스 하나의 속성 검증
+// 스키마는 저장소에서 매번 확인하고, 버전이 같으면 컴파일 결과를 재사용 (여러 인스턴스가 같은 저장소를 쓸 때도 최신 스키마 적용)
+func (uc *AttributeSchemaUseCase) ValidateAttributes(ctx context.Context, namespace string, values map[string]any) error {
+	if !domain.ValidNamespace(namespace) {
+		return domain.ErrInvalidNamespace
+	}
+
+	schema, err := uc.schemaFor(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := schema.Validate(values); err != nil {
+		if ve, ok := jsonschema.AsValidationError(err); ok {
+			return &AttributeValidationError{Namespace: namespace, Errors: ve.Errors}
+		}
+		return err
+	}
+	return nil
+}
+
+// schemaFor - 컴파일된 네임스페이스 스키마 (미등록이면 ErrUnknownNamespace)
+func (uc *AttributeSchemaUseCase) schemaFor(ctx context.Context, namespace string) (*jsonschema.Schema, error) {
+	stored, err := uc.repo.GetSchema(ctx, namespace)
+	if errors.Is(err, domain.ErrSchemaNotFound) {
+		return nil, domain.ErrUnknownNamespace
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if c, ok := uc.compiled[namespace]; ok && c.version == stored.Version {
+		return c.schema, nil
+	}
+	compiled, err := jsonschema.Compile(stored.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidSchema, err)
+	}
+	uc.compiled[namespace] = compiledSchema{version: stored.Version, schema: compiled}
+	return compiled, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"io"
 
 	"github.com/milman2/go-api/clean-architecture/internal/domain"
 )
@@ -16,3 +17,61 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id string) error
 }
+
+// EventPublisher - 사용자 변경 이벤트 발행 포트 (웹훅 등)
+// 사용자 변경이 이미 저장된 뒤 호출되므로, 전달 실패는 구현체가 스스로 처리 (재시도/로그)
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.UserEvent)
+}
+
+// UserStreamer - 사용자를 한 명씩 순회하는 선택적 포트
+// 구현한 리포지토리는 전체 목록을 한 번에 메모리에 올리지 않고 내보내기 가능
+type UserStreamer interface {
+	Stream(ctx context.Context, fn func(*domain.User) error) error
+}
+
+// UserBatchGetter - 여러 ID를 한 번의 조회로 가져오는 선택적 포트
+// 컨텍스트 테넌트의 사용자만 ID를 키로 돌려주며, 없거나 다른 테넌트 소속인 ID는 결과에서 빠짐
+// 구현하지 않은 저장소는 ID마다 GetByID를 호출
+type UserBatchGetter interface {
+	GetByIDs(ctx context.Context, ids []string) (map[string]*domain.User, error)
+}
+
+// UserSearcher - 이름/이메일 부분 검색을 지원하는 선택적 포트
+// 결과는 관련도 순이며 limit명을 넘지 않음
+type UserSearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]*domain.User, error)
+}
+
+// UserAttributeQuerier - 속성 조건으로 사용자를 찾는 선택적 포트
+// 구현하지 않은 저장소는 GetAll 결과를 유스케이스에서 거름
+type UserAttributeQuerier interface {
+	FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error)
+}
+
+// UserTransactor - 여러 변경을 한 번에 반영하거나 모두 취소하는 선택적 포트
+// fn이 에러를 반환하면 tx로 한 변경은 모두 취소되고 그 에러를 그대로 반환
+// tx는 fn 안에서만 쓸 수 있으며, fn 실행 중에는 같은 저장소의 다른 쓰기가 기다릴 수 있음
+// 구현에 따라 (Spanner) 충돌하면 fn을 처음부터 다시 실행하므로 fn은 tx로 읽은 값만으로 동작해야 함
+type UserTransactor interface {
+	InTransaction(ctx context.Context, fn func(tx UserRepository) error) error
+}
+
+// AttributeSchemaRepository - 속성 네임스페이스별 JSON Schema 저장소 (포트)
+// 스키마는 테넌트와 무관하게 서비스 전체에 적용
+type AttributeSchemaRepository interface {
+	GetSchema(ctx context.Context, namespace string) (*domain.AttributeSchema, error)
+	ListSchemas(ctx context.Context) ([]*domain.AttributeSchema, error)
+	PutSchema(ctx context.Context, schema *domain.AttributeSchema) error
+	DeleteSchema(ctx context.Context, namespace string) error
+}
+
+// BlobStore - 바이너리 객체 저장소 (포트)
+// 키는 '/'로 구분한 경로 형태 (예: avatars/<테넌트>/<사용자>/original)
+// Get이 돌려준 ReadCloser는 호출자가 닫아야 하며, 없는 키는 domain.ErrBlobNotFound
+// Delete는 없는 키도 성공으로 취급
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, r io.Reader) (*domain.BlobInfo, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, *domain.BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// validateAttributes - 스키마로 네임스페이스 속성 검증 (스키마 기능이 꺼져 있으면 모든 네임스페이스가 미등록)
+func (uc *UserUseCase) validateAttributes(ctx context.Context, namespace string, values map[string]any) error {
+	if uc.schemas == nil {
+		return domain.ErrUnknownNamespace
+	}
+	return uc.schemas.ValidateAttributes(ctx, namespace, values)
+}
+
+// SetUserAttributes - 사용자의 네임스페이스 속성 전체 교체
+func (uc *UserUseCase) SetUserAttributes(ctx context.Context, id, namespace string, values map[string]any) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err := uc.validateAttributes(ctx, namespace, values); err != nil {
+		return nil, err
+	}
+
+	user, _, err := uc.modifyUser(ctx, id, func(user *domain.User) (bool, error) {
+		if err := user.CheckMutable(); err != nil {
+			return false, err
+		}
+		return true, user.SetAttributes(namespace, values)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, domain.UserUpdated, user)
+	return user, nil
+}
+
+// RemoveUserAttributes - 사용자의 네임스페이스 속성 삭제 (스키마가 삭제된 네임스페이스도 정리 가능)
+func (uc *UserUseCase) RemoveUserAttributes(ctx context.Context, id, namespace string) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if !domain.ValidNamespace(namespace) {
+		return nil, domain.ErrInvalidNamespace
+	}
+
+	user, saved, err := uc.modifyUser(ctx, id, func(user *domain.User) (bool, error) {
+		if err := user.CheckMutable(); err != nil {
+			return false, err
+		}
+		return user.RemoveAttributes(namespace), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if saved {
+		uc.publish(ctx, domain.UserUpdated, user)
+	}
+	return user, nil
+}
+
+// UserFilter - 목록 조건 (비어 있는 필드는 조건 없음)
+type UserFilter struct {
+	Attributes []domain.AttributeFilter // 모두 만족 (AND)
+	Statuses   []domain.UserStatus      // 하나라도 일치 (OR)
+}
+
+// ListUsers - 속성/상태 조건으로 거른 사용자 목록 (조건이 없으면 GetAllUsers와 같음)
+// 저장소가 UserAttributeQuerier를 구현하면 속성 조건을 저장소에 넘김
+func (uc *UserUseCase) ListUsers(ctx context.Context, filter UserFilter) ([]*domain.User, error) {
+	for _, f := range filter.Attributes {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range filter.Statuses {
+		if !s.Valid() {
+			return nil, domain.ErrInvalidUserStatus
+		}
+	}
+
+	users, err := uc.findByAttributes(ctx, filter.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.Statuses) == 0 {
+		return users, nil
+	}
+	return slices.DeleteFunc(users, func(u *domain.User) bool {
+		return !slices.Contains(filter.Statuses, u.CurrentStatus())
+	}), nil
+}
+
+func (uc *UserUseCase) findByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]*domain.User, error) {
+	if len(filters) == 0 {
+		return uc.GetAllUsers(ctx)
+	}
+	if querier, ok := uc.userRepo.(UserAttributeQuerier); ok {
+		return querier.FindByAttributes(ctx, filters)
+	}
+
+	users, err := uc.userRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		if domain.MatchAttributes(user, filters) {
+			matched = append(matched, user)
+		}
+	}
+	return matched, nil
+}
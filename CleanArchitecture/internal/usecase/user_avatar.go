@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+
+	// 지원 형식 디코더 등록 (jpeg, png는 인코딩에도 사용)
+	_ "image/gif"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/imaging"
+)
+
+// ErrAvatarUnavailable - 블롭 저장소 없이 구성되어 아바타를 쓸 수 없음
+var ErrAvatarUnavailable = domain.NewError("avatar_unavailable", "avatar storage is not configured")
+
+// AvatarUpload - 아바타 업로드 결과
+type AvatarUpload struct {
+	Width     int // 원본 가로 픽셀
+	Height    int // 원본 세로 픽셀
+	Original  *domain.BlobInfo
+	Thumbnail *domain.BlobInfo
+}
+
+// SetAvatar - 사용자 아바타 교체
+// 내용으로 형식을 판별하고(확장자/Content-Type 헤더는 믿지 않음), 헤더로 크기를 확인한 뒤에만 디코딩
+// 원본은 받은 그대로, 썸네일은 가운데를 잘라 정사각형으로 저장
+func (uc *UserUseCase) SetAvatar(ctx context.Context, id string, data []byte) (*AvatarUpload, error) {
+	if uc.blobs == nil {
+		return nil, ErrAvatarUnavailable
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if len(data) > domain.MaxAvatarBytes {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(domain.AvatarContentTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedImage, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	if err := domain.ValidAvatarSize(cfg.Width, cfg.Height); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.CheckMutable(); err != nil {
+		return nil, err
+	}
+
+	// 저장하기 전에 썸네일까지 만들어 두어, 깨진 이미지면 아무것도 쓰지 않음
+	thumb, thumbType, err := avatarThumbnail(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := uc.blobs.Put(ctx, domain.AvatarKey(user.TenantID, user.ID, domain.AvatarOriginal),
+		contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := uc.blobs.Put(ctx, domain.AvatarKey(user.TenantID, user.ID, domain.AvatarThumbnail),
+		thumbType, bytes.NewReader(thumb))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvatarUpload{
+		Width:     cfg.Width,
+		Height:    cfg.Height,
+		Original:  original,
+		Thumbnail: thumbnail,
+	}, nil
+}
+
+// avatarThumbnail - 썸네일 인코딩 (JPEG 원본은 JPEG, 나머지는 투명도를 살리도록 PNG)
+func avatarThumbnail(data []byte, contentType string) ([]byte, string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	thumb := imaging.Thumbnail(img, domain.AvatarThumbnailSide)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&buf, thumb)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), contentType, nil
+}
+
+// GetAvatar - 사용자 아바타 내용 (호출자가 닫아야 함)
+func (uc *UserUseCase) GetAvatar(ctx context.Context, id string, variant domain.AvatarVariant) (io.ReadCloser, *domain.BlobInfo, error) {
+	if uc.blobs == nil {
+		return nil, nil, ErrAvatarUnavailable
+	}
+	if id == "" {
+		return nil, nil, domain.ErrInvalidUserID
+	}
+
+	// 사용자 조회로 존재와 테넌트 소속을 먼저 확인
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, info, err := uc.blobs.Get(ctx, domain.AvatarKey(user.TenantID, user.ID, variant))
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return nil, nil, domain.ErrAvatarNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return rc, info, nil
+}
+
+// DeleteAvatar - 사용자 아바타 삭제 (없어도 성공)
+// 부적절한 이미지를 내릴 수 있도록 정지된 계정도 허용
+func (uc *UserUseCase) DeleteAvatar(ctx context.Context, id string) error {
+	if uc.blobs == nil {
+		return ErrAvatarUnavailable
+	}
+	if id == "" {
+		return domain.ErrInvalidUserID
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return uc.deleteAvatarBlobs(ctx, user)
+}
+
+// deleteAvatarBlobs - 사용자의 아바타 블롭 모두 삭제
+func (uc *UserUseCase) deleteAvatarBlobs(ctx context.Context, user *domain.User) error {
+	var errs []error
+	for _, variant := range []domain.AvatarVariant{domain.AvatarThumbnail, domain.AvatarOriginal} {
+		if err := uc.blobs.Delete(ctx, domain.AvatarKey(user.TenantID, user.ID, variant)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cleanupAvatar - 사용자 삭제 후 아바타 정리 (사용자는 이미 삭제됐으므로 실패는 기록만)
+func (uc *UserUseCase) cleanupAvatar(ctx context.Context, user *domain.User) {
+	if uc.blobs == nil {
+		return
+	}
+	if err := uc.deleteAvatarBlobs(ctx, user); err != nil {
+		log.Printf("사용자 %s 아바타 정리 실패: %v", user.ID, err)
+	}
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+const (
+	// MaxBatchSize - 일괄 요청 하나에 담을 수 있는 최대 항목 수
+	MaxBatchSize = 100
+
+	defaultBatchConcurrency = 8
+)
+
+var (
+	// ErrInvalidBatchSize - 항목이 없거나 MaxBatchSize를 넘음
+	ErrInvalidBatchSize = domain.NewError("invalid_batch_size", "batch must contain between 1 and 100 items")
+	// ErrDuplicateBatchItem - 같은 일괄 요청의 앞 항목과 이메일(생성) 또는 ID(삭제)가 같음
+	ErrDuplicateBatchItem = domain.NewError("duplicate_batch_item", "item duplicates an earlier item in the batch")
+	// ErrBatchAborted - 원자적 일괄 처리에서 다른 항목이 실패해 반영되지 않음
+	ErrBatchAborted = domain.NewError("batch_aborted", "not applied because another item in the atomic batch failed")
+	// ErrTransactionsUnsupported - 저장소가 트랜잭션(UserTransactor)을 지원하지 않아 원자적으로 처리할 수 없음
+	ErrTransactionsUnsupported = domain.NewError("transactions_unsupported", "user store does not support atomic batches")
+)
+
+// errBatchRollback - 원자적 일괄 처리에서 항목이 실패해 트랜잭션을 취소함 (항목 에러는 결과에 있음)
+var errBatchRollback = errors.New("usecase: batch item failed")
+
+// WithBatchConcurrency - 일괄 처리에서 동시에 처리할 항목 수 (기본 8, 원자적 처리는 항상 순서대로 하나씩)
+func WithBatchConcurrency(n int) Option {
+	return func(uc *UserUseCase) {
+		uc.batchConcurrency = n
+	}
+}
+
+// BatchCreateItem - 일괄 생성 항목
+type BatchCreateItem struct {
+	Email      string
+	Name       string
+	Attributes domain.Attributes
+	Status     domain.UserStatus // 초기 상태: active(빈 값) | pending
+}
+
+// BatchResult - 항목별 처리 결과 (Index는 요청 안의 위치)
+// 조회/생성이 성공하면 User, 삭제가 성공하면 삭제 직전 상태의 User
+type BatchResult struct {
+	Index int
+	User  *domain.User
+	Err   error
+}
+
+// BatchCreateUsers - 사용자 일괄 생성
+// 항목마다 CreateUser와 같은 규칙을 적용하고 결과를 요청 순서대로 반환 (일부만 실패할 수 있음)
+// atomic이면 저장소 트랜잭션 안에서 순서대로 처리해, 하나라도 실패하면 아무것도 만들지 않음
+// (실패한 항목은 원래 에러, 나머지는 ErrBatchAborted)
+// 반환 에러는 요청 전체가 처리되지 않은 경우 (항목 수, 테넌트, 트랜잭션 미지원 등)
+func (uc *UserUseCase) BatchCreateUsers(ctx context.Context, items []BatchCreateItem, atomic bool) ([]BatchResult, error) {
+	if err := checkBatch(ctx, len(items)); err != nil {
+		return nil, err
+	}
+
+	// 같은 요청 안의 중복 이메일은 처리 순서와 무관하게 뒤 항목을 실패로
+	dup := make([]bool, len(items))
+	seen := make(map[string]struct{}, len(items))
+	for i, item := range items {
+		if _, ok := seen[item.Email]; ok {
+			dup[i] = true
+		}
+		seen[item.Email] = struct{}{}
+	}
+
+	create := func(uc *UserUseCase, i int) (*domain.User, error) {
+		item := items[i]
+		if dup[i] {
+			return nil, ErrDuplicateBatchItem
+		}
+		switch item.Status {
+		case "", domain.UserActive:
+			return uc.createUser(ctx, item.Email, item.Name, item.Attributes, domain.UserActive)
+		case domain.UserPending:
+			return uc.createUser(ctx, item.Email, item.Name, item.Attributes, domain.UserPending)
+		default:
+			return nil, domain.ErrInvalidUserStatus
+		}
+	}
+
+	if !atomic {
+		return uc.runBatch(ctx, len(items), create), nil
+	}
+	results, err := uc.runAtomic(ctx, len(items), create)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		uc.publish(ctx, domain.UserCreated, res.User)
+	}
+	return results, nil
+}
+
+// BatchGetUsers - 사용자 일괄 조회 (없는 ID 등은 항목별 에러)
+// 저장소가 UserBatchGetter면 한 번에 조회하고, 다른 테넌트 소속 ID도 없는 ID로 취급
+func (uc *UserUseCase) BatchGetUsers(ctx context.Context, ids []string) ([]BatchResult, error) {
+	if err := checkBatch(ctx, len(ids)); err != nil {
+		return nil, err
+	}
+	getter, ok := uc.userRepo.(UserBatchGetter)
+	if !ok {
+		return uc.runBatch(ctx, len(ids), func(uc *UserUseCase, i int) (*domain.User, error) {
+			return uc.GetUser(ctx, ids[i])
+		}), nil
+	}
+
+	lookup := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			lookup = append(lookup, id)
+		}
+	}
+	users, err := getter.GetByIDs(ctx, lookup)
+
+	results := make([]BatchResult, len(ids))
+	for i, id := range ids {
+		results[i].Index = i
+		switch user := users[id]; {
+		case id == "":
+			results[i].Err = domain.ErrInvalidUserID
+		case err != nil:
+			results[i].Err = err
+		case user == nil:
+			results[i].Err = domain.ErrUserNotFound
+		default:
+			results[i].User = user
+		}
+	}
+	return results, nil
+}
+
+// BatchDeleteUsers - 사용자 일괄 삭제
+// 항목마다 DeleteUser와 같은 규칙을 적용하며, atomic이면 하나라도 실패할 때 아무것도 삭제하지 않음
+// 아바타 정리와 삭제 이벤트는 원자적 처리라면 커밋한 뒤에 함
+func (uc *UserUseCase) BatchDeleteUsers(ctx context.Context, ids []string, atomic bool) ([]BatchResult, error) {
+	if err := checkBatch(ctx, len(ids)); err != nil {
+		return nil, err
+	}
+
+	dup := make([]bool, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for i, id := range ids {
+		if _, ok := seen[id]; ok {
+			dup[i] = true
+		}
+		seen[id] = struct{}{}
+	}
+
+	remove := func(uc *UserUseCase, i int) (*domain.User, error) {
+		if dup[i] {
+			return nil, ErrDuplicateBatchItem
+		}
+		return uc.deleteUser(ctx, ids[i])
+	}
+
+	if !atomic {
+		return uc.runBatch(ctx, len(ids), remove), nil
+	}
+	results, err := uc.runAtomic(ctx, len(ids), remove)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if res.Err == nil {
+			uc.cleanupAvatar(ctx, res.User)
+			uc.publish(ctx, domain.UserDeleted, res.User)
+		}
+	}
+	return results, nil
+}
+
+// checkBatch - 항목 수와 테넌트 확인
+func checkBatch(ctx context.Context, n int) error {
+	if n == 0 || n > MaxBatchSize {
+		return ErrInvalidBatchSize
+	}
+	_, err := tenant.Require(ctx)
+	return err
+}
+
+// runBatch - 항목을 최대 batchConcurrency개씩 동시에 처리 (항목 실패는 결과에만 남김)
+// 컨텍스트가 취소되면 아직 시작하지 않은 항목은 컨텍스트 에러로 실패
+func (uc *UserUseCase) runBatch(ctx context.Context, n int, fn func(uc *UserUseCase, i int) (*domain.User, error)) []BatchResult {
+	concurrency := uc.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	results := make([]BatchResult, n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		results[i].Index = i
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].User, results[i].Err = fn(uc, i)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// runAtomic - 저장소 트랜잭션 안에서 항목을 순서대로 처리하고, 하나라도 실패하면 모두 취소
+// fn에는 트랜잭션 저장소를 쓰고 이벤트 발행/아바타 정리를 하지 않는 유스케이스 사본을 넘김
+// (커밋 전에 알리면 취소된 변경이 밖으로 새므로, 후속 처리는 호출자가 커밋 뒤에 함)
+func (uc *UserUseCase) runAtomic(ctx context.Context, n int, fn func(uc *UserUseCase, i int) (*domain.User, error)) ([]BatchResult, error) {
+	transactor, ok := uc.userRepo.(UserTransactor)
+	if !ok {
+		return nil, ErrTransactionsUnsupported
+	}
+
+	results := make([]BatchResult, n)
+	failed := -1
+	err := transactor.InTransaction(ctx, func(repo UserRepository) error {
+		txuc := *uc
+		txuc.userRepo = repo
+		txuc.publishers = nil
+		txuc.blobs = nil
+
+		for i := range results {
+			results[i].Index = i
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			user, err := fn(&txuc, i)
+			if err != nil {
+				results[i].Err = err
+				failed = i
+				return errBatchRollback
+			}
+			results[i].User = user
+		}
+		return nil
+	})
+	if failed >= 0 {
+		for i := range results {
+			if i != failed {
+				results[i] = BatchResult{Index: i, Err: ErrBatchAborted}
+			}
+		}
+		return results, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
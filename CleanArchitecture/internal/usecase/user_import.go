@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// ImportRow - 가져오기 입력 한 행
+// Err는 행 단위 파싱 에러 (해당 행만 실패 처리)
+type ImportRow struct {
+	Line  int
+	Email string
+	Name  string
+	Err   error
+}
+
+// ImportRowReader - 가져오기 입력 스트림 (포트)
+// 입력이 끝나면 io.EOF, 그 외 에러는 전체 중단
+type ImportRowReader interface {
+	Next() (ImportRow, error)
+}
+
+// ImportResult - 행별 처리 결과
+type ImportResult struct {
+	Line  int
+	Email string
+	User  *domain.User
+	Err   error
+}
+
+// ImportSummary - 가져오기 요약
+type ImportSummary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	DryRun    bool
+}
+
+// ImportUsers - 사용자 일괄 가져오기 (스트리밍)
+// 행마다 domain.NewUser 검증과 이메일 중복 규칙을 적용하고 report로 결과 전달
+// dryRun이면 저장하지 않고 검증만 수행 (입력 내 중복 이메일도 검출)
+// 모든 행은 컨텍스트의 테넌트 소속으로 생성
+func (uc *UserUseCase) ImportUsers(ctx context.Context, r ImportRowReader, dryRun bool, report func(ImportResult) error) (ImportSummary, error) {
+	summary := ImportSummary{DryRun: dryRun}
+	seen := make(map[string]struct{})
+
+	if _, err := tenant.Require(ctx); err != nil {
+		return summary, err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		result := ImportResult{Line: row.Line, Email: row.Email}
+		if row.Err != nil {
+			result.Err = row.Err
+		} else {
+			result.User, result.Err = uc.importUser(ctx, row, seen, dryRun)
+		}
+
+		summary.Total++
+		if result.Err != nil {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+		}
+
+		if err := report(result); err != nil {
+			return summary, err
+		}
+	}
+}
+
+// importUser - 한 행 검증 및 저장 (검증과 저장소 중복 체크는 createUser와 같음)
+// 입력 안에서 같은 이메일이 다시 나오면 실패 처리 (dry-run은 저장하지 않으므로 저장소만으로는 검출되지 않음)
+func (uc *UserUseCase) importUser(ctx context.Context, row ImportRow, seen map[string]struct{}, dryRun bool) (*domain.User, error) {
+	if _, dup := seen[row.Email]; dup {
+		return nil, domain.ErrUserExists
+	}
+
+	var user *domain.User
+	var err error
+	if dryRun {
+		user, err = uc.newUser(ctx, row.Email, row.Name, nil, domain.UserActive)
+	} else {
+		user, err = uc.createUser(ctx, row.Email, row.Name, nil, domain.UserActive)
+	}
+	if err != nil {
+		return nil, err
+	}
+	seen[row.Email] = struct{}{}
+	return user, nil
+}
+
+// ExportUsers - 모든 사용자를 한 명씩 fn에 전달 (스트리밍)
+// 저장소가 UserStreamer를 구현하면 전체 목록을 메모리에 올리지 않음
+func (uc *UserUseCase) ExportUsers(ctx context.Context, fn func(*domain.User) error) error {
+	if streamer, ok := uc.userRepo.(UserStreamer); ok {
+		return streamer.Stream(ctx, fn)
+	}
+
+	users, err := uc.userRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
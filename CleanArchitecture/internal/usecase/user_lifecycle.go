@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// ActivateUser - 활성화 전(pending) 계정 활성화
+func (uc *UserUseCase) ActivateUser(ctx context.Context, id, actor string) (*domain.User, error) {
+	return uc.changeStatus(ctx, id, domain.UserPending, domain.UserActive, "", actor)
+}
+
+// SuspendUser - 계정 일시 정지 (사유 필수, 정지된 동안 프로필 수정 불가)
+func (uc *UserUseCase) SuspendUser(ctx context.Context, id, reason, actor string) (*domain.User, error) {
+	return uc.changeStatus(ctx, id, "", domain.UserSuspended, reason, actor)
+}
+
+// ReinstateUser - 정지된 계정 복구
+func (uc *UserUseCase) ReinstateUser(ctx context.Context, id, reason, actor string) (*domain.User, error) {
+	return uc.changeStatus(ctx, id, domain.UserSuspended, domain.UserActive, reason, actor)
+}
+
+// DeactivateUser - 계정 비활성화 (사유 필수, 되돌릴 수 없음)
+func (uc *UserUseCase) DeactivateUser(ctx context.Context, id, reason, actor string) (*domain.User, error) {
+	return uc.changeStatus(ctx, id, "", domain.UserDeactivated, reason, actor)
+}
+
+// changeStatus - 상태 전이 적용 후 저장하고 이벤트 발행
+// from이 비어 있지 않으면 현재 상태가 from일 때만 허용 (pending 활성화와 정지 복구를 구분)
+// 전이 검사와 저장은 modifyUser로 원자적으로 처리하므로, 동시에 들어온 정지/복구 중 하나만 적용됨
+func (uc *UserUseCase) changeStatus(ctx context.Context, id string, from, to domain.UserStatus, reason, actor string) (*domain.User, error) {
+	user, _, err := uc.modifyUser(ctx, id, func(user *domain.User) (bool, error) {
+		if from != "" && user.CurrentStatus() != from {
+			return false, domain.ErrInvalidStatusTransition
+		}
+		return true, user.ChangeStatus(to, reason, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, domain.UserStatusChanged, user)
+	return user, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// userLocks - 트랜잭션이 없는 저장소에서 같은 사용자의 읽기-수정-쓰기를 직렬화 (ID 해시로 나눈 잠금)
+type userLocks [64]sync.Mutex
+
+// lock - id의 잠금을 잡고 해제 함수 반환
+func (l *userLocks) lock(id string) func() {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	m := &l[h.Sum32()%uint32(len(l))]
+	m.Lock()
+	return m.Unlock
+}
+
+// preconditionKey - 컨텍스트에 조건 검사 함수를 담는 키
+type preconditionKey struct{}
+
+// WithPrecondition - 이 컨텍스트로 하는 수정/삭제가 저장 직전에 읽은 사용자로 check를 먼저 실행하도록 함
+// (HTTP If-Match처럼 클라이언트가 본 버전일 때만 바꾸는 경우; check가 에러를 반환하면 아무것도 바꾸지 않음)
+func WithPrecondition(ctx context.Context, check func(user *domain.User) error) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, check)
+}
+
+// checkPrecondition - 컨텍스트에 조건 검사 함수가 있으면 실행
+func checkPrecondition(ctx context.Context, user *domain.User) error {
+	if check, ok := ctx.Value(preconditionKey{}).(func(*domain.User) error); ok {
+		return check(user)
+	}
+	return nil
+}
+
+// modifyUser - 사용자를 읽어 fn으로 바꾼 뒤 저장 (fn이 false를 반환하면 저장하지 않음)
+// 반환한 bool은 저장했는지 여부 (이벤트 발행은 호출자가 커밋 뒤에 함)
+func (uc *UserUseCase) modifyUser(ctx context.Context, id string, fn func(user *domain.User) (bool, error)) (*domain.User, bool, error) {
+	var (
+		user  *domain.User
+		saved bool
+	)
+	err := uc.atomically(ctx, id, func(repo UserRepository, current *domain.User) (func() error, error) {
+		user = current
+		var err error
+		if saved, err = fn(current); err != nil || !saved {
+			return nil, err
+		}
+		return func() error { return repo.Update(ctx, current) }, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return user, saved, nil
+}
+
+// atomically - 사용자를 읽어 조건을 검사하고 fn이 돌려준 쓰기를 실행 (fn이 nil을 돌려주면 쓰지 않음)
+// 저장소가 UserTransactor면 읽기부터 쓰기까지 한 트랜잭션에서 처리해, 동시에 들어온 변경이 서로를 덮어쓰지 않음
+// 아니면 이 유스케이스 안에서 같은 ID의 변경을 직렬화하고, 쓰기 직전에 UpdatedAt이 읽은 값 그대로인지 다시 확인
+// (다른 인스턴스가 그 사이 바꿨으면 ErrUserModified)
+func (uc *UserUseCase) atomically(ctx context.Context, id string, fn func(repo UserRepository, current *domain.User) (func() error, error)) error {
+	if id == "" {
+		return domain.ErrInvalidUserID
+	}
+
+	apply := func(repo UserRepository, recheck bool) error {
+		current, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkPrecondition(ctx, current); err != nil {
+			return err
+		}
+		read := current.UpdatedAt
+		write, err := fn(repo, current)
+		if err != nil || write == nil {
+			return err
+		}
+		if recheck {
+			latest, err := repo.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if !latest.UpdatedAt.Equal(read) {
+				return domain.ErrUserModified
+			}
+		}
+		return write()
+	}
+
+	// 데코레이터는 원본 저장소에 트랜잭션이 없으면 ErrTransactionsUnsupported를 반환하므로 아래 방식으로 처리
+	if transactor, ok := uc.userRepo.(UserTransactor); ok {
+		err := transactor.InTransaction(ctx, func(tx UserRepository) error {
+			return apply(tx, false)
+		})
+		if !errors.Is(err, ErrTransactionsUnsupported) {
+			return err
+		}
+	}
+
+	if uc.locks != nil {
+		defer uc.locks.lock(id)()
+	}
+	return apply(uc.userRepo, true)
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// EraseUser - 사용자 개인정보 삭제 (잊힐 권리)
+// delete는 사용자와 아바타를 지우고, anonymize는 행을 남긴 채 개인정보만 지우고 계정을 비활성화
+// 이벤트는 개인정보가 다시 퍼지지 않도록 ID와 시각만 실어 user.erased로 발행 (삭제/수정 이벤트는 발행하지 않음)
+func (uc *UserUseCase) EraseUser(ctx context.Context, id string, mode domain.ErasureMode, actor string) error {
+	if id == "" {
+		return domain.ErrInvalidUserID
+	}
+	if !mode.Valid() {
+		return domain.ErrInvalidErasureMode
+	}
+
+	var user *domain.User
+	switch mode {
+	case domain.ErasureDelete:
+		current, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.userRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		user = current
+	case domain.ErasureAnonymize:
+		anonymized, _, err := uc.modifyUser(ctx, id, func(user *domain.User) (bool, error) {
+			return true, user.Anonymize(actor)
+		})
+		if err != nil {
+			return err
+		}
+		user = anonymized
+	}
+	uc.cleanupAvatar(ctx, user)
+
+	uc.publish(ctx, domain.UserErased, &domain.User{
+		ID:        user.ID,
+		TenantID:  user.TenantID,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: time.Now(),
+	})
+	return nil
+}
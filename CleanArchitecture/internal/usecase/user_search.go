@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// 검색 결과 수 제한
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	maxQueryLength = 200 // 검색어 최대 글자 수
+)
+
+// ErrSearchUnavailable - 저장소가 UserSearcher를 구현하지 않음
+var ErrSearchUnavailable = domain.NewError("search_unavailable", "user search is not available")
+
+// SearchUsers - 이름/이메일 부분 검색 (관련도 순)
+// limit이 0 이하면 DefaultSearchLimit, MaxSearchLimit보다 크면 MaxSearchLimit
+func (uc *UserUseCase) SearchUsers(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > maxQueryLength {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	searcher, ok := uc.userRepo.(UserSearcher)
+	if !ok {
+		return nil, ErrSearchUnavailable
+	}
+
+	switch {
+	case limit <= 0:
+		limit = DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		limit = MaxSearchLimit
+	}
+	return searcher.Search(ctx, query, limit)
+}
@@ -2,45 +2,139 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
 )
 
 // UserUseCase - 사용자 관련 유스케이스 (애플리케이션 비즈니스 규칙)
 type UserUseCase struct {
-	userRepo UserRepository
+	userRepo   UserRepository
+	publishers []EventPublisher        // 비어 있으면 이벤트 발행 안 함
+	schemas    *AttributeSchemaUseCase // nil이면 사용자 정의 속성을 받지 않음
+	blobs      BlobStore               // nil이면 아바타 기능 사용 안 함
+
+	batchConcurrency int // 일괄 처리 동시 항목 수 (0이면 기본값)
+
+	locks *userLocks // 트랜잭션이 없는 저장소에서 같은 사용자 변경 직렬화 (modifyUser)
+}
+
+// Option - 선택적 유스케이스 설정
+type Option func(*UserUseCase)
+
+// WithEventPublisher - 생성/수정/삭제 후 이벤트 발행 (여러 번 지정하면 등록 순서대로 모두에게 발행)
+func WithEventPublisher(p EventPublisher) Option {
+	return func(uc *UserUseCase) {
+		uc.publishers = append(uc.publishers, p)
+	}
+}
+
+// WithAttributeSchemas - 사용자 정의 속성 사용 (네임스페이스별 스키마로 검증)
+func WithAttributeSchemas(s *AttributeSchemaUseCase) Option {
+	return func(uc *UserUseCase) {
+		uc.schemas = s
+	}
+}
+
+// WithBlobStore - 아바타 이미지를 저장할 블롭 저장소 사용
+func WithBlobStore(b BlobStore) Option {
+	return func(uc *UserUseCase) {
+		uc.blobs = b
+	}
 }
 
 // NewUserUseCase - UserUseCase 생성자
-func NewUserUseCase(userRepo UserRepository) *UserUseCase {
-	return &UserUseCase{
+func NewUserUseCase(userRepo UserRepository, opts ...Option) *UserUseCase {
+	uc := &UserUseCase{
 		userRepo: userRepo,
+		locks:    new(userLocks),
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// publish - 이벤트 발행 (발행기가 없으면 무시, 모든 발행기가 같은 이벤트 ID를 받음)
+func (uc *UserUseCase) publish(ctx context.Context, eventType domain.UserEventType, user *domain.User) {
+	if len(uc.publishers) == 0 {
+		return
+	}
+	event := domain.UserEvent{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		TenantID:   user.TenantID,
+		User:       *user,
+		OccurredAt: time.Now(),
+	}
+	for _, p := range uc.publishers {
+		p.Publish(ctx, event)
 	}
 }
 
-// CreateUser - 사용자 생성 유스케이스
+// CreateUser - 사용자 생성 유스케이스 (컨텍스트의 테넌트 소속으로 생성)
 func (uc *UserUseCase) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
+	return uc.CreateUserWithAttributes(ctx, email, name, nil)
+}
+
+// CreateUserWithAttributes - 사용자 정의 속성과 함께 사용자 생성 (속성은 네임스페이스별로 검증)
+func (uc *UserUseCase) CreateUserWithAttributes(ctx context.Context, email, name string, attrs domain.Attributes) (*domain.User, error) {
+	return uc.createUser(ctx, email, name, attrs, domain.UserActive)
+}
+
+// CreatePendingUser - 활성화 전(pending) 상태로 사용자 생성 (초대 등, ActivateUser로 활성화)
+func (uc *UserUseCase) CreatePendingUser(ctx context.Context, email, name string, attrs domain.Attributes) (*domain.User, error) {
+	return uc.createUser(ctx, email, name, attrs, domain.UserPending)
+}
+
+func (uc *UserUseCase) createUser(ctx context.Context, email, name string, attrs domain.Attributes, status domain.UserStatus) (*domain.User, error) {
+	user, err := uc.newUser(ctx, email, name, attrs, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 저장
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, domain.UserCreated, user)
+	return user, nil
+}
+
+// newUser - 저장 전까지의 생성 단계 (검증, 중복 체크, ID/테넌트/상태 지정), 가져오기 dry-run에서도 사용
+func (uc *UserUseCase) newUser(ctx context.Context, email, name string, attrs domain.Attributes, status domain.UserStatus) (*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. 도메인 엔티티 생성 (비즈니스 규칙 적용)
 	user, err := domain.NewUser(email, name)
 	if err != nil {
 		return nil, err
 	}
+	for namespace, values := range attrs {
+		if err := uc.validateAttributes(ctx, namespace, values); err != nil {
+			return nil, err
+		}
+		if err := user.SetAttributes(namespace, values); err != nil {
+			return nil, err
+		}
+	}
 
-	// 2. 중복 체크 (애플리케이션 비즈니스 규칙)
+	// 2. 중복 체크 (애플리케이션 비즈니스 규칙, 이메일은 테넌트 안에서 고유)
 	existingUser, _ := uc.userRepo.GetByEmail(ctx, email)
 	if existingUser != nil {
 		return nil, domain.ErrUserExists
 	}
 
-	// 3. ID 생성
+	// 3. ID 생성 및 테넌트, 초기 상태 지정
 	user.ID = uuid.New().String()
-
-	// 4. 저장
-	if err := uc.userRepo.Create(ctx, user); err != nil {
-		return nil, err
-	}
-
+	user.TenantID = tenantID
+	user.Status = status
 	return user, nil
 }
 
@@ -67,39 +161,41 @@ func (uc *UserUseCase) GetAllUsers(ctx context.Context) ([]*domain.User, error)
 	return users, nil
 }
 
-// UpdateUser - 사용자 정보 수정
+// UpdateUser - 사용자 정보 수정 (조회부터 저장까지 원자적으로, modifyUser 참고)
 func (uc *UserUseCase) UpdateUser(ctx context.Context, id, name string) (*domain.User, error) {
-	// 1. 사용자 조회
-	user, err := uc.userRepo.GetByID(ctx, id)
+	user, _, err := uc.modifyUser(ctx, id, func(user *domain.User) (bool, error) {
+		// 도메인 로직으로 업데이트 (정지/비활성화된 계정은 수정 불가)
+		if err := user.CheckMutable(); err != nil {
+			return false, err
+		}
+		return true, user.UpdateName(name)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. 도메인 로직으로 업데이트
-	if err := user.UpdateName(name); err != nil {
-		return nil, err
-	}
-
-	// 3. 저장
-	if err := uc.userRepo.Update(ctx, user); err != nil {
-		return nil, err
-	}
-
+	uc.publish(ctx, domain.UserUpdated, user)
 	return user, nil
 }
 
 // DeleteUser - 사용자 삭제
 func (uc *UserUseCase) DeleteUser(ctx context.Context, id string) error {
-	if id == "" {
-		return domain.ErrInvalidUserID
-	}
+	_, err := uc.deleteUser(ctx, id)
+	return err
+}
 
-	// 1. 존재 확인
-	_, err := uc.userRepo.GetByID(ctx, id)
+// deleteUser - 사용자 삭제 후 삭제 직전 상태 반환 (삭제 이벤트에 마지막 상태를 싣기 위해 보관)
+func (uc *UserUseCase) deleteUser(ctx context.Context, id string) (*domain.User, error) {
+	var user *domain.User
+	err := uc.atomically(ctx, id, func(repo UserRepository, current *domain.User) (func() error, error) {
+		user = current
+		return func() error { return repo.Delete(ctx, id) }, nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	uc.cleanupAvatar(ctx, user)
 
-	// 2. 삭제
-	return uc.userRepo.Delete(ctx, id)
+	uc.publish(ctx, domain.UserDeleted, user)
+	return user, nil
 }
@@ -0,0 +1,664 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	mathrand "math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// Config - 전송 설정 (0 값은 기본값 사용)
+type Config struct {
+	Client       *http.Client  // nil이면 리다이렉트를 따라가지 않는 기본 클라이언트
+	Timeout      time.Duration // 시도당 제한 시간 (기본 10초)
+	MaxAttempts  int           // 전송당 최대 시도 수 (기본 8)
+	BaseBackoff  time.Duration // 첫 재시도 대기 (기본 30초, 시도마다 2배)
+	MaxBackoff   time.Duration // 재시도 대기 상한 (기본 1시간)
+	DisableAfter int           // 연속 실패 시도가 이만큼 쌓이면 구독 비활성화 (기본 20)
+	PollInterval time.Duration // 대기 중 전송 확인 주기 (기본 1초)
+	Concurrency  int           // 동시 전송 수 (기본 4)
+
+	// AllowPrivateNetworks - 루프백/링크 로컬/사설 주소로도 전송 허용 (로컬 개발/테스트용)
+	// false면 그런 URL의 구독을 거부하고, 기본 클라이언트는 연결할 때도 주소를 확인함
+	// (공개 도메인이 내부 주소로 풀리는 경우 방지)
+	AllowPrivateNetworks bool
+}
+
+// Service - 웹훅 구독 관리 + 이벤트 전송 (usecase.EventPublisher 구현)
+type Service struct {
+	store  Store
+	cfg    Config
+	client *http.Client
+
+	now    func() time.Time
+	jitter func(d time.Duration) time.Duration
+
+	wake       chan struct{}
+	processing sync.Mutex
+	records    sync.Mutex // 전송 결과 저장과 본문 삭제(RedactUserDeliveries)를 순서대로
+}
+
+// NewService - Service 생성자
+func NewService(store Store, cfg Config) *Service {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.DisableAfter <= 0 {
+		cfg.DisableAfter = 20
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{
+			// 3xx도 실패로 취급 (구독 URL을 정확히 등록하도록)
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		if !cfg.AllowPrivateNetworks {
+			client.Transport = publicTransport()
+		}
+	}
+
+	return &Service{
+		store:  store,
+		cfg:    cfg,
+		client: client,
+		now:    time.Now,
+		jitter: equalJitter,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// SubscriptionInput - 구독 생성 입력
+type SubscriptionInput struct {
+	URL    string
+	Events []domain.UserEventType // 비어 있으면 모든 이벤트
+	Secret string                 // 비어 있으면 생성
+}
+
+// SubscriptionPatch - 구독 수정 입력 (nil 필드는 유지)
+type SubscriptionPatch struct {
+	URL    *string
+	Events *[]domain.UserEventType
+	Active *bool // true로 바꾸면 연속 실패 수 초기화
+}
+
+// Subscribe - 컨텍스트 테넌트에 구독 생성
+func (s *Service) Subscribe(ctx context.Context, in SubscriptionInput) (*Subscription, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateURL(in.URL); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(in.Events); err != nil {
+		return nil, err
+	}
+
+	secret := in.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	sub := &Subscription{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		URL:       in.URL,
+		Events:    in.Events,
+		Secret:    secret,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Subscription - 구독 조회
+func (s *Service) Subscription(ctx context.Context, id string) (*Subscription, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetSubscription(ctx, tenantID, id)
+}
+
+// Subscriptions - 테넌트의 구독 목록
+func (s *Service) Subscriptions(ctx context.Context) ([]*Subscription, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListSubscriptions(ctx, tenantID)
+}
+
+// UpdateSubscription - 구독 수정 (비활성화된 구독 재활성화 포함)
+func (s *Service) UpdateSubscription(ctx context.Context, id string, patch SubscriptionPatch) (*Subscription, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if patch.URL != nil {
+		if err := s.validateURL(*patch.URL); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Events != nil {
+		if err := validateEvents(*patch.Events); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	return s.store.UpdateSubscription(ctx, tenantID, id, func(sub *Subscription) error {
+		if patch.URL != nil {
+			sub.URL = *patch.URL
+		}
+		if patch.Events != nil {
+			sub.Events = *patch.Events
+		}
+		if patch.Active != nil {
+			sub.Active = *patch.Active
+			if sub.Active {
+				sub.ConsecutiveFailures = 0
+				sub.DisabledAt = nil
+			}
+		}
+		sub.UpdatedAt = now
+		return nil
+	})
+}
+
+// Unsubscribe - 구독 삭제 (전송 기록 포함)
+func (s *Service) Unsubscribe(ctx context.Context, id string) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	return s.store.DeleteSubscription(ctx, tenantID, id)
+}
+
+// Deliveries - 구독의 전송 기록 (최신순)
+func (s *Service) Deliveries(ctx context.Context, subscriptionID string) ([]*Delivery, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListDeliveries(ctx, tenantID, subscriptionID)
+}
+
+// Redeliver - 전송을 새 전송으로 다시 보냄 (원래 기록은 그대로 유지)
+func (s *Service) Redeliver(ctx context.Context, subscriptionID, deliveryID string) (*Delivery, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orig, err := s.store.GetDelivery(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if orig.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotFound
+	}
+	sub, err := s.store.GetSubscription(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.Active {
+		return nil, ErrSubscriptionDisabled
+	}
+
+	now := s.now()
+	d := &Delivery{
+		ID:             uuid.New().String(),
+		TenantID:       orig.TenantID,
+		SubscriptionID: orig.SubscriptionID,
+		EventID:        orig.EventID,
+		EventType:      orig.EventType,
+		UserID:         orig.UserID,
+		Payload:        orig.Payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		RedeliveryOf:   orig.ID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.store.SaveDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return d, nil
+}
+
+// UserDeliveries - 사용자에 대한 이벤트의 전송 기록 (모든 구독, 오래된 순)
+func (s *Service) UserDeliveries(ctx context.Context, userID string) ([]*Delivery, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListUserDeliveries(ctx, tenantID, userID)
+}
+
+// RedactUserDeliveries - 사용자에 대한 전송 기록의 본문에서 개인정보(이메일, 이름)를 지우고 바꾼 수 반환
+// 아직 보내지 않은 전송은 지운 본문으로 나감 (이미 보내는 중인 요청은 막을 수 없음)
+func (s *Service) RedactUserDeliveries(ctx context.Context, userID string) (int, error) {
+	s.records.Lock()
+	defer s.records.Unlock()
+
+	deliveries, err := s.UserDeliveries(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	redacted := 0
+	for _, d := range deliveries {
+		var p eventPayload
+		if err := json.Unmarshal(d.Payload, &p); err != nil {
+			return redacted, fmt.Errorf("전송 %s 본문 해석 실패: %w", d.ID, err)
+		}
+		if p.Data.Email == "" && p.Data.Name == "" {
+			continue
+		}
+		p.Data.Email, p.Data.Name = "", ""
+		if d.Payload, err = json.Marshal(p); err != nil {
+			return redacted, err
+		}
+		d.UpdatedAt = s.now()
+		if err := s.store.SaveDelivery(ctx, d); err != nil {
+			return redacted, err
+		}
+		redacted++
+	}
+	return redacted, nil
+}
+
+// eventPayload - 전송 본문
+type eventPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	TenantID  string      `json:"tenant_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      userPayload `json:"data"`
+}
+
+type userPayload struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Publish - 이벤트를 구독한 활성 구독마다 전송 대기열에 추가 (usecase.EventPublisher 구현)
+// 실제 전송은 Run 워커가 수행하므로 사용자 요청을 지연시키지 않음
+func (s *Service) Publish(ctx context.Context, event domain.UserEvent) {
+	// 요청이 끝나도 대기열 기록은 마치도록 취소만 분리
+	ctx = context.WithoutCancel(ctx)
+
+	subs, err := s.store.ListSubscriptions(ctx, event.TenantID)
+	if err != nil {
+		log.Printf("웹훅 구독 조회 실패 (이벤트 %s): %v", event.ID, err)
+		return
+	}
+
+	payload, err := json.Marshal(eventPayload{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		TenantID:  event.TenantID,
+		CreatedAt: event.OccurredAt.UTC(),
+		Data: userPayload{
+			ID:        event.User.ID,
+			Email:     event.User.Email,
+			Name:      event.User.Name,
+			CreatedAt: event.User.CreatedAt.UTC(),
+			UpdatedAt: event.User.UpdatedAt.UTC(),
+		},
+	})
+	if err != nil {
+		log.Printf("웹훅 본문 생성 실패 (이벤트 %s): %v", event.ID, err)
+		return
+	}
+
+	now := s.now()
+	queued := 0
+	for _, sub := range subs {
+		if !sub.Active || !sub.Matches(event.Type) {
+			continue
+		}
+		d := &Delivery{
+			ID:             uuid.New().String(),
+			TenantID:       event.TenantID,
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			UserID:         event.User.ID,
+			Payload:        payload,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := s.store.SaveDelivery(ctx, d); err != nil {
+			log.Printf("웹훅 전송 대기열 추가 실패 (구독 %s): %v", sub.ID, err)
+			continue
+		}
+		queued++
+	}
+	if queued > 0 {
+		s.notify()
+	}
+}
+
+// Run - 대기 중 전송을 처리하는 워커 (ctx가 취소될 때까지 실행)
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.ProcessDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessDue - 전송 시각이 된 대기 중 전송을 한 번 처리하고 시도 수 반환
+// 동시에 여러 번 호출되면 순서대로 실행 (같은 전송을 두 번 보내지 않음)
+func (s *Service) ProcessDue(ctx context.Context) int {
+	s.processing.Lock()
+	defer s.processing.Unlock()
+
+	due, err := s.store.DueDeliveries(ctx, s.now(), s.cfg.Concurrency*16)
+	if err != nil {
+		log.Printf("웹훅 전송 대기열 조회 실패: %v", err)
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	for _, d := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *Delivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.deliver(ctx, d)
+		}(d)
+	}
+	wg.Wait()
+
+	return len(due)
+}
+
+// deliver - 전송 한 번 시도하고 결과에 따라 완료/재시도 예약/실패 처리
+func (s *Service) deliver(ctx context.Context, d *Delivery) {
+	sub, err := s.store.GetSubscription(ctx, d.TenantID, d.SubscriptionID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("웹훅 구독 조회 실패 (전송 %s): %v", d.ID, err)
+		return
+	}
+	if !sub.Active {
+		s.finish(ctx, d, DeliveryFailed, Attempt{At: s.now(), Error: ErrSubscriptionDisabled.Error()})
+		return
+	}
+
+	attempt := s.send(ctx, sub, d)
+	if attempt.Error == "" {
+		s.recordResult(ctx, d, true)
+		s.finish(ctx, d, DeliverySucceeded, attempt)
+		return
+	}
+
+	sub = s.recordResult(ctx, d, false)
+	switch {
+	case sub != nil && !sub.Active:
+		s.finish(ctx, d, DeliveryFailed, attempt)
+	case len(d.Attempts)+1 >= s.cfg.MaxAttempts:
+		s.finish(ctx, d, DeliveryFailed, attempt)
+	default:
+		d.NextAttemptAt = attempt.At.Add(s.backoff(len(d.Attempts) + 1))
+		s.finish(ctx, d, DeliveryPending, attempt)
+	}
+}
+
+// send - 서명된 요청 전송 (2xx만 성공)
+func (s *Service) send(ctx context.Context, sub *Subscription, d *Delivery) Attempt {
+	start := s.now()
+	attempt := Attempt{At: start}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "clean-architecture-webhooks/1.0")
+	req.Header.Set(HeaderEventID, d.EventID)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, start, d.Payload))
+
+	resp, err := s.client.Do(req)
+	attempt.Duration = s.now().Sub(start)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// recordResult - 구독의 연속 실패 수 갱신, 기준을 넘으면 비활성화
+func (s *Service) recordResult(ctx context.Context, d *Delivery, success bool) *Subscription {
+	now := s.now()
+	sub, err := s.store.UpdateSubscription(ctx, d.TenantID, d.SubscriptionID, func(sub *Subscription) error {
+		if success {
+			sub.ConsecutiveFailures = 0
+			return nil
+		}
+		sub.ConsecutiveFailures++
+		if sub.Active && sub.ConsecutiveFailures >= s.cfg.DisableAfter {
+			sub.Active = false
+			sub.DisabledAt = &now
+			sub.UpdatedAt = now
+			log.Printf("웹훅 구독 %s 비활성화: 연속 %d회 실패", sub.ID, sub.ConsecutiveFailures)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("웹훅 구독 상태 갱신 실패 (구독 %s): %v", d.SubscriptionID, err)
+		return nil
+	}
+	return sub
+}
+
+// finish - 시도 기록을 붙여 전송 상태 저장
+func (s *Service) finish(ctx context.Context, d *Delivery, status DeliveryStatus, attempt Attempt) {
+	s.records.Lock()
+	defer s.records.Unlock()
+
+	// 보내는 동안 본문이 지워졌으면 지운 본문을 유지
+	if stored, err := s.store.GetDelivery(ctx, d.TenantID, d.ID); err == nil {
+		d.Payload = stored.Payload
+	}
+	d.Status = status
+	d.Attempts = append(d.Attempts, attempt)
+	d.UpdatedAt = s.now()
+	if err := s.store.SaveDelivery(ctx, d); err != nil {
+		log.Printf("웹훅 전송 기록 저장 실패 (전송 %s): %v", d.ID, err)
+	}
+}
+
+// backoff - n번째 실패 후 대기 시간 (지수 증가 + 지터, 상한 MaxBackoff)
+func (s *Service) backoff(n int) time.Duration {
+	d := s.cfg.BaseBackoff
+	for i := 1; i < n && d < s.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	return s.jitter(d)
+}
+
+// notify - 워커 깨우기 (이미 신호가 있으면 생략)
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// equalJitter - [d/2, d) 구간 난수 (동시 재시도가 한꺼번에 몰리지 않도록)
+func equalJitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + mathrand.N(half)
+}
+
+// validateURL - 절대 http(s) URL만 허용
+// AllowPrivateNetworks가 아니면 localhost와 내부 주소 IP도 거부 (도메인은 연결할 때 publicTransport가 확인)
+func (s *Service) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if s.cfg.AllowPrivateNetworks {
+		return nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenDestination
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && forbiddenAddr(addr) {
+		return ErrForbiddenDestination
+	}
+	return nil
+}
+
+var (
+	thisNetwork        = netip.MustParsePrefix("0.0.0.0/8")
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10") // 통신사 NAT (RFC 6598)
+)
+
+// forbiddenAddr - 웹훅을 보내면 안 되는 주소 (루프백, 사설, 링크 로컬, 미지정, 멀티캐스트)
+func forbiddenAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		thisNetwork.Contains(addr) || sharedAddressSpace.Contains(addr)
+}
+
+// publicTransport - 연결하려는 주소가 forbiddenAddr면 거부하는 Transport
+// DNS 응답이 바뀌어도(리바인딩) 실제로 연결하는 주소를 확인하고,
+// 프록시를 거치면 목적지를 확인할 수 없으므로 환경 변수 프록시는 쓰지 않음
+func publicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if forbiddenAddr(ap.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenDestination, ap.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// validateEvents - 지원하는 이벤트 종류인지 확인
+func validateEvents(events []domain.UserEventType) error {
+	for _, e := range events {
+		known := false
+		for _, k := range domain.UserEvents {
+			if e == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrInvalidEvent, e)
+		}
+	}
+	return nil
+}
+
+// newSecret - 서명용 비밀 키 생성
+func newSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
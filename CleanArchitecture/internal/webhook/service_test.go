@@ -0,0 +1,385 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+	"github.com/milman2/go-api/clean-architecture/internal/tenant"
+)
+
+// receiver - 받은 요청을 기록하고 statuses 순서대로 응답하는 테스트 수신 서버
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+
+	status := http.StatusNoContent
+	if len(rc.statuses) > 0 {
+		status = rc.statuses[0]
+		rc.statuses = rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.requests)
+}
+
+// clock - 테스트에서 직접 움직이는 시계
+type clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *clock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// newTestService - httptest 수신 서버와 고정 시계를 쓰는 Service (지터 없음)
+func newTestService(t *testing.T, cfg Config, statuses ...int) (*Service, *receiver, *clock, string) {
+	t.Helper()
+
+	rc := &receiver{statuses: statuses}
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+
+	clk := &clock{t: time.Now()}
+	cfg.Client = srv.Client()
+	cfg.AllowPrivateNetworks = true // 수신 서버가 127.0.0.1
+	svc := NewService(NewMemoryStore(), cfg)
+	svc.now = clk.now
+	svc.jitter = func(d time.Duration) time.Duration { return d }
+	return svc, rc, clk, srv.URL
+}
+
+func subscribe(t *testing.T, ctx context.Context, svc *Service, url string, events ...domain.UserEventType) *Subscription {
+	t.Helper()
+	sub, err := svc.Subscribe(ctx, SubscriptionInput{URL: url, Events: events, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	return sub
+}
+
+func publish(ctx context.Context, svc *Service, typ domain.UserEventType) {
+	svc.Publish(ctx, domain.UserEvent{
+		ID:         "evt-1",
+		Type:       typ,
+		TenantID:   tenant.Default,
+		User:       domain.User{ID: "u1", Email: "a@example.com", Name: "A"},
+		OccurredAt: time.Now(),
+	})
+}
+
+func TestDeliverySignedAndVerifiable(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc, rc, clk, url := newTestService(t, Config{})
+
+	subscribe(t, ctx, svc, url, domain.UserCreated)
+	publish(ctx, svc, domain.UserCreated)
+	publish(ctx, svc, domain.UserDeleted) // 구독하지 않은 이벤트
+
+	if n := svc.ProcessDue(ctx); n != 1 {
+		t.Fatalf("ProcessDue = %d, want 1", n)
+	}
+	if rc.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", rc.count())
+	}
+
+	req, body := rc.requests[0], rc.bodies[0]
+	if got := req.Header.Get(HeaderEvent); got != string(domain.UserCreated) {
+		t.Errorf("%s = %q", HeaderEvent, got)
+	}
+	if got := req.Header.Get(HeaderEventID); got != "evt-1" {
+		t.Errorf("%s = %q", HeaderEventID, got)
+	}
+	if req.Header.Get(HeaderDelivery) == "" {
+		t.Errorf("%s missing", HeaderDelivery)
+	}
+	if err := Verify("s3cret", req.Header, body, 5*time.Minute, clk.now()); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := Verify("other", req.Header, body, 5*time.Minute, clk.now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("Verify with wrong secret = %v, want ErrInvalidSignature", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload["type"] != string(domain.UserCreated) || payload["tenant_id"] != tenant.Default {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestRetryWithBackoffThenSuccess(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc, rc, clk, url := newTestService(t, Config{BaseBackoff: time.Second},
+		http.StatusInternalServerError, http.StatusBadGateway)
+
+	sub := subscribe(t, ctx, svc, url)
+	publish(ctx, svc, domain.UserUpdated)
+
+	// 1차 실패 → 1초 뒤 재시도
+	svc.ProcessDue(ctx)
+	if n := svc.ProcessDue(ctx); n != 0 {
+		t.Fatalf("retry ran before backoff elapsed")
+	}
+	clk.advance(time.Second)
+
+	// 2차 실패 → 2초 뒤 재시도
+	svc.ProcessDue(ctx)
+	clk.advance(time.Second)
+	if n := svc.ProcessDue(ctx); n != 0 {
+		t.Fatalf("second retry ran before backoff doubled")
+	}
+	clk.advance(time.Second)
+
+	// 3차 성공
+	svc.ProcessDue(ctx)
+	if rc.count() != 3 {
+		t.Fatalf("receiver got %d requests, want 3", rc.count())
+	}
+
+	deliveries, err := svc.Deliveries(ctx, sub.ID)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("Deliveries = %v, %v", deliveries, err)
+	}
+	d := deliveries[0]
+	if d.Status != DeliverySucceeded || len(d.Attempts) != 3 {
+		t.Fatalf("delivery status %s with %d attempts", d.Status, len(d.Attempts))
+	}
+	if d.Attempts[0].StatusCode != http.StatusInternalServerError || d.Attempts[2].StatusCode != http.StatusNoContent {
+		t.Errorf("attempts = %+v", d.Attempts)
+	}
+
+	got, _ := svc.Subscription(ctx, sub.ID)
+	if got.ConsecutiveFailures != 0 {
+		t.Errorf("ConsecutiveFailures = %d after success", got.ConsecutiveFailures)
+	}
+}
+
+func TestMaxAttemptsMarksFailed(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc, _, clk, url := newTestService(t, Config{MaxAttempts: 2, BaseBackoff: time.Second},
+		http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	sub := subscribe(t, ctx, svc, url)
+	publish(ctx, svc, domain.UserCreated)
+
+	for i := 0; i < 3; i++ {
+		svc.ProcessDue(ctx)
+		clk.advance(time.Hour)
+	}
+
+	deliveries, _ := svc.Deliveries(ctx, sub.ID)
+	if d := deliveries[0]; d.Status != DeliveryFailed || len(d.Attempts) != 2 {
+		t.Fatalf("delivery status %s with %d attempts, want failed after 2", d.Status, len(d.Attempts))
+	}
+}
+
+func TestDisableAfterRepeatedFailures(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc, rc, clk, url := newTestService(t, Config{DisableAfter: 3, BaseBackoff: time.Second},
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	sub := subscribe(t, ctx, svc, url)
+	publish(ctx, svc, domain.UserCreated)
+
+	for i := 0; i < 5; i++ {
+		svc.ProcessDue(ctx)
+		clk.advance(time.Hour)
+	}
+	if rc.count() != 3 {
+		t.Fatalf("receiver got %d requests, want 3", rc.count())
+	}
+
+	got, _ := svc.Subscription(ctx, sub.ID)
+	if got.Active || got.DisabledAt == nil {
+		t.Fatalf("subscription still active after %d failures", got.ConsecutiveFailures)
+	}
+
+	// 비활성 구독에는 새 이벤트를 쌓지 않고, 재전송도 거부
+	publish(ctx, svc, domain.UserUpdated)
+	deliveries, _ := svc.Deliveries(ctx, sub.ID)
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryFailed {
+		t.Fatalf("deliveries = %d, status %s", len(deliveries), deliveries[0].Status)
+	}
+	if _, err := svc.Redeliver(ctx, sub.ID, deliveries[0].ID); !errors.Is(err, ErrSubscriptionDisabled) {
+		t.Fatalf("Redeliver on disabled = %v", err)
+	}
+
+	// 다시 활성화하면 연속 실패 수 초기화
+	active := true
+	got, err := svc.UpdateSubscription(ctx, sub.ID, SubscriptionPatch{Active: &active})
+	if err != nil || !got.Active || got.ConsecutiveFailures != 0 || got.DisabledAt != nil {
+		t.Fatalf("re-enable = %+v, %v", got, err)
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc, rc, _, url := newTestService(t, Config{})
+
+	sub := subscribe(t, ctx, svc, url)
+	publish(ctx, svc, domain.UserCreated)
+	svc.ProcessDue(ctx)
+
+	deliveries, _ := svc.Deliveries(ctx, sub.ID)
+	orig := deliveries[0]
+
+	d, err := svc.Redeliver(ctx, sub.ID, orig.ID)
+	if err != nil {
+		t.Fatalf("Redeliver: %v", err)
+	}
+	if d.RedeliveryOf != orig.ID || d.EventID != orig.EventID || d.ID == orig.ID {
+		t.Fatalf("redelivery = %+v", d)
+	}
+	svc.ProcessDue(ctx)
+
+	if rc.count() != 2 {
+		t.Fatalf("receiver got %d requests, want 2", rc.count())
+	}
+	if a, b := rc.requests[0].Header.Get(HeaderEventID), rc.requests[1].Header.Get(HeaderEventID); a != b {
+		t.Errorf("event id changed on redelivery: %q != %q", a, b)
+	}
+
+	// 다른 테넌트에서는 조회/재전송 불가
+	other := tenant.WithID(context.Background(), "acme")
+	if _, err := svc.Redeliver(other, sub.ID, orig.ID); !errors.Is(err, domain.ErrCrossTenant) {
+		t.Fatalf("cross-tenant Redeliver = %v", err)
+	}
+	if _, err := svc.Redeliver(ctx, sub.ID, "missing"); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Fatalf("Redeliver missing = %v", err)
+	}
+}
+
+func TestSubscribeValidation(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc := NewService(NewMemoryStore(), Config{})
+
+	if _, err := svc.Subscribe(ctx, SubscriptionInput{URL: "ftp://example.com"}); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("bad scheme = %v", err)
+	}
+	if _, err := svc.Subscribe(ctx, SubscriptionInput{URL: "https://example.com", Events: []domain.UserEventType{"user.exploded"}}); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("bad event = %v", err)
+	}
+	if _, err := svc.Subscribe(context.Background(), SubscriptionInput{URL: "https://example.com"}); !errors.Is(err, domain.ErrTenantRequired) {
+		t.Errorf("no tenant = %v", err)
+	}
+
+	sub, err := svc.Subscribe(ctx, SubscriptionInput{URL: "https://example.com/hook"})
+	if err != nil || sub.Secret == "" {
+		t.Fatalf("generated secret = %q, %v", sub.Secret, err)
+	}
+}
+
+// 내부 네트워크 주소로는 구독할 수 없음 (서버가 대신 요청을 보내 내부 서비스에 접근하는 것 방지)
+func TestSubscribeForbiddenDestination(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	svc := NewService(NewMemoryStore(), Config{})
+
+	for _, u := range []string{
+		"http://localhost:8080/hook",
+		"http://api.localhost/hook",
+		"http://127.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.5/hook",
+		"http://172.16.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[fe80::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"http://100.64.0.1/hook",
+	} {
+		if _, err := svc.Subscribe(ctx, SubscriptionInput{URL: u}); !errors.Is(err, ErrForbiddenDestination) {
+			t.Errorf("Subscribe(%s) = %v, want ErrForbiddenDestination", u, err)
+		}
+	}
+
+	sub, err := svc.Subscribe(ctx, SubscriptionInput{URL: "https://93.184.216.34/hook"})
+	if err != nil {
+		t.Fatalf("public address: %v", err)
+	}
+	internal := "http://10.0.0.5/hook"
+	if _, err := svc.UpdateSubscription(ctx, sub.ID, SubscriptionPatch{URL: &internal}); !errors.Is(err, ErrForbiddenDestination) {
+		t.Errorf("UpdateSubscription = %v, want ErrForbiddenDestination", err)
+	}
+
+	// 로컬 개발용 설정에서는 허용
+	dev := NewService(NewMemoryStore(), Config{AllowPrivateNetworks: true})
+	if _, err := dev.Subscribe(ctx, SubscriptionInput{URL: "http://localhost:8080/hook"}); err != nil {
+		t.Errorf("AllowPrivateNetworks: %v", err)
+	}
+}
+
+// 도메인이 내부 주소로 풀려도 연결할 때 거부
+func TestPublicTransportRejectsInternalAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached internal server")
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: publicTransport()}
+	_, err := client.Get(srv.URL)
+	if !errors.Is(err, ErrForbiddenDestination) {
+		t.Fatalf("Get(%s) = %v, want ErrForbiddenDestination", srv.URL, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt-1"}`)
+	ts := time.Unix(1_700_000_000, 0)
+
+	header := http.Header{}
+	header.Set(HeaderTimestamp, "1700000000")
+	header.Set(HeaderSignature, Sign("s3cret", ts, body))
+
+	if err := Verify("s3cret", header, body, time.Minute, ts.Add(30*time.Second)); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := Verify("s3cret", header, []byte(`{"id":"evt-2"}`), time.Minute, ts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered body = %v", err)
+	}
+	if err := Verify("s3cret", header, body, time.Minute, ts.Add(2*time.Minute)); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("stale = %v", err)
+	}
+	if err := Verify("s3cret", http.Header{}, body, time.Minute, ts); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("missing = %v", err)
+	}
+
+	// 비밀 키 교체 중에는 여러 서명 중 하나만 맞으면 통과
+	header.Set(HeaderSignature, Sign("old", ts, body)+", "+Sign("s3cret", ts, body))
+	if err := Verify("s3cret", header, body, time.Minute, ts); err != nil {
+		t.Errorf("rotated signatures = %v", err)
+	}
+}
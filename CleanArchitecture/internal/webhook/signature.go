@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+// 전송 헤더
+const (
+	HeaderEventID   = "Webhook-Id"        // 이벤트 ID (재전송해도 같음, 수신 측 중복 제거용)
+	HeaderDelivery  = "Webhook-Delivery"  // 전송 ID
+	HeaderEvent     = "Webhook-Event"     // 이벤트 종류 (user.created 등)
+	HeaderTimestamp = "Webhook-Timestamp" // 서명 시각 (Unix 초)
+	HeaderSignature = "Webhook-Signature" // "v1=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+)
+
+const signatureVersion = "v1="
+
+var (
+	ErrMissingSignature = domain.NewError("missing_webhook_signature", "missing webhook signature")
+	ErrInvalidSignature = domain.NewError("invalid_webhook_signature", "invalid webhook signature")
+	ErrStaleTimestamp   = domain.NewError("stale_webhook_timestamp", "webhook timestamp outside tolerance")
+)
+
+// Sign - 서명 헤더 값 계산
+// 타임스탬프를 서명에 포함해 오래된 요청의 재사용(replay)을 막음
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify - 수신 측 서명 검증
+// tolerance 밖의 타임스탬프는 거부하고, 서명은 상수 시간으로 비교
+// 서명 헤더에 여러 값(쉼표 구분)이 있으면 하나만 맞아도 통과 (비밀 키 교체 기간용)
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	sigHeader := header.Get(HeaderSignature)
+	tsHeader := header.Get(HeaderTimestamp)
+	if sigHeader == "" || tsHeader == "" {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(tsHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	timestamp := time.Unix(unix, 0)
+	if d := now.Sub(timestamp); d > tolerance || d < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	want := []byte(Sign(secret, timestamp, body))
+	for _, sig := range strings.Split(sigHeader, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(sig)), want) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milman2/go-api/clean-architecture/internal/domain"
+)
+
+var (
+	ErrSubscriptionNotFound = domain.NewError("webhook_not_found", "webhook subscription not found")
+	ErrDeliveryNotFound     = domain.NewError("webhook_delivery_not_found", "webhook delivery not found")
+	ErrInvalidURL           = domain.NewError("invalid_webhook_url", "invalid webhook url")
+	ErrForbiddenDestination = domain.NewError("webhook_destination_forbidden", "webhook url points to a loopback, link-local or private address")
+	ErrInvalidEvent         = domain.NewError("invalid_webhook_event", "invalid webhook event type")
+	ErrSubscriptionDisabled = domain.NewError("webhook_disabled", "webhook subscription is disabled")
+)
+
+// Subscription - 웹훅 구독 (테넌트 소속)
+type Subscription struct {
+	ID       string
+	TenantID string
+	URL      string
+	Events   []domain.UserEventType // 비어 있으면 모든 이벤트
+	Secret   string
+
+	Active              bool
+	ConsecutiveFailures int        // 마지막 성공 이후 연속 실패 시도 수
+	DisabledAt          *time.Time // 연속 실패로 자동 비활성화된 시각
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Matches - 구독 대상 이벤트인지 확인
+func (s *Subscription) Matches(eventType domain.UserEventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus - 전송 상태
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // 전송 대기 또는 재시도 대기
+	DeliverySucceeded DeliveryStatus = "succeeded" // 2xx 응답
+	DeliveryFailed    DeliveryStatus = "failed"    // 재시도 소진 또는 구독 비활성화
+)
+
+// Attempt - 전송 시도 기록
+type Attempt struct {
+	At         time.Time
+	StatusCode int // 응답을 받지 못했으면 0
+	Error      string
+	Duration   time.Duration
+}
+
+// Delivery - 이벤트 하나를 구독 하나로 보내는 전송 (재시도 포함)
+type Delivery struct {
+	ID             string
+	TenantID       string
+	SubscriptionID string
+	EventID        string
+	EventType      domain.UserEventType
+	UserID         string // 이벤트 대상 사용자 (개인정보 내보내기/삭제에서 사용)
+	Payload        []byte
+
+	Status        DeliveryStatus
+	Attempts      []Attempt
+	NextAttemptAt time.Time
+	RedeliveryOf  string // 수동 재전송이면 원래 전송 ID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// SubscriptionUpdateFunc - 저장된 구독을 받아 수정 (에러를 반환하면 저장하지 않음)
+type SubscriptionUpdateFunc func(sub *Subscription) error
+
+// Store - 웹훅 구독/전송 기록 저장소 (포트)
+// 다른 테넌트의 항목을 조회/수정하면 domain.ErrCrossTenant
+type Store interface {
+	CreateSubscription(ctx context.Context, sub *Subscription) error
+	GetSubscription(ctx context.Context, tenantID, id string) (*Subscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error)
+	// UpdateSubscription - 구독 하나를 원자적으로 읽고-수정하고-쓰기
+	UpdateSubscription(ctx context.Context, tenantID, id string, fn SubscriptionUpdateFunc) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, id string) error
+
+	// SaveDelivery - 전송 기록 생성 또는 갱신
+	SaveDelivery(ctx context.Context, d *Delivery) error
+	GetDelivery(ctx context.Context, tenantID, id string) (*Delivery, error)
+	// ListDeliveries - 구독의 전송 기록 (최신순)
+	ListDeliveries(ctx context.Context, tenantID, subscriptionID string) ([]*Delivery, error)
+	// ListUserDeliveries - 사용자에 대한 이벤트의 전송 기록 (모든 구독, 오래된 순)
+	ListUserDeliveries(ctx context.Context, tenantID, userID string) ([]*Delivery, error)
+	// DueDeliveries - 전송 시각이 된 대기 중 전송 (오래된 순, 최대 limit개)
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+}
+
+// MemoryStore - 메모리 기반 저장소 (단일 인스턴스용)
+type MemoryStore struct {
+	mu            sync.Mutex
+	subscriptions map[string]*Subscription
+	deliveries    map[string]*Delivery
+}
+
+// NewMemoryStore - MemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		subscriptions: make(map[string]*Subscription),
+		deliveries:    make(map[string]*Delivery),
+	}
+}
+
+// CreateSubscription - 구독 생성
+func (s *MemoryStore) CreateSubscription(ctx context.Context, sub *Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions[sub.ID] = copySubscription(sub)
+	return nil
+}
+
+// GetSubscription - 구독 조회
+func (s *MemoryStore) GetSubscription(ctx context.Context, tenantID, id string) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, err := s.subscription(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return copySubscription(sub), nil
+}
+
+// ListSubscriptions - 테넌트의 구독 목록 (생성순)
+func (s *MemoryStore) ListSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := make([]*Subscription, 0)
+	for _, sub := range s.subscriptions {
+		if sub.TenantID == tenantID {
+			subs = append(subs, copySubscription(sub))
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+	return subs, nil
+}
+
+// UpdateSubscription - 구독 원자적 수정
+func (s *MemoryStore) UpdateSubscription(ctx context.Context, tenantID, id string, fn SubscriptionUpdateFunc) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, err := s.subscription(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := copySubscription(sub)
+	if err := fn(next); err != nil {
+		return nil, err
+	}
+	s.subscriptions[id] = next
+	return copySubscription(next), nil
+}
+
+// DeleteSubscription - 구독과 전송 기록 삭제
+func (s *MemoryStore) DeleteSubscription(ctx context.Context, tenantID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.subscription(tenantID, id); err != nil {
+		return err
+	}
+	delete(s.subscriptions, id)
+	for deliveryID, d := range s.deliveries {
+		if d.SubscriptionID == id {
+			delete(s.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// SaveDelivery - 전송 기록 저장
+func (s *MemoryStore) SaveDelivery(ctx context.Context, d *Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[d.ID] = copyDelivery(d)
+	return nil
+}
+
+// GetDelivery - 전송 기록 조회
+func (s *MemoryStore) GetDelivery(ctx context.Context, tenantID, id string) (*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	if d.TenantID != tenantID {
+		return nil, domain.ErrCrossTenant
+	}
+	return copyDelivery(d), nil
+}
+
+// ListDeliveries - 구독의 전송 기록 (최신순)
+func (s *MemoryStore) ListDeliveries(ctx context.Context, tenantID, subscriptionID string) ([]*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.subscription(tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*Delivery, 0)
+	for _, d := range s.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, copyDelivery(d))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+// ListUserDeliveries - 사용자에 대한 전송 기록 (오래된 순)
+func (s *MemoryStore) ListUserDeliveries(ctx context.Context, tenantID, userID string) ([]*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deliveries := make([]*Delivery, 0)
+	for _, d := range s.deliveries {
+		if d.TenantID == tenantID && d.UserID == userID {
+			deliveries = append(deliveries, copyDelivery(d))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+// DueDeliveries - 전송할 차례가 된 대기 중 전송
+func (s *MemoryStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]*Delivery, 0)
+	for _, d := range s.deliveries {
+		if d.Status == DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, copyDelivery(d))
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// subscription - 테넌트 확인 후 구독 조회 (호출자가 잠금 보유)
+func (s *MemoryStore) subscription(tenantID, id string) (*Subscription, error) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	if sub.TenantID != tenantID {
+		return nil, domain.ErrCrossTenant
+	}
+	return sub, nil
+}
+
+func copySubscription(sub *Subscription) *Subscription {
+	c := *sub
+	c.Events = append([]domain.UserEventType(nil), sub.Events...)
+	if sub.DisabledAt != nil {
+		t := *sub.DisabledAt
+		c.DisabledAt = &t
+	}
+	return &c
+}
+
+func copyDelivery(d *Delivery) *Delivery {
+	c := *d
+	c.Payload = append([]byte(nil), d.Payload...)
+	c.Attempts = append([]Attempt(nil), d.Attempts...)
+	return &c
+}